@@ -0,0 +1,191 @@
+package authentication
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+// UserDetailsCacheMetricsRecorder represents the methods used to record the usage of the cache of the details of users.
+type UserDetailsCacheMetricsRecorder interface {
+	RecordUserDetailsCache(result string)
+}
+
+// NewCachingUserProvider creates a new instance of CachingUserProvider which caches the details of users retrieved from
+// the provider. The UserDetailsCacheMetricsRecorder is optional.
+func NewCachingUserProvider(config schema.CacheAuthenticationBackendConfiguration, provider UserProvider, recorder UserDetailsCacheMetricsRecorder) *CachingUserProvider {
+	return &CachingUserProvider{
+		provider: provider,
+		size:     config.Size,
+		ttl:      config.TTL,
+		clock:    utils.RealClock{},
+		metrics:  recorder,
+		entries:  map[string]*list.Element{},
+		lru:      list.New(),
+	}
+}
+
+// CachingUserProvider is a provider which caches the details of users retrieved from another provider. The cache holds
+// up to the configured number of users, evicting the least recently used user when it's full, and the details of a
+// user expire after the configured TTL. Passwords are never cached.
+type CachingUserProvider struct {
+	provider UserProvider
+	size     int
+	ttl      time.Duration
+	clock    utils.Clock
+	metrics  UserDetailsCacheMetricsRecorder
+
+	group singleflight.Group
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+}
+
+type cachedUserDetails struct {
+	username string
+	details  *UserDetails
+	expires  time.Time
+}
+
+// CheckUserPassword checks if provided password matches for the given user.
+func (p *CachingUserProvider) CheckUserPassword(username string, password string) (valid bool, err error) {
+	return p.provider.CheckUserPassword(username, password)
+}
+
+// GetDetails retrieve the details of a user from the cache or from the provider if they aren't cached or have expired.
+// Concurrent misses for the same user share a single lookup from the provider. Errors aren't cached.
+func (p *CachingUserProvider) GetDetails(username string) (details *UserDetails, err error) {
+	if details = p.get(username); details != nil {
+		p.record(cacheResultHit)
+
+		return details, nil
+	}
+
+	p.record(cacheResultMiss)
+
+	var value interface{}
+
+	value, err, _ = p.group.Do(username, func() (interface{}, error) {
+		result, err := p.provider.GetDetails(username)
+		if err != nil {
+			return nil, err
+		}
+
+		p.set(username, result)
+
+		return result, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return value.(*UserDetails), nil
+}
+
+// UpdatePassword update the password of the given user and removes the user from the cache.
+func (p *CachingUserProvider) UpdatePassword(username string, newPassword string) (err error) {
+	defer p.Invalidate(username)
+
+	return p.provider.UpdatePassword(username, newPassword)
+}
+
+// CreateUser creates a new user if the provider is able to create users.
+func (p *CachingUserProvider) CreateUser(username, displayName, email, password string, groups []string) (err error) {
+	creator, ok := p.provider.(UserCreator)
+	if !ok {
+		return ErrUserCreationNotSupported
+	}
+
+	defer p.Invalidate(username)
+
+	return creator.CreateUser(username, displayName, email, password, groups)
+}
+
+// StartupCheck implements the startup check provider interface.
+func (p *CachingUserProvider) StartupCheck() (err error) {
+	return p.provider.StartupCheck()
+}
+
+// Watch implements the UserWatcher interface if the provider is able to watch its backend for changes.
+func (p *CachingUserProvider) Watch(ctx context.Context) {
+	if watcher, ok := p.provider.(UserWatcher); ok {
+		watcher.Watch(ctx)
+	}
+}
+
+// Invalidate removes the user from the cache. Lookups which are in progress are forgotten so later misses don't share
+// their result.
+func (p *CachingUserProvider) Invalidate(username string) {
+	p.group.Forget(username)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if element, ok := p.entries[username]; ok {
+		p.remove(element)
+	}
+}
+
+func (p *CachingUserProvider) get(username string) (details *UserDetails) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	element, ok := p.entries[username]
+	if !ok {
+		return nil
+	}
+
+	entry := element.Value.(*cachedUserDetails)
+
+	if !p.clock.Now().Before(entry.expires) {
+		p.remove(element)
+
+		return nil
+	}
+
+	p.lru.MoveToFront(element)
+
+	return entry.details
+}
+
+func (p *CachingUserProvider) set(username string, details *UserDetails) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	entry := &cachedUserDetails{username: username, details: details, expires: p.clock.Now().Add(p.ttl)}
+
+	if element, ok := p.entries[username]; ok {
+		element.Value = entry
+
+		p.lru.MoveToFront(element)
+
+		return
+	}
+
+	p.entries[username] = p.lru.PushFront(entry)
+
+	for p.lru.Len() > p.size {
+		p.remove(p.lru.Back())
+	}
+}
+
+// remove removes the element from the cache. The caller must hold the lock.
+func (p *CachingUserProvider) remove(element *list.Element) {
+	p.lru.Remove(element)
+
+	delete(p.entries, element.Value.(*cachedUserDetails).username)
+}
+
+func (p *CachingUserProvider) record(result string) {
+	if p.metrics != nil {
+		p.metrics.RecordUserDetailsCache(result)
+	}
+}
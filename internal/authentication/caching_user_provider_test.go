@@ -0,0 +1,219 @@
+package authentication
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+type cacheTestRecorder struct {
+	mu      sync.Mutex
+	results []string
+}
+
+func (r *cacheTestRecorder) RecordUserDetailsCache(result string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.results = append(r.results, result)
+}
+
+type cacheTestUserCreator struct {
+	UserProvider
+
+	created []string
+}
+
+func (c *cacheTestUserCreator) CreateUser(username, _, _, _ string, _ []string) (err error) {
+	c.created = append(c.created, username)
+
+	return nil
+}
+
+func TestCachingUserProviderShouldCacheDetails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mock := NewMockUserProvider(ctrl)
+	recorder := &cacheTestRecorder{}
+
+	clock := &utils.TestingClock{}
+	clock.Set(time.Unix(1000000, 0))
+
+	provider := NewCachingUserProvider(schema.CacheAuthenticationBackendConfiguration{Enable: true, Size: 10, TTL: time.Minute}, mock, recorder)
+	provider.clock = clock
+
+	mock.EXPECT().GetDetails("john").Return(&UserDetails{Username: "john", DisplayName: "John Doe", Groups: []string{"admins"}}, nil).Times(2)
+
+	for i := 0; i < 3; i++ {
+		details, err := provider.GetDetails("john")
+		require.NoError(t, err)
+		assert.Equal(t, "John Doe", details.DisplayName)
+		assert.Equal(t, []string{"admins"}, details.Groups)
+	}
+
+	clock.Set(clock.Now().Add(time.Minute))
+
+	_, err := provider.GetDetails("john")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{cacheResultMiss, cacheResultHit, cacheResultHit, cacheResultMiss}, recorder.results)
+}
+
+func TestCachingUserProviderShouldNotCacheErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mock := NewMockUserProvider(ctrl)
+	recorder := &cacheTestRecorder{}
+
+	provider := NewCachingUserProvider(schema.CacheAuthenticationBackendConfiguration{Enable: true, Size: 10, TTL: time.Minute}, mock, recorder)
+
+	mock.EXPECT().GetDetails("john").Return(nil, ErrUserNotFound).Times(2)
+
+	for i := 0; i < 2; i++ {
+		details, err := provider.GetDetails("john")
+		assert.ErrorIs(t, err, ErrUserNotFound)
+		assert.Nil(t, details)
+	}
+
+	assert.Equal(t, []string{cacheResultMiss, cacheResultMiss}, recorder.results)
+}
+
+func TestCachingUserProviderShouldShareConcurrentMisses(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mock := NewMockUserProvider(ctrl)
+
+	provider := NewCachingUserProvider(schema.CacheAuthenticationBackendConfiguration{Enable: true, Size: 10, TTL: time.Minute}, mock, nil)
+
+	started, release := make(chan struct{}), make(chan struct{})
+
+	mock.EXPECT().GetDetails("john").DoAndReturn(func(_ string) (*UserDetails, error) {
+		close(started)
+		<-release
+
+		return &UserDetails{Username: "john", DisplayName: "John Doe"}, nil
+	}).Times(1)
+
+	var wg sync.WaitGroup
+
+	results := make([]*UserDetails, 5)
+
+	for i := range results {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			details, err := provider.GetDetails("john")
+			assert.NoError(t, err)
+
+			results[i] = details
+		}(i)
+
+		if i == 0 {
+			<-started
+		}
+	}
+
+	time.Sleep(time.Millisecond * 50)
+	close(release)
+
+	wg.Wait()
+
+	for _, details := range results {
+		require.NotNil(t, details)
+		assert.Equal(t, "John Doe", details.DisplayName)
+	}
+}
+
+func TestCachingUserProviderShouldEvictLeastRecentlyUsed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mock := NewMockUserProvider(ctrl)
+	recorder := &cacheTestRecorder{}
+
+	provider := NewCachingUserProvider(schema.CacheAuthenticationBackendConfiguration{Enable: true, Size: 2, TTL: time.Minute}, mock, recorder)
+
+	gomock.InOrder(
+		mock.EXPECT().GetDetails("john").Return(&UserDetails{Username: "john"}, nil),
+		mock.EXPECT().GetDetails("harry").Return(&UserDetails{Username: "harry"}, nil),
+		mock.EXPECT().GetDetails("bob").Return(&UserDetails{Username: "bob"}, nil),
+		mock.EXPECT().GetDetails("harry").Return(&UserDetails{Username: "harry"}, nil),
+	)
+
+	for _, username := range []string{"john", "harry", "john", "bob", "john", "harry"} {
+		details, err := provider.GetDetails(username)
+		require.NoError(t, err)
+		assert.Equal(t, username, details.Username)
+	}
+
+	assert.Equal(t, []string{cacheResultMiss, cacheResultMiss, cacheResultHit, cacheResultMiss, cacheResultHit, cacheResultMiss}, recorder.results)
+	assert.Equal(t, 2, provider.lru.Len())
+}
+
+func TestCachingUserProviderShouldInvalidateOnUpdatePassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mock := NewMockUserProvider(ctrl)
+
+	provider := NewCachingUserProvider(schema.CacheAuthenticationBackendConfiguration{Enable: true, Size: 10, TTL: time.Minute}, mock, nil)
+
+	gomock.InOrder(
+		mock.EXPECT().GetDetails("john").Return(&UserDetails{Username: "john"}, nil),
+		mock.EXPECT().UpdatePassword("john", "newpassword").Return(nil),
+		mock.EXPECT().GetDetails("john").Return(&UserDetails{Username: "john"}, nil),
+	)
+
+	_, err := provider.GetDetails("john")
+	require.NoError(t, err)
+
+	require.NoError(t, provider.UpdatePassword("john", "newpassword"))
+
+	assert.NotContains(t, provider.entries, "john")
+
+	_, err = provider.GetDetails("john")
+	require.NoError(t, err)
+}
+
+func TestCachingUserProviderShouldCreateUsers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mock := NewMockUserProvider(ctrl)
+
+	// The mock doesn't implement UserCreator so users can't be created.
+	provider := NewCachingUserProvider(schema.CacheAuthenticationBackendConfiguration{Enable: true, Size: 10, TTL: time.Minute}, mock, nil)
+
+	assert.ErrorIs(t, provider.CreateUser("fred", "Fred", "fred@example.com", "password", nil), ErrUserCreationNotSupported)
+
+	creator := &cacheTestUserCreator{UserProvider: mock}
+
+	provider = NewCachingUserProvider(schema.CacheAuthenticationBackendConfiguration{Enable: true, Size: 10, TTL: time.Minute}, creator, nil)
+
+	gomock.InOrder(
+		mock.EXPECT().GetDetails("fred").Return(&UserDetails{Username: "fred", DisplayName: "Fred"}, nil),
+		mock.EXPECT().GetDetails("fred").Return(&UserDetails{Username: "fred", DisplayName: "Frederick"}, nil),
+	)
+
+	_, err := provider.GetDetails("fred")
+	require.NoError(t, err)
+
+	require.NoError(t, provider.CreateUser("fred", "Frederick", "fred@example.com", "password", nil))
+	assert.Equal(t, []string{"fred"}, creator.created)
+
+	details, err := provider.GetDetails("fred")
+	require.NoError(t, err)
+	assert.Equal(t, "Frederick", details.DisplayName)
+}
@@ -0,0 +1,207 @@
+package authentication
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/authelia/authelia/v4/internal/logging"
+)
+
+// ChainBackend is a backend in the chain of a ChainUserProvider.
+type ChainBackend struct {
+	// Name of the backend used in logs and errors.
+	Name string
+
+	// Prefix which qualifies the usernames owned by this backend, i.e. 'local\' for 'local\john'.
+	Prefix string
+
+	// Realm which qualifies the usernames owned by this backend, i.e. 'local' for 'john@local'.
+	Realm string
+
+	Provider UserProvider
+}
+
+// ChainUserProvider is a provider which delegates to a chain of providers. Usernames qualified with the prefix or realm
+// of a backend are only looked up in that backend, other usernames are looked up in order in the backends which don't
+// have a prefix or realm and the first backend which knows the user owns it. Only ErrUserNotFound moves on to the next
+// backend, any other error such as ErrAccountDisabled or an error connecting to the backend is returned immediately so
+// a user of a later backend with the same username can't take over the user.
+type ChainUserProvider struct {
+	backends []ChainBackend
+}
+
+// NewChainUserProvider creates a new instance of ChainUserProvider.
+func NewChainUserProvider(backends ...ChainBackend) *ChainUserProvider {
+	return &ChainUserProvider{
+		backends: backends,
+	}
+}
+
+// CheckUserPassword checks if provided password matches for the given user.
+func (p *ChainUserProvider) CheckUserPassword(username string, password string) (valid bool, err error) {
+	for _, candidate := range p.candidates(username) {
+		if valid, err = candidate.backend.Provider.CheckUserPassword(candidate.username, password); err == nil {
+			return valid, nil
+		}
+
+		if !errors.Is(err, ErrUserNotFound) {
+			logging.Logger().WithError(err).Errorf("Error occurred checking the password of user '%s' with the '%s' authentication backend", candidate.username, candidate.backend.Name)
+
+			return false, err
+		}
+	}
+
+	return false, ErrUserNotFound
+}
+
+// GetDetails retrieve the details of a user from the backend which owns the user. The username of the details is
+// qualified with the prefix or realm of the backend so the user is looked up in the same backend subsequently.
+func (p *ChainUserProvider) GetDetails(username string) (details *UserDetails, err error) {
+	for _, candidate := range p.candidates(username) {
+		if details, err = candidate.backend.Provider.GetDetails(candidate.username); err == nil {
+			details.Username = candidate.backend.qualify(details.Username)
+
+			return details, nil
+		}
+
+		if !errors.Is(err, ErrUserNotFound) {
+			logging.Logger().WithError(err).Errorf("Error occurred retrieving the details of user '%s' from the '%s' authentication backend", candidate.username, candidate.backend.Name)
+
+			return nil, err
+		}
+	}
+
+	return nil, ErrUserNotFound
+}
+
+// UpdatePassword update the password of the given user in the backend which owns the user.
+func (p *ChainUserProvider) UpdatePassword(username string, newPassword string) (err error) {
+	for _, candidate := range p.candidates(username) {
+		if err = candidate.backend.Provider.UpdatePassword(candidate.username, newPassword); err == nil {
+			return nil
+		}
+
+		if !errors.Is(err, ErrUserNotFound) {
+			return fmt.Errorf("error occurred updating the password of user '%s' with the '%s' authentication backend: %w", candidate.username, candidate.backend.Name, err)
+		}
+	}
+
+	return ErrUserNotFound
+}
+
+// CreateUser creates a new user in the first backend of the chain which is able to create users and doesn't have a
+// prefix or realm, provided none of the backends which may own the username already have the user.
+func (p *ChainUserProvider) CreateUser(username, displayName, email, password string, groups []string) (err error) {
+	if _, err = p.GetDetails(username); err == nil {
+		return ErrUserAlreadyExists
+	} else if !errors.Is(err, ErrUserNotFound) {
+		return err
+	}
+
+	for _, backend := range p.backends {
+		if backend.Prefix != "" || backend.Realm != "" {
+			continue
+		}
+
+		if creator, ok := backend.Provider.(UserCreator); ok {
+			if err = creator.CreateUser(username, displayName, email, password, groups); err != nil {
+				return fmt.Errorf("error occurred creating user '%s' with the '%s' authentication backend: %w", username, backend.Name, err)
+			}
+
+			return nil
+		}
+	}
+
+	return ErrUserCreationNotSupported
+}
+
+// StartupCheck implements the startup check provider interface by performing the startup check of every backend in the
+// chain and reporting the combined results.
+func (p *ChainUserProvider) StartupCheck() (err error) {
+	var failures []string
+
+	for _, backend := range p.backends {
+		if err = backend.Provider.StartupCheck(); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", backend.Name, err))
+		}
+	}
+
+	if len(failures) != 0 {
+		return fmt.Errorf("the following authentication backends had failures during the startup check: %s", strings.Join(failures, "; "))
+	}
+
+	return nil
+}
+
+// Watch implements the UserWatcher interface by watching every backend in the chain which is able to watch its backend
+// for changes until the context is done.
+func (p *ChainUserProvider) Watch(ctx context.Context) {
+	wg := sync.WaitGroup{}
+
+	for _, backend := range p.backends {
+		if watcher, ok := backend.Provider.(UserWatcher); ok {
+			wg.Add(1)
+
+			go func(watcher UserWatcher) {
+				defer wg.Done()
+
+				watcher.Watch(ctx)
+			}(watcher)
+		}
+	}
+
+	wg.Wait()
+}
+
+type chainCandidate struct {
+	backend  *ChainBackend
+	username string
+}
+
+// candidates returns the backends which may own the username along with the unqualified username for each.
+func (p *ChainUserProvider) candidates(username string) (candidates []chainCandidate) {
+	for i := range p.backends {
+		if unqualified, ok := p.backends[i].unqualify(username); ok {
+			return []chainCandidate{{backend: &p.backends[i], username: unqualified}}
+		}
+	}
+
+	for i := range p.backends {
+		if p.backends[i].Prefix == "" && p.backends[i].Realm == "" {
+			candidates = append(candidates, chainCandidate{backend: &p.backends[i], username: username})
+		}
+	}
+
+	return candidates
+}
+
+// unqualify returns the username with the prefix or realm of the backend removed and true if the username is qualified
+// with them, otherwise it returns false.
+func (b ChainBackend) unqualify(username string) (unqualified string, ok bool) {
+	if b.Prefix != "" && len(username) > len(b.Prefix) && strings.EqualFold(username[:len(b.Prefix)], b.Prefix) {
+		return username[len(b.Prefix):], true
+	}
+
+	if b.Realm != "" {
+		if i := strings.LastIndex(username, "@"); i > 0 && strings.EqualFold(username[i+1:], b.Realm) {
+			return username[:i], true
+		}
+	}
+
+	return "", false
+}
+
+// qualify returns the username qualified with the prefix or realm of the backend.
+func (b ChainBackend) qualify(username string) string {
+	switch {
+	case b.Prefix != "":
+		return b.Prefix + username
+	case b.Realm != "":
+		return username + "@" + b.Realm
+	default:
+		return username
+	}
+}
@@ -0,0 +1,172 @@
+package authentication
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/storage"
+)
+
+func TestChainUserProviderShouldRouteQualifiedUsernames(t *testing.T) {
+	WithDatabase(UserDatabaseContent, func(path string) {
+		config := DefaultFileAuthenticationBackendConfiguration
+		config.Path = path
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sqlConfig := DefaultSQLAuthenticationBackendConfiguration
+		mock := NewMockUserAccountProvider(ctrl)
+		sql := NewSQLUserProvider(&sqlConfig, mock)
+
+		provider := NewChainUserProvider(
+			ChainBackend{Name: "file", Prefix: "local\\", Provider: NewFileUserProvider(&config)},
+			ChainBackend{Name: "sql", Provider: sql},
+		)
+
+		mock.EXPECT().LoadUserAccount(context.Background(), "john").Return(nil, storage.ErrNoUserAccount)
+
+		valid, err := provider.CheckUserPassword("LOCAL\\john", "password")
+		assert.NoError(t, err)
+		assert.True(t, valid)
+
+		valid, err = provider.CheckUserPassword("local\\john", "wrong")
+		assert.NoError(t, err)
+		assert.False(t, valid)
+
+		// The unqualified username isn't looked up in the backend with a prefix.
+		valid, err = provider.CheckUserPassword("john", "password")
+		assert.ErrorIs(t, err, ErrUserNotFound)
+		assert.False(t, valid)
+
+		details, err := provider.GetDetails("local\\john")
+		require.NoError(t, err)
+		assert.Equal(t, "local\\john", details.Username)
+		assert.Equal(t, []string{"admins", "dev"}, details.Groups)
+
+		require.NoError(t, provider.UpdatePassword("local\\john", "newpassword"))
+
+		valid, err = provider.CheckUserPassword("local\\john", "newpassword")
+		assert.NoError(t, err)
+		assert.True(t, valid)
+	})
+}
+
+func TestChainUserProviderShouldTryBackendsInOrder(t *testing.T) {
+	WithDatabase(UserDatabaseContent, func(path string) {
+		config := DefaultFileAuthenticationBackendConfiguration
+		config.Path = path
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sqlConfig := DefaultSQLAuthenticationBackendConfiguration
+		mock := NewMockUserAccountProvider(ctrl)
+		sql := NewSQLUserProvider(&sqlConfig, mock)
+
+		provider := NewChainUserProvider(
+			ChainBackend{Name: "file", Provider: NewFileUserProvider(&config)},
+			ChainBackend{Name: "sql", Realm: "example.com", Provider: sql},
+		)
+
+		hash, err := HashPasswordWithConfig("password", sql.configuration.Password)
+		require.NoError(t, err)
+
+		mock.EXPECT().LoadUserAccount(context.Background(), "fred").Return(&model.UserAccount{Username: "fred", Password: hash, DisplayName: "Fred"}, nil).Times(2)
+		mock.EXPECT().LoadUserAccount(context.Background(), "sam").Return(nil, errors.New("database is locked"))
+		mock.EXPECT().UpdateUserAccountPassword(context.Background(), "fred", gomock.Any()).Return(nil)
+
+		valid, err := provider.CheckUserPassword("john", "password")
+		assert.NoError(t, err)
+		assert.True(t, valid)
+
+		valid, err = provider.CheckUserPassword("fred@example.com", "password")
+		assert.NoError(t, err)
+		assert.True(t, valid)
+
+		valid, err = provider.CheckUserPassword("sam@example.com", "password")
+		assert.EqualError(t, err, "unable to retrieve user account for user 'sam': database is locked")
+		assert.False(t, valid)
+
+		details, err := provider.GetDetails("fred@Example.com")
+		require.NoError(t, err)
+		assert.Equal(t, "fred@example.com", details.Username)
+		assert.Equal(t, "Fred", details.DisplayName)
+
+		details, err = provider.GetDetails("fred")
+		assert.ErrorIs(t, err, ErrUserNotFound)
+		assert.Nil(t, details)
+
+		assert.NoError(t, provider.UpdatePassword("fred@example.com", "newpassword"))
+		assert.ErrorIs(t, provider.UpdatePassword("fred", "newpassword"), ErrUserNotFound)
+
+		assert.NoError(t, provider.StartupCheck())
+	})
+}
+
+func TestChainUserProviderShouldNotFallThroughDisabledUsers(t *testing.T) {
+	WithDatabase(UserDatabaseWithDisabledContent, func(path string) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		config := DefaultFileAuthenticationBackendConfiguration
+		config.Path = path
+
+		// The LDAP backend has a user with the same username which must never be looked up.
+		ldap := NewMockUserProvider(ctrl)
+
+		provider := NewChainUserProvider(
+			ChainBackend{Name: "file", Provider: NewFileUserProvider(&config)},
+			ChainBackend{Name: "ldap", Provider: ldap},
+		)
+
+		valid, err := provider.CheckUserPassword("john", "password")
+		assert.ErrorIs(t, err, ErrAccountDisabled)
+		assert.False(t, valid)
+
+		details, err := provider.GetDetails("john")
+		assert.ErrorIs(t, err, ErrAccountDisabled)
+		assert.Nil(t, details)
+	})
+}
+
+func TestChainUserProviderShouldCreateUser(t *testing.T) {
+	WithDatabase(UserDatabaseContent, func(path string) {
+		config := DefaultFileAuthenticationBackendConfiguration
+		config.Path = path
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sqlConfig := DefaultSQLAuthenticationBackendConfiguration
+		mock := NewMockUserAccountProvider(ctrl)
+		sql := NewSQLUserProvider(&sqlConfig, mock)
+
+		provider := NewChainUserProvider(
+			ChainBackend{Name: "file", Prefix: "local\\", Provider: NewFileUserProvider(&config)},
+			ChainBackend{Name: "sql", Provider: sql},
+		)
+
+		gomock.InOrder(
+			mock.EXPECT().LoadUserAccount(context.Background(), "fred").Return(nil, storage.ErrNoUserAccount).Times(2),
+			mock.EXPECT().SaveUserAccount(context.Background(), gomock.Any()).Return(nil),
+			mock.EXPECT().LoadUserAccount(context.Background(), "sam").Return(&model.UserAccount{Username: "sam"}, nil),
+		)
+
+		require.NoError(t, provider.CreateUser("fred", "Fred", "fred@example.com", "password", nil))
+		assert.ErrorIs(t, provider.CreateUser("sam", "Sam", "sam@example.com", "password", nil), ErrUserAlreadyExists)
+
+		// Users aren't created in backends with a prefix or realm.
+		provider = NewChainUserProvider(
+			ChainBackend{Name: "file", Prefix: "local\\", Provider: NewFileUserProvider(&config)},
+		)
+
+		assert.ErrorIs(t, provider.CreateUser("fred", "Fred", "fred@example.com", "password", nil), ErrUserCreationNotSupported)
+	})
+}
@@ -2,6 +2,8 @@ package authentication
 
 import (
 	"errors"
+	"regexp"
+	"time"
 )
 
 // Level is the type representing a level of authentication.
@@ -60,9 +62,70 @@ const (
 	ldapOIDControlMsftServerPolicyHintsDeprecated = "1.2.840.113556.1.4.2066"
 )
 
+const (
+	ldapSupportedCapabilitiesAttribute = "supportedCapabilities"
+
+	// LDAP Capability OID: Microsoft Active Directory.
+	//
+	// MS ADTS: https://docs.microsoft.com/en-us/openspecs/windows_protocols/ms-adts/3ed61e6c-cfbd-4d1e-8a8f-b7b5a3a5bd3a
+	//
+	// OID Reference: https://oidref.com/1.2.840.113556.1.4.800
+	//
+	// See the linked documents for more information.
+	ldapOIDCapabilityActiveDirectory = "1.2.840.113556.1.4.800"
+
+	// LDAP Matching Rule OID: Microsoft LDAP_MATCHING_RULE_IN_CHAIN.
+	//
+	// MS ADTS: https://docs.microsoft.com/en-us/openspecs/windows_protocols/ms-adts/4e638665-f466-4597-93c4-12f2ebfabab5
+	//
+	// OID Reference: https://oidref.com/1.2.840.113556.1.4.1941
+	//
+	// See the linked documents for more information.
+	ldapOIDMatchingRuleInChain = "1.2.840.113556.1.4.1941"
+)
+
+const (
+	// ldapGroupsFilterInChain is the filter used to retrieve all of the groups the user is a member of either directly
+	// or through nested groups when the group search mode is in_chain.
+	ldapGroupsFilterInChain = "(&(member:" + ldapOIDMatchingRuleInChain + ":=" + ldapPlaceholderDistinguishedName + ")(objectClass=group))"
+)
+
+const (
+	// Microsoft Active Directory bind sub-error codes which are reported as the data value of the diagnostic message of
+	// an invalid credentials result. These are the hexadecimal representation of the Win32 system error codes.
+	//
+	// MS Docs: https://docs.microsoft.com/en-us/windows/win32/debug/system-error-codes
+	ldapADSubErrorPasswordExpired    = "532"
+	ldapADSubErrorAccountDisabled    = "533"
+	ldapADSubErrorAccountExpired     = "701"
+	ldapADSubErrorPasswordMustChange = "773"
+	ldapADSubErrorAccountLocked      = "775"
+)
+
+// reLDAPADSubError matches the sub-error code in the diagnostic message of a Microsoft Active Directory bind failure,
+// i.e. '80090308: LdapErr: DSID-0C09044E, comment: AcceptSecurityContext error, data 775, v2580'.
+var reLDAPADSubError = regexp.MustCompile(`AcceptSecurityContext error, data ([0-9a-fA-F]+),`)
+
 const (
 	ldapAttributeUnicodePwd   = "unicodePwd"
 	ldapAttributeUserPassword = "userPassword"
+
+	// ldapNoAttributes is the special attribute which requests no attributes to be returned by a search.
+	//
+	// RFC4511: https://datatracker.ietf.org/doc/html/rfc4511#section-4.5.1.8
+	ldapNoAttributes = "1.1"
+)
+
+const (
+	ldapPoolResultReused  = "reused"
+	ldapPoolResultDialed  = "dialed"
+	ldapPoolResultTimeout = "timeout"
+	ldapPoolResultError   = "error"
+)
+
+const (
+	cacheResultHit  = "hit"
+	cacheResultMiss = "miss"
 )
 
 const (
@@ -83,6 +146,12 @@ const (
 	HashingAlgorithmArgon2id CryptAlgo = argon2id
 	// HashingAlgorithmSHA512 SHA512 hash identifier.
 	HashingAlgorithmSHA512 CryptAlgo = "6"
+	// HashingAlgorithmBcrypt bcrypt hash identifier, the 2a and 2y identifiers are also accepted.
+	HashingAlgorithmBcrypt CryptAlgo = "2b"
+	// HashingAlgorithmScrypt scrypt hash identifier.
+	HashingAlgorithmScrypt CryptAlgo = "scrypt"
+	// HashingAlgorithmPBKDF2SHA256 PBKDF2-SHA256 hash identifier.
+	HashingAlgorithmPBKDF2SHA256 CryptAlgo = "pbkdf2-sha256"
 )
 
 // These are the default values from the upstream crypt module we use them to for GetInt
@@ -101,6 +170,31 @@ var HashingPossibleSaltCharacters = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQ
 // ErrUserNotFound indicates the user wasn't found in the authentication backend.
 var ErrUserNotFound = errors.New("user not found")
 
+var (
+	// ErrPasswordExpired indicates the password of the user is correct but has expired.
+	ErrPasswordExpired = errors.New("password expired")
+
+	// ErrPasswordMustChange indicates the password of the user is correct but must be changed before it can be used,
+	// usually because it was set by an administrator.
+	ErrPasswordMustChange = errors.New("password must be changed")
+
+	// ErrAccountLocked indicates the account of the user is locked.
+	ErrAccountLocked = errors.New("account locked")
+
+	// ErrAccountDisabled indicates the account of the user is disabled or has expired.
+	ErrAccountDisabled = errors.New("account disabled")
+
+	// ErrPasswordReused indicates the new password of the user matches one of the previous passwords of the user.
+	ErrPasswordReused = errors.New("password was used previously")
+
+	// ErrUserAlreadyExists indicates a user can't be created as the authentication backend already has a user with the
+	// same username.
+	ErrUserAlreadyExists = errors.New("user already exists")
+
+	// ErrUserCreationNotSupported indicates the authentication backend isn't able to create users.
+	ErrUserCreationNotSupported = errors.New("the authentication backend doesn't support creating users")
+)
+
 const argon2id = "argon2id"
 const sha512 = "sha512"
 
@@ -108,6 +202,9 @@ const testPassword = "my;secure*password"
 
 const fileAuthenticationMode = 0600
 
+// fileWatchInterval is the interval the users database file is checked for changes when it's watched.
+const fileWatchInterval = time.Second * 5
+
 // OWASP recommends to escape some special characters.
 // https://github.com/OWASP/CheatSheetSeries/blob/master/cheatsheets/LDAP_Injection_Prevention_Cheat_Sheet.md
 const specialLDAPRunes = ",#+<>;\"="
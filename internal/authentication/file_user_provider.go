@@ -1,11 +1,14 @@
 package authentication
 
 import (
+	"context"
 	_ "embed" // Embed users_database.template.yml.
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/asaskevich/govalidator"
 	"gopkg.in/yaml.v3"
@@ -18,7 +21,12 @@ import (
 type FileUserProvider struct {
 	configuration *schema.FileAuthenticationBackendConfiguration
 	database      *DatabaseModel
-	lock          *sync.Mutex
+	lock          *sync.RWMutex
+
+	// modified and size are the modification time and size of the database file when it was last read or written
+	// which are used to detect changes when the file is watched.
+	modified time.Time
+	size     int64
 }
 
 // UserDetailsModel is the model of user details in the file database.
@@ -27,6 +35,33 @@ type UserDetailsModel struct {
 	DisplayName    string   `yaml:"displayname" valid:"required"`
 	Email          string   `yaml:"email"`
 	Groups         []string `yaml:"groups"`
+	Disabled       bool     `yaml:"disabled,omitempty"`
+
+	Attributes map[string]UserAttributeValues `yaml:"attributes,omitempty"`
+}
+
+// UserAttributeValues is the model of the values of an extra attribute in the file database. It may be written either
+// as a single value or as a list of values.
+type UserAttributeValues []string
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (v *UserAttributeValues) UnmarshalYAML(value *yaml.Node) (err error) {
+	switch value.Kind {
+	case yaml.ScalarNode:
+		*v = UserAttributeValues{value.Value}
+
+		return nil
+	default:
+		var values []string
+
+		if err = value.Decode(&values); err != nil {
+			return err
+		}
+
+		*v = values
+
+		return nil
+	}
 }
 
 // DatabaseModel is the model of users file database.
@@ -59,11 +94,17 @@ func NewFileUserProvider(configuration *schema.FileAuthenticationBackendConfigur
 		panic(err)
 	}
 
-	return &FileUserProvider{
+	provider := &FileUserProvider{
 		configuration: configuration,
 		database:      database,
-		lock:          &sync.Mutex{},
+		lock:          &sync.RWMutex{},
+	}
+
+	if info, err := os.Stat(configuration.Path); err == nil {
+		provider.modified, provider.size = info.ModTime(), info.Size()
 	}
+
+	return provider
 }
 
 func checkPasswordHashes(database *DatabaseModel) error {
@@ -139,73 +180,352 @@ func readDatabase(path string) (*DatabaseModel, error) {
 	return &db, nil
 }
 
-// CheckUserPassword checks if provided password matches for the given user.
-func (p *FileUserProvider) CheckUserPassword(username string, password string) (bool, error) {
-	if details, ok := p.database.Users[username]; ok {
-		ok, err := CheckPassword(password, details.HashedPassword)
-		if err != nil {
-			return false, err
+func writeDatabase(path string, database *DatabaseModel) (err error) {
+	var (
+		data []byte
+		file *os.File
+	)
+
+	if data, err = yaml.Marshal(database); err != nil {
+		return err
+	}
+
+	// Resolve symbolic links so the file they point to is replaced instead of the link.
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	} else if !os.IsNotExist(err) {
+		return fmt.Errorf("Unable to resolve database file: %w", err)
+	}
+
+	// Write to a temporary file in the same directory and rename it over the database so the database file is never
+	// partially written.
+	if file, err = os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*"); err != nil {
+		return fmt.Errorf("Unable to create temporary database file: %w", err)
+	}
+
+	if err = file.Chmod(fileAuthenticationMode); err == nil {
+		if _, err = file.Write(data); err == nil {
+			err = file.Sync()
 		}
+	}
+
+	if errClose := file.Close(); err == nil {
+		err = errClose
+	}
+
+	if err != nil {
+		_ = os.Remove(file.Name())
+
+		return fmt.Errorf("Unable to write temporary database file: %w", err)
+	}
+
+	if err = os.Rename(file.Name(), path); err != nil {
+		_ = os.Remove(file.Name())
+
+		return fmt.Errorf("Unable to replace database file: %w", err)
+	}
+
+	return nil
+}
+
+// ReadFileUserDatabase reads the users database file at the given path and validates it the same way the
+// FileUserProvider does at startup. Unlike the FileUserProvider a database isn't generated if the file doesn't exist.
+func ReadFileUserDatabase(path string) (database *DatabaseModel, err error) {
+	return loadDatabase(path)
+}
 
-		return ok, nil
+// WriteFileUserDatabase atomically writes the users database to the file at the given path.
+func WriteFileUserDatabase(path string, database *DatabaseModel) (err error) {
+	return writeDatabase(path, database)
+}
+
+// CheckUserPassword checks if provided password matches for the given user. If the password matches but the hash doesn't
+// use the configured algorithm and parameters the password is rehashed so hashes are upgraded over time. If the user is
+// disabled ErrAccountDisabled is returned when the password matches.
+func (p *FileUserProvider) CheckUserPassword(username string, password string) (bool, error) {
+	p.lock.RLock()
+	details, ok := p.database.Users[username]
+	p.lock.RUnlock()
+
+	if !ok {
+		return false, ErrUserNotFound
+	}
+
+	ok, rehash, err := CheckPasswordWithConfig(password, details.HashedPassword, p.configuration.Password)
+	if err != nil {
+		return false, err
+	}
+
+	if ok && details.Disabled {
+		return false, ErrAccountDisabled
+	}
+
+	if rehash {
+		p.rehash(username, password, details.HashedPassword)
+	}
+
+	return ok, nil
+}
+
+// rehash replaces the hash of the password of the user with a hash using the configured algorithm and parameters
+// provided the hash hasn't changed since the password was checked. Failures are logged as the password was valid.
+func (p *FileUserProvider) rehash(username, password, hash string) {
+	logger := logging.Logger()
+
+	newHash, err := HashPasswordWithConfig(password, p.configuration.Password)
+	if err != nil {
+		logger.WithError(err).Errorf("Error occurred rehashing the password of user '%s'", username)
+
+		return
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if err = p.update(); err != nil {
+		logger.WithError(err).Errorf("Error occurred saving the rehashed password of user '%s'", username)
+
+		return
+	}
+
+	details, ok := p.database.Users[username]
+	if !ok || details.HashedPassword != hash {
+		return
+	}
+
+	details.HashedPassword = newHash
+
+	p.database.Users[username] = details
+
+	if err = p.write(); err != nil {
+		logger.WithError(err).Errorf("Error occurred saving the rehashed password of user '%s'", username)
+
+		return
 	}
 
-	return false, ErrUserNotFound
+	logger.Debugf("Rehashed the password of user '%s' with the configured algorithm and parameters", username)
 }
 
-// GetDetails retrieve the groups a user belongs to.
+// GetDetails retrieve the groups a user belongs to. If the user is disabled ErrAccountDisabled is returned.
 func (p *FileUserProvider) GetDetails(username string) (*UserDetails, error) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
 	if details, ok := p.database.Users[username]; ok {
+		if details.Disabled {
+			return nil, ErrAccountDisabled
+		}
+
 		return &UserDetails{
 			Username:    username,
 			DisplayName: details.DisplayName,
 			Groups:      details.Groups,
 			Emails:      []string{details.Email},
+			Attributes:  p.getAttributes(details),
 		}, nil
 	}
 
-	return nil, fmt.Errorf("User '%s' does not exist in database", username)
+	return nil, ErrUserNotFound
+}
+
+func (p *FileUserProvider) getAttributes(details UserDetailsModel) (attributes map[string][]string) {
+	for _, extra := range p.configuration.ExtraAttributes {
+		for name, values := range details.Attributes {
+			if !strings.EqualFold(name, extra) {
+				continue
+			}
+
+			if attributes == nil {
+				attributes = map[string][]string{}
+			}
+
+			attributes[strings.ToLower(extra)] = values
+		}
+	}
+
+	return attributes
 }
 
 // UpdatePassword update the password of the given user.
 func (p *FileUserProvider) UpdatePassword(username string, newPassword string) error {
+	hash, err := HashPasswordWithConfig(newPassword, p.configuration.Password)
+	if err != nil {
+		return err
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if err = p.update(); err != nil {
+		return err
+	}
+
 	details, ok := p.database.Users[username]
 	if !ok {
 		return ErrUserNotFound
 	}
 
-	algorithm, err := ConfigAlgoToCryptoAlgo(p.configuration.Password.Algorithm)
+	details.HashedPassword = hash
+
+	p.database.Users[username] = details
+
+	return p.write()
+}
+
+// CreateUser creates a new user with the password hashed using the configured algorithm and parameters.
+func (p *FileUserProvider) CreateUser(username, displayName, email, password string, groups []string) (err error) {
+	hash, err := HashPasswordWithConfig(password, p.configuration.Password)
 	if err != nil {
 		return err
 	}
 
-	hash, err := HashPassword(
-		newPassword, "", algorithm, p.configuration.Password.Iterations,
-		p.configuration.Password.Memory*1024, p.configuration.Password.Parallelism,
-		p.configuration.Password.KeyLength, p.configuration.Password.SaltLength)
+	p.lock.Lock()
+	defer p.lock.Unlock()
 
-	if err != nil {
+	if err = p.update(); err != nil {
 		return err
 	}
 
-	details.HashedPassword = hash
+	if _, ok := p.database.Users[username]; ok {
+		return ErrUserAlreadyExists
+	}
 
-	p.lock.Lock()
-	p.database.Users[username] = details
+	if p.database.Users == nil {
+		p.database.Users = map[string]UserDetailsModel{}
+	}
+
+	p.database.Users[username] = UserDetailsModel{
+		HashedPassword: hash,
+		DisplayName:    displayName,
+		Email:          email,
+		Groups:         groups,
+	}
+
+	if err = p.write(); err != nil {
+		delete(p.database.Users, username)
+
+		return err
+	}
+
+	return nil
+}
+
+// changed returns the state of the database file and whether it has changed since it was last read or written. The
+// caller must hold a lock.
+func (p *FileUserProvider) changed() (info os.FileInfo, changed bool, err error) {
+	if info, err = os.Stat(p.configuration.Path); err != nil {
+		return nil, false, fmt.Errorf("Unable to find database file: %w", err)
+	}
+
+	return info, !info.ModTime().Equal(p.modified) || info.Size() != p.size, nil
+}
 
-	b, err := yaml.Marshal(p.database)
+// update reads the database file if it has changed since it was last read or written so changes made to the file, for
+// example by an administrator, aren't overwritten when the database is modified and written. The caller must hold the
+// write lock.
+func (p *FileUserProvider) update() (err error) {
+	info, changed, err := p.changed()
+	if err != nil || !changed {
+		return err
+	}
+
+	database, err := loadDatabase(p.configuration.Path)
 	if err != nil {
-		p.lock.Unlock()
+		return fmt.Errorf("Unable to reload the changed database file: %w", err)
+	}
+
+	p.database = database
+	p.modified, p.size = info.ModTime(), info.Size()
+
+	logging.Logger().Infof("Reloaded the users database file '%s' with %d users", p.configuration.Path, len(database.Users))
+
+	return nil
+}
+
+// write writes the database to the file and records the state of the file so the write isn't detected as a change. The
+// caller must hold the write lock.
+func (p *FileUserProvider) write() (err error) {
+	if err = writeDatabase(p.configuration.Path, p.database); err != nil {
 		return err
 	}
 
-	err = os.WriteFile(p.configuration.Path, b, fileAuthenticationMode)
-	p.lock.Unlock()
+	if info, err := os.Stat(p.configuration.Path); err == nil {
+		p.modified, p.size = info.ModTime(), info.Size()
+	}
 
-	return err
+	return nil
 }
 
 // StartupCheck implements the startup check provider interface.
 func (p *FileUserProvider) StartupCheck() (err error) {
 	return nil
 }
+
+// Watch periodically checks the database file for changes and reloads it when it has changed until the context is
+// done. It returns immediately if the watch option isn't enabled.
+func (p *FileUserProvider) Watch(ctx context.Context) {
+	if !p.configuration.Watch {
+		return
+	}
+
+	ticker := time.NewTicker(fileWatchInterval)
+
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := p.reload(); err != nil {
+				logging.Logger().WithError(err).Errorf("Error occurred reloading the users database file '%s', the previously loaded users database will continue to be used", p.configuration.Path)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// reload reads the database file if it has changed since it was last read or written. The database is validated the
+// same way it is at startup, except a missing file is an error rather than being generated from the template, and only
+// replaces the current database if it's valid and the file hasn't changed or been
+// written while it was read, otherwise it's read again the next time it's checked.
+func (p *FileUserProvider) reload() (reloaded bool, err error) {
+	p.lock.RLock()
+	info, changed, err := p.changed()
+	p.lock.RUnlock()
+
+	if err != nil || !changed {
+		return false, err
+	}
+
+	database, err := loadDatabase(p.configuration.Path)
+	if err != nil {
+		return false, err
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if current, err := os.Stat(p.configuration.Path); err != nil || !current.ModTime().Equal(info.ModTime()) || current.Size() != info.Size() {
+		return false, nil
+	}
+
+	p.database = database
+	p.modified, p.size = info.ModTime(), info.Size()
+
+	logging.Logger().Infof("Reloaded the users database file '%s' with %d users", p.configuration.Path, len(database.Users))
+
+	return true, nil
+}
+
+// loadDatabase reads the database file and validates it the same way it is at startup.
+func loadDatabase(path string) (database *DatabaseModel, err error) {
+	if database, err = readDatabase(path); err != nil {
+		return nil, err
+	}
+
+	if err = checkPasswordHashes(database); err != nil {
+		return nil, err
+	}
+
+	return database, nil
+}
@@ -1,11 +1,14 @@
 package authentication
 
 import (
+	"context"
 	"log"
 	"os"
+	"path/filepath"
 	"runtime"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -132,6 +135,47 @@ func TestShouldRetrieveUserDetails(t *testing.T) {
 	})
 }
 
+func TestShouldRetrieveUserDetailsExtraAttributes(t *testing.T) {
+	WithDatabase(UserDatabaseWithAttributesContent, func(path string) {
+		config := DefaultFileAuthenticationBackendConfiguration
+		config.Path = path
+		config.ExtraAttributes = []string{"Department", "employeeType"}
+		provider := NewFileUserProvider(&config)
+
+		details, err := provider.GetDetails("john")
+		assert.NoError(t, err)
+		assert.Equal(t, map[string][]string{"department": {"engineering"}, "employeetype": {"staff", "oncall"}}, details.Attributes)
+
+		details, err = provider.GetDetails("harry")
+		assert.NoError(t, err)
+		assert.Nil(t, details.Attributes)
+	})
+}
+
+func TestShouldNotAuthenticateDisabledUser(t *testing.T) {
+	WithDatabase(UserDatabaseWithDisabledContent, func(path string) {
+		config := DefaultFileAuthenticationBackendConfiguration
+		config.Path = path
+		provider := NewFileUserProvider(&config)
+
+		ok, err := provider.CheckUserPassword("john", "password")
+		assert.ErrorIs(t, err, ErrAccountDisabled)
+		assert.False(t, ok)
+
+		ok, err = provider.CheckUserPassword("john", "wrong_password")
+		assert.NoError(t, err)
+		assert.False(t, ok)
+
+		details, err := provider.GetDetails("john")
+		assert.ErrorIs(t, err, ErrAccountDisabled)
+		assert.Nil(t, details)
+
+		ok, err = provider.CheckUserPassword("harry", "password")
+		assert.NoError(t, err)
+		assert.True(t, ok)
+	})
+}
+
 func TestShouldUpdatePassword(t *testing.T) {
 	WithDatabase(UserDatabaseContent, func(path string) {
 		config := DefaultFileAuthenticationBackendConfiguration
@@ -188,6 +232,237 @@ func TestShouldUpdatePasswordHashingAlgorithmToSHA512(t *testing.T) {
 	})
 }
 
+func TestShouldUpdatePasswordAtomically(t *testing.T) {
+	WithDatabase(UserDatabaseContent, func(path string) {
+		config := DefaultFileAuthenticationBackendConfiguration
+		config.Path = path
+		provider := NewFileUserProvider(&config)
+
+		require.NoError(t, provider.UpdatePassword("harry", "newpassword"))
+
+		entries, err := os.ReadDir(filepath.Dir(path))
+		require.NoError(t, err)
+
+		for _, entry := range entries {
+			assert.False(t, strings.HasPrefix(entry.Name(), "."+filepath.Base(path)), "temporary file %s was not removed", entry.Name())
+		}
+
+		if runtime.GOOS != "windows" {
+			info, err := os.Stat(path)
+			require.NoError(t, err)
+
+			assert.Equal(t, os.FileMode(fileAuthenticationMode), info.Mode().Perm())
+		}
+
+		// The write made by the provider isn't detected as a change.
+		reloaded, err := provider.reload()
+		assert.NoError(t, err)
+		assert.False(t, reloaded)
+	})
+}
+
+func TestShouldNotOverwriteChangesToDatabaseFile(t *testing.T) {
+	WithDatabase(UserDatabaseContent, func(path string) {
+		config := DefaultFileAuthenticationBackendConfiguration
+		config.Path = path
+		provider := NewFileUserProvider(&config)
+
+		require.NoError(t, os.WriteFile(path, UserDatabaseWithDisabledContent, fileAuthenticationMode))
+		require.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(time.Minute)))
+
+		require.NoError(t, provider.UpdatePassword("harry", "newpassword"))
+
+		database, err := readDatabase(path)
+		require.NoError(t, err)
+
+		assert.True(t, database.Users["john"].Disabled)
+		assert.NotContains(t, database.Users, "bob")
+		assert.True(t, strings.HasPrefix(database.Users["harry"].HashedPassword, "$argon2id$"))
+
+		require.NoError(t, os.WriteFile(path, MalformedUserDatabaseContent, fileAuthenticationMode))
+		require.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(time.Hour)))
+
+		assert.EqualError(t, provider.UpdatePassword("harry", "password"), "Unable to reload the changed database file: Unable to parse database: yaml: line 4: mapping values are not allowed in this context")
+
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, MalformedUserDatabaseContent, content)
+	})
+}
+
+func TestShouldUpdatePasswordThroughSymlink(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("skipping test due to being on windows")
+	}
+
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "users_database.yml")
+	link := filepath.Join(dir, "users_database.link.yml")
+
+	require.NoError(t, os.WriteFile(path, UserDatabaseContent, fileAuthenticationMode))
+	require.NoError(t, os.Symlink(path, link))
+
+	config := DefaultFileAuthenticationBackendConfiguration
+	config.Path = link
+	provider := NewFileUserProvider(&config)
+
+	require.NoError(t, provider.UpdatePassword("harry", "newpassword"))
+
+	info, err := os.Lstat(link)
+	require.NoError(t, err)
+	assert.Equal(t, os.ModeSymlink, info.Mode()&os.ModeSymlink)
+
+	database, err := readDatabase(path)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(database.Users["harry"].HashedPassword, "$argon2id$"))
+}
+
+func TestShouldCreateUser(t *testing.T) {
+	WithDatabase(UserDatabaseContent, func(path string) {
+		config := DefaultFileAuthenticationBackendConfiguration
+		config.Path = path
+		provider := NewFileUserProvider(&config)
+
+		require.NoError(t, provider.CreateUser("fred", "Fred", "fred@example.com", "password", []string{"users"}))
+		assert.ErrorIs(t, provider.CreateUser("harry", "Harry", "harry@example.com", "password", nil), ErrUserAlreadyExists)
+
+		// Reset the provider to force a read from disk.
+		provider = NewFileUserProvider(&config)
+		ok, err := provider.CheckUserPassword("fred", "password")
+		assert.NoError(t, err)
+		assert.True(t, ok)
+
+		details, err := provider.GetDetails("fred")
+		require.NoError(t, err)
+		assert.Equal(t, "Fred", details.DisplayName)
+		assert.Equal(t, []string{"fred@example.com"}, details.Emails)
+		assert.Equal(t, []string{"users"}, details.Groups)
+
+		assert.Equal(t, "Harry Potter", provider.database.Users["harry"].DisplayName)
+	})
+}
+
+func TestShouldReloadDatabaseWhenChanged(t *testing.T) {
+	WithDatabase(UserDatabaseContent, func(path string) {
+		config := DefaultFileAuthenticationBackendConfiguration
+		config.Path = path
+		provider := NewFileUserProvider(&config)
+
+		reloaded, err := provider.reload()
+		assert.NoError(t, err)
+		assert.False(t, reloaded)
+
+		require.NoError(t, os.WriteFile(path, UserDatabaseWithAttributesContent, fileAuthenticationMode))
+		require.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(time.Minute)))
+
+		reloaded, err = provider.reload()
+		assert.NoError(t, err)
+		assert.True(t, reloaded)
+
+		details, err := provider.GetDetails("john")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"admins"}, details.Groups)
+
+		_, err = provider.GetDetails("bob")
+		assert.ErrorIs(t, err, ErrUserNotFound)
+	})
+}
+
+func TestShouldNotReloadInvalidDatabase(t *testing.T) {
+	WithDatabase(UserDatabaseContent, func(path string) {
+		config := DefaultFileAuthenticationBackendConfiguration
+		config.Path = path
+		provider := NewFileUserProvider(&config)
+
+		require.NoError(t, os.WriteFile(path, BadSHA512HashContent, fileAuthenticationMode))
+		require.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(time.Minute)))
+
+		reloaded, err := provider.reload()
+		assert.EqualError(t, err, "Unable to parse hash of user john: Hash key is not the last parameter, the hash is likely malformed ($6$rounds00000$jgiCMRyGXzoqpxS3$w2pJeZnnH8bwW3zzvoMWtTRfQYsHbWbD/hquuQ5vUeIyl9gdwBIt6RWk2S6afBA0DPakbeWgD/4SZPiS0hYtU/)")
+		assert.False(t, reloaded)
+
+		details, err := provider.GetDetails("bob")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"dev"}, details.Groups)
+
+		require.NoError(t, os.WriteFile(path, MalformedUserDatabaseContent, fileAuthenticationMode))
+		require.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(time.Hour)))
+
+		reloaded, err = provider.reload()
+		assert.EqualError(t, err, "Unable to parse database: yaml: line 4: mapping values are not allowed in this context")
+		assert.False(t, reloaded)
+	})
+}
+
+func TestShouldStopWatchingDatabaseWhenContextDone(t *testing.T) {
+	WithDatabase(UserDatabaseContent, func(path string) {
+		config := DefaultFileAuthenticationBackendConfiguration
+		config.Path = path
+		config.Watch = true
+		provider := NewFileUserProvider(&config)
+
+		ctx, cancel := context.WithCancel(context.Background())
+
+		done := make(chan struct{})
+
+		go func() {
+			provider.Watch(ctx)
+
+			close(done)
+		}()
+
+		cancel()
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("the watcher didn't stop when the context was done")
+		}
+	})
+}
+
+func TestShouldRehashPasswordOnLogin(t *testing.T) {
+	WithDatabase(UserDatabaseWithAdditionalAlgorithmsContent, func(path string) {
+		config := DefaultFileAuthenticationBackendConfiguration
+		config.Path = path
+		provider := NewFileUserProvider(&config)
+
+		for _, username := range []string{"john", "harry", "bob"} {
+			ok, err := provider.CheckUserPassword(username, "password")
+			assert.NoError(t, err)
+			assert.True(t, ok)
+
+			assert.True(t, strings.HasPrefix(provider.database.Users[username].HashedPassword, "$argon2id$"))
+		}
+
+		// Reset the provider to force a read from disk.
+		provider = NewFileUserProvider(&config)
+
+		for _, username := range []string{"john", "harry", "bob"} {
+			assert.True(t, strings.HasPrefix(provider.database.Users[username].HashedPassword, "$argon2id$"))
+
+			ok, err := provider.CheckUserPassword(username, "password")
+			assert.NoError(t, err)
+			assert.True(t, ok)
+		}
+	})
+}
+
+func TestShouldNotRehashPasswordOnFailedLogin(t *testing.T) {
+	WithDatabase(UserDatabaseWithAdditionalAlgorithmsContent, func(path string) {
+		config := DefaultFileAuthenticationBackendConfiguration
+		config.Path = path
+		provider := NewFileUserProvider(&config)
+
+		ok, err := provider.CheckUserPassword("john", "wrong")
+		assert.NoError(t, err)
+		assert.False(t, ok)
+
+		assert.True(t, strings.HasPrefix(provider.database.Users["john"].HashedPassword, "$2b$"))
+	})
+}
+
 func TestShouldRaiseWhenLoadingMalformedDatabaseForFirstTime(t *testing.T) {
 	WithDatabase(MalformedUserDatabaseContent, func(path string) {
 		config := DefaultFileAuthenticationBackendConfiguration
@@ -309,6 +584,45 @@ users:
     email: james.dean@authelia.com
 `)
 
+var UserDatabaseWithAttributesContent = []byte(`
+users:
+  john:
+    displayname: "John Doe"
+    password: "{CRYPT}$argon2id$v=19$m=65536,t=3,p=2$BpLnfgDsc2WD8F2q$o/vzA4myCqZZ36bUGsDY//8mKUYNZZaR0t4MFFSs+iM"
+    email: john.doe@authelia.com
+    groups:
+      - admins
+    attributes:
+      department: engineering
+      employeeType:
+        - staff
+        - oncall
+      location: paris
+
+  harry:
+    displayname: "Harry Potter"
+    password: "{CRYPT}$6$rounds=500000$jgiCMRyGXzoqpxS3$w2pJeZnnH8bwW3zzvoMWtTRfQYsHbWbD/hquuQ5vUeIyl9gdwBIt6RWk2S6afBA0DPakbeWgD/4SZPiS0hYtU/"
+    email: harry.potter@authelia.com
+    groups: []
+`)
+
+var UserDatabaseWithDisabledContent = []byte(`
+users:
+  john:
+    displayname: "John Doe"
+    password: "{CRYPT}$argon2id$v=19$m=65536,t=3,p=2$BpLnfgDsc2WD8F2q$o/vzA4myCqZZ36bUGsDY//8mKUYNZZaR0t4MFFSs+iM"
+    email: john.doe@authelia.com
+    groups:
+      - admins
+    disabled: true
+
+  harry:
+    displayname: "Harry Potter"
+    password: "{CRYPT}$6$rounds=500000$jgiCMRyGXzoqpxS3$w2pJeZnnH8bwW3zzvoMWtTRfQYsHbWbD/hquuQ5vUeIyl9gdwBIt6RWk2S6afBA0DPakbeWgD/4SZPiS0hYtU/"
+    email: harry.potter@authelia.com
+    groups: []
+`)
+
 var MalformedUserDatabaseContent = []byte(`
 users
 john
@@ -395,3 +709,21 @@ users:
       - admins
       - dev
 `)
+
+var UserDatabaseWithAdditionalAlgorithmsContent = []byte(`
+users:
+  john:
+    displayname: "John Doe"
+    password: "$2b$10$hbmlHQoYjbqQvfa3CrVwXe/7FdEJWnrVlCtrtl/RPTUMInVhutTSi"
+    email: john.doe@authelia.com
+
+  harry:
+    displayname: "Harry Potter"
+    password: "$scrypt$ln=14,r=8,p=1$YXV0aGVsaWEtc2NyeXB0IQ$Egu3uhcQ9I7HHRLHS0dvOkHUOw1q/FPRY20dsH.tMmk"
+    email: harry.potter@authelia.com
+
+  bob:
+    displayname: "Bob Dylan"
+    password: "{CRYPT}$pbkdf2-sha256$29000$YXV0aGVsaWEtcGJrZGYyIQ$zg9Fen9cXyD4Ct5dryfTgwq6N2tayPqEPXekUldKQCM"
+    email: bob.dylan@authelia.com
+`)
@@ -5,3 +5,6 @@ package authentication
 
 //go:generate mockgen -package authentication -destination ldap_client_mock.go -mock_names LDAPClient=MockLDAPClient github.com/authelia/authelia/v4/internal/authentication LDAPClient
 //go:generate mockgen -package authentication -destination ldap_client_factory_mock.go -mock_names LDAPClientFactory=MockLDAPClientFactory github.com/authelia/authelia/v4/internal/authentication LDAPClientFactory
+//go:generate mockgen -package authentication -destination user_account_provider_mock.go -mock_names UserAccountProvider=MockUserAccountProvider github.com/authelia/authelia/v4/internal/storage UserAccountProvider
+//go:generate mockgen -package authentication -destination user_password_history_provider_mock.go -mock_names UserPasswordHistoryProvider=MockUserPasswordHistoryProvider github.com/authelia/authelia/v4/internal/storage UserPasswordHistoryProvider
+//go:generate mockgen -package authentication -destination user_provider_mock.go -mock_names UserProvider=MockUserProvider github.com/authelia/authelia/v4/internal/authentication UserProvider
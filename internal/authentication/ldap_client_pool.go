@@ -0,0 +1,251 @@
+package authentication
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/sirupsen/logrus"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/logging"
+)
+
+// LDAPPoolMetricsRecorder represents the methods used to record the usage of the LDAP connection pool.
+type LDAPPoolMetricsRecorder interface {
+	RecordLDAPPoolConnections(active, idle int)
+	RecordLDAPPoolGet(result string)
+}
+
+// NewLDAPClientPool creates a new LDAPClientPool which uses the dial function to open new connections. The dial function
+// is expected to return a connection which is already bound with the service account.
+func NewLDAPClientPool(config schema.LDAPAuthenticationBackendPoolingConfiguration, dial func() (client LDAPClient, err error)) *LDAPClientPool {
+	return &LDAPClientPool{
+		config: config,
+		dial:   dial,
+		log:    logging.Logger(),
+		slots:  make(chan struct{}, config.Count),
+	}
+}
+
+// LDAPClientPool is a bounded pool of LDAP connections. Idle connections aren't closed in the background, instead when an
+// idle connection is taken from the pool it's closed if it has been idle for longer than the idle timeout, and it's
+// checked if it has been idle for longer than the health check interval. Connections which are closed, fail the health
+// check, or encounter a network error are replaced by new connections.
+type LDAPClientPool struct {
+	config  schema.LDAPAuthenticationBackendPoolingConfiguration
+	dial    func() (client LDAPClient, err error)
+	log     *logrus.Logger
+	metrics LDAPPoolMetricsRecorder
+
+	// slots limits the number of connections which are in use to the count of the pool.
+	slots chan struct{}
+
+	mu     sync.Mutex
+	idle   []*LDAPPooledClient
+	active int
+}
+
+// Get returns a connection from the pool, waiting for a connection to be released if all of the connections are in use.
+// The connection must be closed to release it back to the pool.
+func (p *LDAPClientPool) Get() (client LDAPClient, err error) {
+	timer := time.NewTimer(p.config.Timeout)
+
+	defer timer.Stop()
+
+	select {
+	case p.slots <- struct{}{}:
+	case <-timer.C:
+		p.recordGet(ldapPoolResultTimeout)
+
+		return nil, fmt.Errorf("timeout waiting for an available connection from the pool after %s", p.config.Timeout)
+	}
+
+	if pooled := p.takeIdle(); pooled != nil {
+		p.recordGet(ldapPoolResultReused)
+
+		return pooled, nil
+	}
+
+	var conn LDAPClient
+
+	attempts := 1
+
+	if p.config.Retries > 0 {
+		attempts += p.config.Retries
+	}
+
+	for i := 0; i < attempts; i++ {
+		if conn, err = p.dial(); err == nil {
+			break
+		}
+
+		p.log.WithError(err).Debugf("Failed to open a connection for the pool (attempt %d of %d)", i+1, attempts)
+	}
+
+	if err != nil {
+		<-p.slots
+
+		p.recordGet(ldapPoolResultError)
+
+		return nil, err
+	}
+
+	p.mu.Lock()
+	p.active++
+	p.recordConnections()
+	p.mu.Unlock()
+
+	p.recordGet(ldapPoolResultDialed)
+
+	return &LDAPPooledClient{LDAPClient: conn, pool: p}, nil
+}
+
+// takeIdle returns the most recently used idle connection which is healthy, closing the connections which aren't.
+func (p *LDAPClientPool) takeIdle() (client *LDAPPooledClient) {
+	for {
+		p.mu.Lock()
+
+		n := len(p.idle)
+
+		if n == 0 {
+			p.mu.Unlock()
+
+			return nil
+		}
+
+		client, p.idle = p.idle[n-1], p.idle[:n-1]
+
+		p.active++
+		p.recordConnections()
+		p.mu.Unlock()
+
+		idle := time.Since(client.used)
+
+		switch {
+		case idle > p.config.IdleTimeout:
+			p.log.Tracef("Closing pooled connection which has been idle for %s", idle)
+		case idle > p.config.HealthCheckInterval && !p.healthy(client.LDAPClient):
+			p.log.Debugf("Closing pooled connection which failed the health check after being idle for %s", idle)
+		default:
+			client.released = false
+
+			return client
+		}
+
+		client.LDAPClient.Close()
+
+		p.mu.Lock()
+		p.active--
+		p.recordConnections()
+		p.mu.Unlock()
+	}
+}
+
+// healthy performs a search of the RootDSE which doesn't return any attributes to check the connection is usable.
+func (p *LDAPClientPool) healthy(client LDAPClient) bool {
+	request := ldap.NewSearchRequest("", ldap.ScopeBaseObject, ldap.NeverDerefAliases,
+		1, 0, false, "(objectClass=*)", []string{ldapNoAttributes}, nil)
+
+	if _, err := client.Search(request); err != nil {
+		p.log.WithError(err).Debug("Pooled connection failed the health check")
+
+		return false
+	}
+
+	return true
+}
+
+// put releases a connection back to the pool, or closes it if it encountered a network error.
+func (p *LDAPClientPool) put(client *LDAPPooledClient) {
+	defer func() {
+		<-p.slots
+	}()
+
+	p.mu.Lock()
+
+	p.active--
+
+	if client.broken {
+		p.recordConnections()
+		p.mu.Unlock()
+
+		client.LDAPClient.Close()
+
+		return
+	}
+
+	client.used = time.Now()
+
+	p.idle = append(p.idle, client)
+
+	p.recordConnections()
+	p.mu.Unlock()
+}
+
+func (p *LDAPClientPool) recordConnections() {
+	if p.metrics != nil {
+		p.metrics.RecordLDAPPoolConnections(p.active, len(p.idle))
+	}
+}
+
+func (p *LDAPClientPool) recordGet(result string) {
+	if p.metrics != nil {
+		p.metrics.RecordLDAPPoolGet(result)
+	}
+}
+
+// LDAPPooledClient is a connection from a LDAPClientPool which is released back to the pool when it's closed.
+type LDAPPooledClient struct {
+	LDAPClient
+
+	pool *LDAPClientPool
+	used time.Time
+
+	broken, released bool
+}
+
+// Close releases the connection back to the pool.
+func (c *LDAPPooledClient) Close() {
+	if c.released {
+		return
+	}
+
+	c.released = true
+
+	c.pool.put(c)
+}
+
+// Modify performs the ldap.ModifyRequest and marks the connection as broken on a network error.
+func (c *LDAPPooledClient) Modify(modifyRequest *ldap.ModifyRequest) (err error) {
+	err = c.LDAPClient.Modify(modifyRequest)
+
+	c.check(err)
+
+	return err
+}
+
+// PasswordModify performs the ldap.PasswordModifyRequest and marks the connection as broken on a network error.
+func (c *LDAPPooledClient) PasswordModify(pwdModifyRequest *ldap.PasswordModifyRequest) (pwdModifyResult *ldap.PasswordModifyResult, err error) {
+	pwdModifyResult, err = c.LDAPClient.PasswordModify(pwdModifyRequest)
+
+	c.check(err)
+
+	return pwdModifyResult, err
+}
+
+// Search performs the ldap.SearchRequest and marks the connection as broken on a network error.
+func (c *LDAPPooledClient) Search(searchRequest *ldap.SearchRequest) (searchResult *ldap.SearchResult, err error) {
+	searchResult, err = c.LDAPClient.Search(searchRequest)
+
+	c.check(err)
+
+	return searchResult, err
+}
+
+func (c *LDAPPooledClient) check(err error) {
+	if err != nil && ldap.IsErrorAnyOf(err, ldap.ErrorNetwork, ldap.LDAPResultUnavailable) {
+		c.broken = true
+	}
+}
@@ -0,0 +1,302 @@
+package authentication
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+type ldapPoolTestRecorder struct {
+	results      []string
+	active, idle int
+}
+
+func (r *ldapPoolTestRecorder) RecordLDAPPoolConnections(active, idle int) {
+	r.active, r.idle = active, idle
+}
+
+func (r *ldapPoolTestRecorder) RecordLDAPPoolGet(result string) {
+	r.results = append(r.results, result)
+}
+
+func TestLDAPClientPoolShouldReuseConnections(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFactory := NewMockLDAPClientFactory(ctrl)
+	mockClient := NewMockLDAPClient(ctrl)
+
+	pool := NewLDAPClientPool(schema.DefaultLDAPAuthenticationBackendConfiguration.Pooling, func() (client LDAPClient, err error) {
+		return mockFactory.DialURL("ldap://127.0.0.1:389")
+	})
+
+	recorder := &ldapPoolTestRecorder{}
+	pool.metrics = recorder
+
+	mockFactory.EXPECT().DialURL("ldap://127.0.0.1:389").Return(mockClient, nil)
+
+	client, err := pool.Get()
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, recorder.active)
+	assert.Equal(t, 0, recorder.idle)
+
+	client.Close()
+
+	// Closing the connection again doesn't release it twice.
+	client.Close()
+
+	assert.Equal(t, 0, recorder.active)
+	assert.Equal(t, 1, recorder.idle)
+
+	client, err = pool.Get()
+	require.NoError(t, err)
+
+	client.Close()
+
+	assert.Equal(t, []string{ldapPoolResultDialed, ldapPoolResultReused}, recorder.results)
+}
+
+func TestLDAPClientPoolShouldTimeoutWhenExhausted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFactory := NewMockLDAPClientFactory(ctrl)
+	mockClient := NewMockLDAPClient(ctrl)
+
+	config := schema.DefaultLDAPAuthenticationBackendConfiguration.Pooling
+	config.Count = 1
+	config.Timeout = time.Millisecond * 10
+
+	pool := NewLDAPClientPool(config, func() (client LDAPClient, err error) {
+		return mockFactory.DialURL("ldap://127.0.0.1:389")
+	})
+
+	recorder := &ldapPoolTestRecorder{}
+	pool.metrics = recorder
+
+	mockFactory.EXPECT().DialURL("ldap://127.0.0.1:389").Return(mockClient, nil)
+
+	client, err := pool.Get()
+	require.NoError(t, err)
+
+	_, err = pool.Get()
+	assert.EqualError(t, err, "timeout waiting for an available connection from the pool after 10ms")
+
+	client.Close()
+
+	client, err = pool.Get()
+	require.NoError(t, err)
+
+	client.Close()
+
+	assert.Equal(t, []string{ldapPoolResultDialed, ldapPoolResultTimeout, ldapPoolResultReused}, recorder.results)
+}
+
+func TestLDAPClientPoolShouldReplaceBrokenConnections(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFactory := NewMockLDAPClientFactory(ctrl)
+	mockClient, mockClientReplacement := NewMockLDAPClient(ctrl), NewMockLDAPClient(ctrl)
+
+	pool := NewLDAPClientPool(schema.DefaultLDAPAuthenticationBackendConfiguration.Pooling, func() (client LDAPClient, err error) {
+		return mockFactory.DialURL("ldap://127.0.0.1:389")
+	})
+
+	recorder := &ldapPoolTestRecorder{}
+	pool.metrics = recorder
+
+	gomock.InOrder(
+		mockFactory.EXPECT().DialURL("ldap://127.0.0.1:389").Return(mockClient, nil),
+		mockFactory.EXPECT().DialURL("ldap://127.0.0.1:389").Return(mockClientReplacement, nil),
+	)
+
+	gomock.InOrder(
+		mockClient.EXPECT().Search(gomock.Any()).Return(nil, ldap.NewError(ldap.ErrorNetwork, errors.New("ldap: connection closed"))),
+		mockClient.EXPECT().Close(),
+	)
+
+	client, err := pool.Get()
+	require.NoError(t, err)
+
+	_, err = client.Search(&ldap.SearchRequest{})
+	assert.Error(t, err)
+
+	client.Close()
+
+	assert.Equal(t, 0, recorder.idle)
+
+	client, err = pool.Get()
+	require.NoError(t, err)
+
+	client.Close()
+
+	assert.Equal(t, []string{ldapPoolResultDialed, ldapPoolResultDialed}, recorder.results)
+}
+
+func TestLDAPClientPoolShouldHealthCheckIdleConnections(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFactory := NewMockLDAPClientFactory(ctrl)
+	mockClient, mockClientReplacement := NewMockLDAPClient(ctrl), NewMockLDAPClient(ctrl)
+
+	config := schema.DefaultLDAPAuthenticationBackendConfiguration.Pooling
+	config.HealthCheckInterval = time.Nanosecond
+
+	pool := NewLDAPClientPool(config, func() (client LDAPClient, err error) {
+		return mockFactory.DialURL("ldap://127.0.0.1:389")
+	})
+
+	recorder := &ldapPoolTestRecorder{}
+	pool.metrics = recorder
+
+	gomock.InOrder(
+		mockFactory.EXPECT().DialURL("ldap://127.0.0.1:389").Return(mockClient, nil),
+		mockFactory.EXPECT().DialURL("ldap://127.0.0.1:389").Return(mockClientReplacement, nil),
+	)
+
+	gomock.InOrder(
+		mockClient.EXPECT().Search(gomock.Any()).DoAndReturn(func(request *ldap.SearchRequest) (*ldap.SearchResult, error) {
+			assert.Equal(t, "", request.BaseDN)
+			assert.Equal(t, ldap.ScopeBaseObject, request.Scope)
+			assert.Equal(t, []string{ldapNoAttributes}, request.Attributes)
+
+			return &ldap.SearchResult{}, nil
+		}),
+		mockClient.EXPECT().Search(gomock.Any()).Return(nil, ldap.NewError(ldap.LDAPResultUnavailable, errors.New("unavailable"))),
+		mockClient.EXPECT().Close(),
+	)
+
+	client, err := pool.Get()
+	require.NoError(t, err)
+
+	client.Close()
+
+	time.Sleep(time.Millisecond)
+
+	client, err = pool.Get()
+	require.NoError(t, err)
+
+	client.Close()
+
+	time.Sleep(time.Millisecond)
+
+	client, err = pool.Get()
+	require.NoError(t, err)
+
+	client.Close()
+
+	assert.Equal(t, []string{ldapPoolResultDialed, ldapPoolResultReused, ldapPoolResultDialed}, recorder.results)
+}
+
+func TestLDAPClientPoolShouldCloseIdleConnections(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFactory := NewMockLDAPClientFactory(ctrl)
+	mockClient, mockClientReplacement := NewMockLDAPClient(ctrl), NewMockLDAPClient(ctrl)
+
+	config := schema.DefaultLDAPAuthenticationBackendConfiguration.Pooling
+	config.IdleTimeout = time.Nanosecond
+
+	pool := NewLDAPClientPool(config, func() (client LDAPClient, err error) {
+		return mockFactory.DialURL("ldap://127.0.0.1:389")
+	})
+
+	recorder := &ldapPoolTestRecorder{}
+	pool.metrics = recorder
+
+	gomock.InOrder(
+		mockFactory.EXPECT().DialURL("ldap://127.0.0.1:389").Return(mockClient, nil),
+		mockFactory.EXPECT().DialURL("ldap://127.0.0.1:389").Return(mockClientReplacement, nil),
+	)
+
+	mockClient.EXPECT().Close()
+
+	client, err := pool.Get()
+	require.NoError(t, err)
+
+	client.Close()
+
+	time.Sleep(time.Millisecond)
+
+	client, err = pool.Get()
+	require.NoError(t, err)
+
+	client.Close()
+
+	assert.Equal(t, []string{ldapPoolResultDialed, ldapPoolResultDialed}, recorder.results)
+}
+
+func TestLDAPClientPoolShouldRetryDial(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFactory := NewMockLDAPClientFactory(ctrl)
+	mockClient := NewMockLDAPClient(ctrl)
+
+	pool := NewLDAPClientPool(schema.DefaultLDAPAuthenticationBackendConfiguration.Pooling, func() (client LDAPClient, err error) {
+		return mockFactory.DialURL("ldap://127.0.0.1:389")
+	})
+
+	recorder := &ldapPoolTestRecorder{}
+	pool.metrics = recorder
+
+	gomock.InOrder(
+		mockFactory.EXPECT().DialURL("ldap://127.0.0.1:389").Return(nil, errors.New("dial failed")),
+		mockFactory.EXPECT().DialURL("ldap://127.0.0.1:389").Return(nil, errors.New("dial failed")),
+		mockFactory.EXPECT().DialURL("ldap://127.0.0.1:389").Return(mockClient, nil),
+	)
+
+	client, err := pool.Get()
+	require.NoError(t, err)
+
+	client.Close()
+
+	assert.Equal(t, []string{ldapPoolResultDialed}, recorder.results)
+
+	pool = NewLDAPClientPool(schema.DefaultLDAPAuthenticationBackendConfiguration.Pooling, func() (client LDAPClient, err error) {
+		return mockFactory.DialURL("ldap://127.0.0.1:389")
+	})
+
+	recorder = &ldapPoolTestRecorder{}
+	pool.metrics = recorder
+
+	gomock.InOrder(
+		mockFactory.EXPECT().DialURL("ldap://127.0.0.1:389").Return(nil, errors.New("dial failed")),
+		mockFactory.EXPECT().DialURL("ldap://127.0.0.1:389").Return(nil, errors.New("dial failed")),
+		mockFactory.EXPECT().DialURL("ldap://127.0.0.1:389").Return(nil, errors.New("dial failed")),
+	)
+
+	_, err = pool.Get()
+	assert.EqualError(t, err, "dial failed")
+
+	assert.Equal(t, 0, recorder.active)
+	assert.Equal(t, []string{ldapPoolResultError}, recorder.results)
+
+	config := schema.DefaultLDAPAuthenticationBackendConfiguration.Pooling
+	config.Retries = schema.LDAPPoolingRetriesDisabled
+
+	pool = NewLDAPClientPool(config, func() (client LDAPClient, err error) {
+		return mockFactory.DialURL("ldap://127.0.0.1:389")
+	})
+
+	recorder = &ldapPoolTestRecorder{}
+	pool.metrics = recorder
+
+	mockFactory.EXPECT().DialURL("ldap://127.0.0.1:389").Return(nil, errors.New("dial failed"))
+
+	_, err = pool.Get()
+	assert.EqualError(t, err, "dial failed")
+
+	assert.Equal(t, []string{ldapPoolResultError}, recorder.results)
+}
@@ -22,6 +22,7 @@ type LDAPUserProvider struct {
 	dialOpts  []ldap.DialOpt
 	log       *logrus.Logger
 	factory   LDAPClientFactory
+	pool      *LDAPClientPool
 
 	disableResetPassword bool
 
@@ -41,10 +42,15 @@ type LDAPUserProvider struct {
 	groupsFilterReplacementDN       bool
 }
 
-// NewLDAPUserProvider creates a new instance of LDAPUserProvider.
-func NewLDAPUserProvider(config schema.AuthenticationBackendConfiguration, certPool *x509.CertPool) (provider *LDAPUserProvider) {
+// NewLDAPUserProvider creates a new instance of LDAPUserProvider. The LDAPPoolMetricsRecorder is optional and is only
+// used when the connections are pooled.
+func NewLDAPUserProvider(config schema.AuthenticationBackendConfiguration, certPool *x509.CertPool, recorder LDAPPoolMetricsRecorder) (provider *LDAPUserProvider) {
 	provider = newLDAPUserProvider(*config.LDAP, config.PasswordReset.Disable, certPool, nil)
 
+	if provider.pool != nil {
+		provider.pool.metrics = recorder
+	}
+
 	return provider
 }
 
@@ -76,6 +82,10 @@ func newLDAPUserProvider(config schema.LDAPAuthenticationBackendConfiguration, d
 		disableResetPassword: disableResetPassword,
 	}
 
+	if config.Pooling.Enable {
+		provider.pool = NewLDAPClientPool(config.Pooling, provider.connectServiceAccount)
+	}
+
 	provider.parseDynamicUsersConfiguration()
 	provider.parseDynamicGroupsConfiguration()
 
@@ -99,7 +109,7 @@ func (p *LDAPUserProvider) CheckUserPassword(username string, password string) (
 		return false, err
 	}
 
-	if clientUser, err = p.connectCustom(p.config.URL, profile.DN, password, p.config.StartTLS, p.dialOpts...); err != nil {
+	if clientUser, err = p.connectUser(profile.DN, password); err != nil {
 		return false, fmt.Errorf("authentication failed. Cause: %w", err)
 	}
 
@@ -125,27 +135,40 @@ func (p *LDAPUserProvider) GetDetails(username string) (details *UserDetails, er
 		return nil, err
 	}
 
+	var groups []string
+
+	if groups, err = p.getUserGroups(client, username, profile); err != nil {
+		return nil, err
+	}
+
+	return &UserDetails{
+		Username:    profile.Username,
+		DisplayName: profile.DisplayName,
+		Emails:      profile.Emails,
+		Groups:      groups,
+		Attributes:  profile.Attributes,
+	}, nil
+}
+
+func (p *LDAPUserProvider) getUserGroups(client LDAPClient, username string, profile *ldapUserProfile) (groups []string, err error) {
 	var (
-		filter        string
-		searchRequest *ldap.SearchRequest
-		searchResult  *ldap.SearchResult
+		filter       string
+		searchResult *ldap.SearchResult
 	)
 
-	if filter, err = p.resolveGroupsFilter(username, profile); err != nil {
+	mode := p.getGroupSearchMode(p.features)
+
+	if mode == schema.LDAPGroupSearchModeInChain {
+		filter = strings.ReplaceAll(ldapGroupsFilterInChain, ldapPlaceholderDistinguishedName, ldap.EscapeFilter(profile.DN))
+	} else if filter, err = p.resolveGroupsFilter(username, profile); err != nil {
 		return nil, fmt.Errorf("unable to create group filter for user '%s'. Cause: %w", username, err)
 	}
 
-	// Search for the users groups.
-	searchRequest = ldap.NewSearchRequest(
-		p.groupsBaseDN, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases,
-		0, 0, false, filter, p.groupsAttributes, nil,
-	)
-
-	if searchResult, err = p.search(client, searchRequest); err != nil {
+	if searchResult, err = p.searchGroups(client, filter); err != nil {
 		return nil, fmt.Errorf("unable to retrieve groups of user '%s'. Cause: %w", username, err)
 	}
 
-	groups := make([]string, 0)
+	groups = make([]string, 0)
 
 	for _, res := range searchResult.Entries {
 		if len(res.Attributes) == 0 {
@@ -157,12 +180,89 @@ func (p *LDAPUserProvider) GetDetails(username string) (details *UserDetails, er
 		groups = append(groups, res.Attributes[0].Values...)
 	}
 
-	return &UserDetails{
-		Username:    profile.Username,
-		DisplayName: profile.DisplayName,
-		Emails:      profile.Emails,
-		Groups:      groups,
-	}, nil
+	if mode != schema.LDAPGroupSearchModeRecursive {
+		return groups, nil
+	}
+
+	return p.getUserNestedGroups(client, username, profile, groups, searchResult.Entries)
+}
+
+// getUserNestedGroups walks the parent groups of the groups level by level up to the maximum depth. The parent groups of
+// a group are the groups matching the groups filter with the {dn} placeholder replaced by the group DN. Groups which
+// have already been visited are skipped which prevents membership cycles from being walked more than once.
+func (p *LDAPUserProvider) getUserNestedGroups(client LDAPClient, username string, profile *ldapUserProfile, groups []string, entries []*ldap.Entry) ([]string, error) {
+	var (
+		filter, filters string
+		searchResult    *ldap.SearchResult
+		err             error
+	)
+
+	visited := map[string]bool{}
+
+	level := make([]string, 0, len(entries))
+
+	for _, entry := range entries {
+		if dn := strings.ToLower(entry.DN); !visited[dn] {
+			visited[dn] = true
+
+			level = append(level, entry.DN)
+		}
+	}
+
+	for depth := 1; len(level) != 0; depth++ {
+		if depth > p.config.GroupSearchMaxDepth {
+			p.log.Warnf("The maximum depth of %d nested groups was reached retrieving the groups of user %s, the groups nested deeper are ignored", p.config.GroupSearchMaxDepth, username)
+
+			break
+		}
+
+		filters = ""
+
+		for _, dn := range level {
+			if filter, err = p.resolveGroupsFilter(username, &ldapUserProfile{DN: dn, Username: profile.Username}); err != nil {
+				return nil, fmt.Errorf("unable to create nested group filter for user '%s'. Cause: %w", username, err)
+			}
+
+			filters += filter
+		}
+
+		if len(level) > 1 {
+			filters = "(|" + filters + ")"
+		}
+
+		if searchResult, err = p.searchGroups(client, filters); err != nil {
+			return nil, fmt.Errorf("unable to retrieve nested groups of user '%s'. Cause: %w", username, err)
+		}
+
+		level = level[:0]
+
+		for _, entry := range searchResult.Entries {
+			dn := strings.ToLower(entry.DN)
+
+			if visited[dn] {
+				continue
+			}
+
+			visited[dn] = true
+
+			level = append(level, entry.DN)
+
+			if len(entry.Attributes) != 0 {
+				groups = append(groups, entry.Attributes[0].Values...)
+			}
+		}
+	}
+
+	return groups, nil
+}
+
+func (p *LDAPUserProvider) searchGroups(client LDAPClient, filter string) (searchResult *ldap.SearchResult, err error) {
+	searchRequest := ldap.NewSearchRequest(
+		p.groupsBaseDN, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases,
+		0, 0, false, filter, p.groupsAttributes, nil,
+	)
+
+	return p.search(client, searchRequest)
 }
 
 // UpdatePassword update the password of the given user.
@@ -222,21 +322,52 @@ func (p *LDAPUserProvider) UpdatePassword(username, password string) (err error)
 	return nil
 }
 
+// connect returns a connection bound with the service account, which is taken from the pool if pooling is enabled.
 func (p *LDAPUserProvider) connect() (client LDAPClient, err error) {
+	if p.pool != nil {
+		return p.pool.Get()
+	}
+
+	return p.connectServiceAccount()
+}
+
+func (p *LDAPUserProvider) connectServiceAccount() (client LDAPClient, err error) {
 	return p.connectCustom(p.config.URL, p.config.User, p.config.Password, p.config.StartTLS, p.dialOpts...)
 }
 
-func (p *LDAPUserProvider) connectCustom(url, username, password string, startTLS bool, opts ...ldap.DialOpt) (client LDAPClient, err error) {
-	if client, err = p.factory.DialURL(url, opts...); err != nil {
-		return nil, fmt.Errorf("dial failed with error: %w", err)
+// connectUser connects and binds as a user requesting the password policy control. If the directory reports a password
+// policy state for the user such as an expired password or a locked account it's returned as a typed error.
+func (p *LDAPUserProvider) connectUser(dn, password string) (client LDAPClient, err error) {
+	if client, err = p.dial(p.config.URL, p.config.StartTLS, p.dialOpts...); err != nil {
+		return nil, err
 	}
 
-	if startTLS {
-		if err = client.StartTLS(p.tlsConfig); err != nil {
-			client.Close()
+	var result *ldap.SimpleBindResult
 
-			return nil, fmt.Errorf("starttls failed with error: %w", err)
-		}
+	if password == "" {
+		err = client.UnauthenticatedBind(dn)
+	} else {
+		result, err = client.SimpleBind(ldap.NewSimpleBindRequest(dn, password, []ldap.Control{ldap.NewControlBeheraPasswordPolicy()}))
+	}
+
+	if errPolicy := ldapGetPasswordPolicyError(result, err); errPolicy != nil {
+		client.Close()
+
+		return nil, errPolicy
+	}
+
+	if err != nil {
+		client.Close()
+
+		return nil, fmt.Errorf("bind failed with error: %w", err)
+	}
+
+	return client, nil
+}
+
+func (p *LDAPUserProvider) connectCustom(url, username, password string, startTLS bool, opts ...ldap.DialOpt) (client LDAPClient, err error) {
+	if client, err = p.dial(url, startTLS, opts...); err != nil {
+		return nil, err
 	}
 
 	if password == "" {
@@ -254,6 +385,22 @@ func (p *LDAPUserProvider) connectCustom(url, username, password string, startTL
 	return client, nil
 }
 
+func (p *LDAPUserProvider) dial(url string, startTLS bool, opts ...ldap.DialOpt) (client LDAPClient, err error) {
+	if client, err = p.factory.DialURL(url, opts...); err != nil {
+		return nil, fmt.Errorf("dial failed with error: %w", err)
+	}
+
+	if startTLS {
+		if err = client.StartTLS(p.tlsConfig); err != nil {
+			client.Close()
+
+			return nil, fmt.Errorf("starttls failed with error: %w", err)
+		}
+	}
+
+	return client, nil
+}
+
 func (p *LDAPUserProvider) search(client LDAPClient, searchRequest *ldap.SearchRequest) (searchResult *ldap.SearchResult, err error) {
 	if searchResult, err = client.Search(searchRequest); err != nil {
 		if referral, ok := p.getReferral(err); ok {
@@ -371,6 +518,18 @@ func (p *LDAPUserProvider) getUserProfile(client LDAPClient, username string) (p
 		if attr.Name == p.config.DisplayNameAttribute {
 			userProfile.DisplayName = attr.Values[0]
 		}
+
+		for _, extra := range p.config.ExtraAttributes {
+			if !strings.EqualFold(attr.Name, extra) {
+				continue
+			}
+
+			if userProfile.Attributes == nil {
+				userProfile.Attributes = map[string][]string{}
+			}
+
+			userProfile.Attributes[strings.ToLower(extra)] = attr.Values
+		}
 	}
 
 	if userProfile.Username == "" {
@@ -50,8 +50,15 @@ func (p *LDAPUserProvider) getServerSupportedFeatures(client LDAPClient) (featur
 		searchResult  *ldap.SearchResult
 	)
 
+	attributes := []string{ldapSupportedExtensionAttribute, ldapSupportedControlAttribute}
+
+	// The capabilities are only required to detect the group search mode.
+	if p.config.GroupSearchMode == schema.LDAPGroupSearchModeAuto {
+		attributes = append(attributes, ldapSupportedCapabilitiesAttribute)
+	}
+
 	searchRequest = ldap.NewSearchRequest("", ldap.ScopeBaseObject, ldap.NeverDerefAliases,
-		1, 0, false, "(objectClass=*)", []string{ldapSupportedExtensionAttribute, ldapSupportedControlAttribute}, nil)
+		1, 0, false, "(objectClass=*)", attributes, nil)
 
 	if searchResult, err = client.Search(searchRequest); err != nil {
 		return features, err
@@ -79,9 +86,30 @@ func (p *LDAPUserProvider) getServerSupportedFeatures(client LDAPClient) (featur
 
 	p.log.Debugf("LDAP Supported OIDs. Control Types: %s. Extensions: %s", controlTypes, extensions)
 
+	if mode := p.getGroupSearchMode(features); mode != schema.LDAPGroupSearchModeFilter {
+		p.log.Infof("LDAP nested groups are resolved using the '%s' group search mode (configured as '%s')", mode, p.config.GroupSearchMode)
+	}
+
 	return features, nil
 }
 
+// getGroupSearchMode returns the group search mode, the auto mode is resolved to the in_chain mode if the server is
+// Active Directory and the recursive mode otherwise.
+func (p *LDAPUserProvider) getGroupSearchMode(features LDAPSupportedFeatures) string {
+	switch p.config.GroupSearchMode {
+	case schema.LDAPGroupSearchModeRecursive, schema.LDAPGroupSearchModeInChain:
+		return p.config.GroupSearchMode
+	case schema.LDAPGroupSearchModeAuto:
+		if features.Capabilities.ActiveDirectory {
+			return schema.LDAPGroupSearchModeInChain
+		}
+
+		return schema.LDAPGroupSearchModeRecursive
+	default:
+		return schema.LDAPGroupSearchModeFilter
+	}
+}
+
 func (p *LDAPUserProvider) parseDynamicUsersConfiguration() {
 	p.config.UsersFilter = strings.ReplaceAll(p.config.UsersFilter, "{username_attribute}", p.config.UsernameAttribute)
 	p.config.UsersFilter = strings.ReplaceAll(p.config.UsersFilter, "{mail_attribute}", p.config.MailAttribute)
@@ -101,6 +129,12 @@ func (p *LDAPUserProvider) parseDynamicUsersConfiguration() {
 		p.usersAttributes = append(p.usersAttributes, p.config.DisplayNameAttribute)
 	}
 
+	for _, extra := range p.config.ExtraAttributes {
+		if !utils.IsStringInSliceFold(extra, p.usersAttributes) {
+			p.usersAttributes = append(p.usersAttributes, extra)
+		}
+	}
+
 	if p.config.AdditionalUsersDN != "" {
 		p.usersBaseDN = p.config.AdditionalUsersDN + "," + p.config.BaseDN
 	} else {
@@ -511,6 +511,25 @@ func (srm *SearchRequestMatcher) String() string {
 	return ""
 }
 
+type SimpleBindRequestMatcher struct {
+	username, password string
+}
+
+func NewSimpleBindRequestMatcher(username, password string) *SimpleBindRequestMatcher {
+	return &SimpleBindRequestMatcher{username, password}
+}
+
+func (m *SimpleBindRequestMatcher) Matches(x interface{}) bool {
+	br := x.(*ldap.SimpleBindRequest)
+
+	return br.Username == m.username && br.Password == m.password &&
+		len(br.Controls) == 1 && br.Controls[0].GetControlType() == ldap.ControlTypeBeheraPasswordPolicy
+}
+
+func (m *SimpleBindRequestMatcher) String() string {
+	return fmt.Sprintf("username: %s with the password policy control", m.username)
+}
+
 func TestShouldEscapeUserInput(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -1018,6 +1037,86 @@ func TestShouldReturnUsernameFromLDAP(t *testing.T) {
 	assert.Equal(t, details.Username, "John")
 }
 
+func TestShouldReturnExtraAttributesFromLDAP(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFactory := NewMockLDAPClientFactory(ctrl)
+	mockClient := NewMockLDAPClient(ctrl)
+
+	ldapClient := newLDAPUserProvider(
+		schema.LDAPAuthenticationBackendConfiguration{
+			URL:                  "ldap://127.0.0.1:389",
+			User:                 "cn=admin,dc=example,dc=com",
+			Password:             "password",
+			UsernameAttribute:    "uid",
+			MailAttribute:        "mail",
+			DisplayNameAttribute: "displayName",
+			ExtraAttributes:      []string{"departmentNumber", "employeeType"},
+			UsersFilter:          "uid={input}",
+			AdditionalUsersDN:    "ou=users",
+			BaseDN:               "dc=example,dc=com",
+		},
+		false,
+		nil,
+		mockFactory)
+
+	assert.Equal(t, []string{"uid", "mail", "displayName", "departmentNumber", "employeeType"}, ldapClient.usersAttributes)
+
+	dialURL := mockFactory.EXPECT().
+		DialURL(gomock.Eq("ldap://127.0.0.1:389"), gomock.Any()).
+		Return(mockClient, nil)
+
+	connBind := mockClient.EXPECT().
+		Bind(gomock.Eq("cn=admin,dc=example,dc=com"), gomock.Eq("password")).
+		Return(nil)
+
+	connClose := mockClient.EXPECT().Close()
+
+	searchGroups := mockClient.EXPECT().
+		Search(gomock.Any()).
+		Return(createSearchResultWithAttributeValues("group1", "group2"), nil)
+
+	searchProfile := mockClient.EXPECT().
+		Search(gomock.Any()).
+		Return(&ldap.SearchResult{
+			Entries: []*ldap.Entry{
+				{
+					DN: "uid=test,dc=example,dc=com",
+					Attributes: []*ldap.EntryAttribute{
+						{
+							Name:   "displayName",
+							Values: []string{"John Doe"},
+						},
+						{
+							Name:   "mail",
+							Values: []string{"test@example.com"},
+						},
+						{
+							Name:   "uid",
+							Values: []string{"John"},
+						},
+						{
+							Name:   "departmentnumber",
+							Values: []string{"engineering"},
+						},
+						{
+							Name:   "employeeType",
+							Values: []string{"staff", "oncall"},
+						},
+					},
+				},
+			},
+		}, nil)
+
+	gomock.InOrder(dialURL, connBind, searchProfile, searchGroups, connClose)
+
+	details, err := ldapClient.GetDetails("john")
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string][]string{"departmentnumber": {"engineering"}, "employeetype": {"staff", "oncall"}}, details.Attributes)
+}
+
 func TestShouldReturnUsernameFromLDAPWithReferrals(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -3614,8 +3713,8 @@ func TestShouldCheckValidUserPassword(t *testing.T) {
 			DialURL(gomock.Eq("ldap://127.0.0.1:389"), gomock.Any()).
 			Return(mockClient, nil),
 		mockClient.EXPECT().
-			Bind(gomock.Eq("uid=test,dc=example,dc=com"), gomock.Eq("password")).
-			Return(nil),
+			SimpleBind(NewSimpleBindRequestMatcher("uid=test,dc=example,dc=com", "password")).
+			Return(&ldap.SimpleBindResult{}, nil),
 		mockClient.EXPECT().Close().Times(2),
 	)
 
@@ -3721,8 +3820,8 @@ func TestShouldCheckInvalidUserPassword(t *testing.T) {
 			DialURL(gomock.Eq("ldap://127.0.0.1:389"), gomock.Any()).
 			Return(mockClient, nil),
 		mockClient.EXPECT().
-			Bind(gomock.Eq("uid=test,dc=example,dc=com"), gomock.Eq("password")).
-			Return(errors.New("invalid username or password")),
+			SimpleBind(NewSimpleBindRequestMatcher("uid=test,dc=example,dc=com", "password")).
+			Return(nil, errors.New("invalid username or password")),
 		mockClient.EXPECT().Close().Times(2),
 	)
 
@@ -3968,3 +4067,310 @@ func TestShouldReturnLDAPSAlreadySecuredWhenStartTLSAttempted(t *testing.T) {
 	_, err := ldapClient.GetDetails("john")
 	assert.EqualError(t, err, "starttls failed with error: LDAP Result Code 200 \"Network Error\": ldap: already encrypted")
 }
+
+func createGroupEntries(names ...string) *ldap.SearchResult {
+	result := &ldap.SearchResult{}
+
+	for _, name := range names {
+		result.Entries = append(result.Entries, &ldap.Entry{
+			DN: fmt.Sprintf("cn=%s,ou=groups,dc=example,dc=com", name),
+			Attributes: []*ldap.EntryAttribute{
+				{
+					Name:   "cn",
+					Values: []string{name},
+				},
+			},
+		})
+	}
+
+	return result
+}
+
+func createUserProfileSearchResult(dn string) *ldap.SearchResult {
+	return &ldap.SearchResult{
+		Entries: []*ldap.Entry{
+			{
+				DN: dn,
+				Attributes: []*ldap.EntryAttribute{
+					{
+						Name:   "displayName",
+						Values: []string{"John Doe"},
+					},
+					{
+						Name:   "mail",
+						Values: []string{"john.doe@example.com"},
+					},
+					{
+						Name:   "uid",
+						Values: []string{"john"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestShouldReturnNestedGroupsRecursively(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFactory := NewMockLDAPClientFactory(ctrl)
+	mockClient := NewMockLDAPClient(ctrl)
+
+	ldapClient := newLDAPUserProvider(
+		schema.LDAPAuthenticationBackendConfiguration{
+			URL:                  "ldap://127.0.0.1:389",
+			User:                 "cn=admin,dc=example,dc=com",
+			Password:             "password",
+			UsernameAttribute:    "uid",
+			MailAttribute:        "mail",
+			DisplayNameAttribute: "displayName",
+			UsersFilter:          "uid={input}",
+			GroupsFilter:         "(&(member={dn})(objectClass=groupOfNames))",
+			GroupNameAttribute:   "cn",
+			GroupSearchMode:      schema.LDAPGroupSearchModeRecursive,
+			GroupSearchMaxDepth:  2,
+			AdditionalUsersDN:    "ou=users",
+			BaseDN:               "dc=example,dc=com",
+		},
+		false,
+		nil,
+		mockFactory)
+
+	gomock.InOrder(
+		mockFactory.EXPECT().
+			DialURL(gomock.Eq("ldap://127.0.0.1:389"), gomock.Any()).
+			Return(mockClient, nil),
+		mockClient.EXPECT().
+			Bind(gomock.Eq("cn=admin,dc=example,dc=com"), gomock.Eq("password")).
+			Return(nil),
+		mockClient.EXPECT().
+			Search(NewSearchRequestMatcher("uid=john")).
+			Return(createUserProfileSearchResult("uid=john,ou=users,dc=example,dc=com"), nil),
+		mockClient.EXPECT().
+			Search(NewSearchRequestMatcher("(&(member=uid=john,ou=users,dc=example,dc=com)(objectClass=groupOfNames))")).
+			Return(createGroupEntries("dev", "ops"), nil),
+		mockClient.EXPECT().
+			Search(NewSearchRequestMatcher("(|(&(member=cn=dev,ou=groups,dc=example,dc=com)(objectClass=groupOfNames))(&(member=cn=ops,ou=groups,dc=example,dc=com)(objectClass=groupOfNames)))")).
+			Return(createGroupEntries("engineering"), nil),
+		// The dev group is a member of the engineering group which is a membership cycle.
+		mockClient.EXPECT().
+			Search(NewSearchRequestMatcher("(&(member=cn=engineering,ou=groups,dc=example,dc=com)(objectClass=groupOfNames))")).
+			Return(createGroupEntries("dev", "staff"), nil),
+		mockClient.EXPECT().Close(),
+	)
+
+	details, err := ldapClient.GetDetails("john")
+	require.NoError(t, err)
+
+	// The parent groups of the staff group are deeper than the maximum depth.
+	assert.Equal(t, []string{"dev", "ops", "engineering", "staff"}, details.Groups)
+}
+
+func TestShouldReturnNestedGroupsInChain(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFactory := NewMockLDAPClientFactory(ctrl)
+	mockClient := NewMockLDAPClient(ctrl)
+
+	ldapClient := newLDAPUserProvider(
+		schema.LDAPAuthenticationBackendConfiguration{
+			URL:                  "ldap://127.0.0.1:389",
+			User:                 "cn=admin,dc=example,dc=com",
+			Password:             "password",
+			UsernameAttribute:    "uid",
+			MailAttribute:        "mail",
+			DisplayNameAttribute: "displayName",
+			UsersFilter:          "uid={input}",
+			GroupsFilter:         "(&(member={dn})(objectClass=group))",
+			GroupNameAttribute:   "cn",
+			GroupSearchMode:      schema.LDAPGroupSearchModeInChain,
+			AdditionalUsersDN:    "ou=users",
+			BaseDN:               "dc=example,dc=com",
+		},
+		false,
+		nil,
+		mockFactory)
+
+	gomock.InOrder(
+		mockFactory.EXPECT().
+			DialURL(gomock.Eq("ldap://127.0.0.1:389"), gomock.Any()).
+			Return(mockClient, nil),
+		mockClient.EXPECT().
+			Bind(gomock.Eq("cn=admin,dc=example,dc=com"), gomock.Eq("password")).
+			Return(nil),
+		mockClient.EXPECT().
+			Search(NewSearchRequestMatcher("uid=john")).
+			Return(createUserProfileSearchResult("CN=John (Admin),OU=Users,DC=example,DC=com"), nil),
+		mockClient.EXPECT().
+			Search(NewSearchRequestMatcher("(&(member:1.2.840.113556.1.4.1941:=CN=John \\28Admin\\29,OU=Users,DC=example,DC=com)(objectClass=group))")).
+			Return(createGroupEntries("dev", "engineering"), nil),
+		mockClient.EXPECT().Close(),
+	)
+
+	details, err := ldapClient.GetDetails("john")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"dev", "engineering"}, details.Groups)
+}
+
+func TestShouldDetectGroupSearchModeAuto(t *testing.T) {
+	testCases := []struct {
+		name         string
+		capabilities []string
+		expected     string
+	}{
+		{"ShouldUseInChainWithActiveDirectory", []string{ldapOIDCapabilityActiveDirectory}, schema.LDAPGroupSearchModeInChain},
+		{"ShouldUseRecursiveWithoutActiveDirectory", nil, schema.LDAPGroupSearchModeRecursive},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockFactory := NewMockLDAPClientFactory(ctrl)
+			mockClient := NewMockLDAPClient(ctrl)
+
+			ldapClient := newLDAPUserProvider(
+				schema.LDAPAuthenticationBackendConfiguration{
+					URL:                  "ldap://127.0.0.1:389",
+					User:                 "cn=admin,dc=example,dc=com",
+					Password:             "password",
+					UsernameAttribute:    "uid",
+					MailAttribute:        "mail",
+					DisplayNameAttribute: "displayName",
+					UsersFilter:          "uid={input}",
+					GroupsFilter:         "(&(member={dn})(objectClass=group))",
+					GroupSearchMode:      schema.LDAPGroupSearchModeAuto,
+					BaseDN:               "dc=example,dc=com",
+				},
+				false,
+				nil,
+				mockFactory)
+
+			assert.Equal(t, schema.LDAPGroupSearchModeRecursive, ldapClient.getGroupSearchMode(ldapClient.features))
+
+			gomock.InOrder(
+				mockFactory.EXPECT().
+					DialURL(gomock.Eq("ldap://127.0.0.1:389"), gomock.Any()).
+					Return(mockClient, nil),
+				mockClient.EXPECT().
+					Bind(gomock.Eq("cn=admin,dc=example,dc=com"), gomock.Eq("password")).
+					Return(nil),
+				mockClient.EXPECT().
+					Search(NewExtendedSearchRequestMatcher("(objectClass=*)", "", ldap.ScopeBaseObject, ldap.NeverDerefAliases, false, []string{ldapSupportedExtensionAttribute, ldapSupportedControlAttribute, ldapSupportedCapabilitiesAttribute})).
+					Return(&ldap.SearchResult{
+						Entries: []*ldap.Entry{
+							{
+								DN: "",
+								Attributes: []*ldap.EntryAttribute{
+									{
+										Name:   ldapSupportedCapabilitiesAttribute,
+										Values: tc.capabilities,
+									},
+								},
+							},
+						},
+					}, nil),
+				mockClient.EXPECT().Close(),
+			)
+
+			require.NoError(t, ldapClient.StartupCheck())
+
+			assert.Equal(t, tc.expected, ldapClient.getGroupSearchMode(ldapClient.features))
+		})
+	}
+}
+
+func TestShouldReturnPasswordPolicyErrors(t *testing.T) {
+	errInvalidCredentialsAD := func(data string) error {
+		return ldap.NewError(ldap.LDAPResultInvalidCredentials, fmt.Errorf("80090308: LdapErr: DSID-0C09044E, comment: AcceptSecurityContext error, data %s, v2580", data))
+	}
+
+	resultPasswordPolicy := func(code int8) *ldap.SimpleBindResult {
+		control := ldap.NewControlBeheraPasswordPolicy()
+		control.Error = code
+
+		return &ldap.SimpleBindResult{Controls: []ldap.Control{control}}
+	}
+
+	errInvalidCredentials := ldap.NewError(ldap.LDAPResultInvalidCredentials, errors.New("invalid credentials"))
+
+	testCases := []struct {
+		name     string
+		result   *ldap.SimpleBindResult
+		err      error
+		expected error
+	}{
+		{"ShouldReturnPasswordExpiredControl", resultPasswordPolicy(ldap.BeheraPasswordExpired), errInvalidCredentials, ErrPasswordExpired},
+		{"ShouldReturnAccountLockedControl", resultPasswordPolicy(ldap.BeheraAccountLocked), errInvalidCredentials, ErrAccountLocked},
+		{"ShouldReturnPasswordMustChangeControl", resultPasswordPolicy(ldap.BeheraChangeAfterReset), nil, ErrPasswordMustChange},
+		{"ShouldReturnPasswordExpiredAD", nil, errInvalidCredentialsAD("532"), ErrPasswordExpired},
+		{"ShouldReturnAccountDisabledAD", nil, errInvalidCredentialsAD("533"), ErrAccountDisabled},
+		{"ShouldReturnAccountExpiredAD", nil, errInvalidCredentialsAD("701"), ErrAccountDisabled},
+		{"ShouldReturnPasswordMustChangeAD", nil, errInvalidCredentialsAD("773"), ErrPasswordMustChange},
+		{"ShouldReturnAccountLockedAD", nil, errInvalidCredentialsAD("775"), ErrAccountLocked},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockFactory := NewMockLDAPClientFactory(ctrl)
+			mockClient := NewMockLDAPClient(ctrl)
+
+			ldapClient := newLDAPUserProvider(
+				schema.LDAPAuthenticationBackendConfiguration{
+					URL:                  "ldap://127.0.0.1:389",
+					User:                 "cn=admin,dc=example,dc=com",
+					Password:             "password",
+					UsernameAttribute:    "uid",
+					MailAttribute:        "mail",
+					DisplayNameAttribute: "displayName",
+					UsersFilter:          "uid={input}",
+					AdditionalUsersDN:    "ou=users",
+					BaseDN:               "dc=example,dc=com",
+				},
+				false,
+				nil,
+				mockFactory)
+
+			gomock.InOrder(
+				mockFactory.EXPECT().
+					DialURL(gomock.Eq("ldap://127.0.0.1:389"), gomock.Any()).
+					Return(mockClient, nil),
+				mockClient.EXPECT().
+					Bind(gomock.Eq("cn=admin,dc=example,dc=com"), gomock.Eq("password")).
+					Return(nil),
+				mockClient.EXPECT().
+					Search(NewSearchRequestMatcher("uid=john")).
+					Return(createUserProfileSearchResult("uid=john,ou=users,dc=example,dc=com"), nil),
+				mockFactory.EXPECT().
+					DialURL(gomock.Eq("ldap://127.0.0.1:389"), gomock.Any()).
+					Return(mockClient, nil),
+				mockClient.EXPECT().
+					SimpleBind(NewSimpleBindRequestMatcher("uid=john,ou=users,dc=example,dc=com", "password")).
+					Return(tc.result, tc.err),
+				mockClient.EXPECT().Close().Times(2),
+			)
+
+			valid, err := ldapClient.CheckUserPassword("john", "password")
+
+			assert.False(t, valid)
+			assert.ErrorIs(t, err, tc.expected)
+		})
+	}
+}
+
+func TestShouldNotReturnPasswordPolicyErrorForOtherFailures(t *testing.T) {
+	control := ldap.NewControlBeheraPasswordPolicy()
+	control.Error = ldap.BeheraPasswordTooShort
+
+	assert.NoError(t, ldapGetPasswordPolicyError(&ldap.SimpleBindResult{Controls: []ldap.Control{control}}, nil))
+	assert.NoError(t, ldapGetPasswordPolicyError(&ldap.SimpleBindResult{}, ldap.NewError(ldap.LDAPResultInvalidCredentials, errors.New("invalid credentials"))))
+	assert.NoError(t, ldapGetPasswordPolicyError(nil, ldap.NewError(ldap.LDAPResultInvalidCredentials, errors.New("80090308: LdapErr: DSID-0C09044E, comment: AcceptSecurityContext error, data 52e, v2580"))))
+	assert.NoError(t, ldapGetPasswordPolicyError(nil, ldap.NewError(ldap.LDAPResultBusy, errors.New("80090308: LdapErr: DSID-0C09044E, comment: AcceptSecurityContext error, data 775, v2580"))))
+}
@@ -51,6 +51,12 @@ func ldapGetFeatureSupportFromEntry(entry *ldap.Entry) (controlTypeOIDs, extensi
 					features.Extensions.TLS = true
 				}
 			}
+		case ldapSupportedCapabilitiesAttribute:
+			for _, oid := range attr.Values {
+				if oid == ldapOIDCapabilityActiveDirectory {
+					features.Capabilities.ActiveDirectory = true
+				}
+			}
 		}
 	}
 
@@ -104,3 +110,44 @@ func ldapGetReferral(err error) (referral string, ok bool) {
 		return "", false
 	}
 }
+
+// ldapGetPasswordPolicyError returns the typed error for the password policy state of a bind which is reported by either
+// the password policy response control or the Microsoft Active Directory sub-error code, or nil if there is no known
+// state to report.
+func ldapGetPasswordPolicyError(result *ldap.SimpleBindResult, err error) error {
+	if result != nil {
+		if control, ok := ldap.FindControl(result.Controls, ldap.ControlTypeBeheraPasswordPolicy).(*ldap.ControlBeheraPasswordPolicy); ok {
+			switch control.Error {
+			case ldap.BeheraPasswordExpired:
+				return ErrPasswordExpired
+			case ldap.BeheraAccountLocked:
+				return ErrAccountLocked
+			case ldap.BeheraChangeAfterReset:
+				return ErrPasswordMustChange
+			}
+		}
+	}
+
+	if err == nil || !ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+		return nil
+	}
+
+	matches := reLDAPADSubError.FindStringSubmatch(err.Error())
+
+	if len(matches) != 2 {
+		return nil
+	}
+
+	switch strings.ToLower(matches[1]) {
+	case ldapADSubErrorPasswordExpired:
+		return ErrPasswordExpired
+	case ldapADSubErrorPasswordMustChange:
+		return ErrPasswordMustChange
+	case ldapADSubErrorAccountLocked:
+		return ErrAccountLocked
+	case ldapADSubErrorAccountDisabled, ldapADSubErrorAccountExpired:
+		return ErrAccountDisabled
+	default:
+		return nil
+	}
+}
@@ -1,19 +1,27 @@
 package authentication
 
 import (
+	"crypto/sha256"
 	"crypto/subtle"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"strconv"
 	"strings"
 
 	"github.com/simia-tech/crypt"
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
 
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/utils"
 )
 
 // PasswordHash represents all characteristics of a password hash.
-// Authelia only supports salted SHA512 or salted argon2id method, i.e., $6$ mode or $argon2id$ mode.
+// Authelia only generates salted SHA512 or salted argon2id hashes, i.e., $6$ mode or $argon2id$ mode. The bcrypt,
+// scrypt, and PBKDF2-SHA256 hashes are only supported for verification, for the bcrypt algorithm the iterations are
+// the cost and for the scrypt algorithm they are the base 2 logarithm of the CPU/memory cost.
 type PasswordHash struct {
 	Algorithm   CryptAlgo
 	Iterations  int
@@ -22,6 +30,9 @@ type PasswordHash struct {
 	KeyLength   int
 	Memory      int
 	Parallelism int
+	BlockSize   int
+
+	hash string
 }
 
 // ConfigAlgoToCryptoAlgo returns a CryptAlgo and nil error if valid, otherwise it returns argon2id and an error.
@@ -38,6 +49,15 @@ func ConfigAlgoToCryptoAlgo(fromConfig string) (CryptAlgo, error) {
 
 // ParseHash extracts all characteristics of a hash given its string representation.
 func ParseHash(hash string) (passwordHash *PasswordHash, err error) {
+	switch {
+	case strings.HasPrefix(hash, "$2a$"), strings.HasPrefix(hash, "$2b$"), strings.HasPrefix(hash, "$2y$"):
+		return parseBcryptHash(hash)
+	case strings.HasPrefix(hash, "$"+string(HashingAlgorithmScrypt)+"$"):
+		return parseScryptHash(hash)
+	case strings.HasPrefix(hash, "$"+string(HashingAlgorithmPBKDF2SHA256)+"$"):
+		return parsePBKDF2SHA256Hash(hash)
+	}
+
 	parts := strings.Split(hash, "$")
 
 	// This error can be ignored as it's always nil.
@@ -97,12 +117,131 @@ func ParseHash(hash string) (passwordHash *PasswordHash, err error) {
 			return nil, fmt.Errorf("Argon2id key length parameter (%d) does not match the actual key length (%d)", h.KeyLength, len(decodedKey))
 		}
 	default:
-		return nil, fmt.Errorf("Authelia only supports salted SHA512 hashing ($6$) and salted argon2id ($argon2id$), not $%s$", code)
+		return nil, fmt.Errorf("Authelia only supports salted SHA512 hashing ($6$), salted argon2id ($argon2id$), bcrypt ($2a$, $2b$, $2y$), scrypt ($scrypt$), and PBKDF2-SHA256 ($pbkdf2-sha256$), not $%s$", code)
+	}
+
+	return h, nil
+}
+
+// parseBcryptHash parses a bcrypt hash in the modular crypt format, i.e. $2b$<cost>$<salt><key>.
+func parseBcryptHash(hash string) (h *PasswordHash, err error) {
+	cost, err := bcrypt.Cost([]byte(hash))
+	if err != nil {
+		return nil, fmt.Errorf("Bcrypt hash is malformed (%s): %w", hash, err)
+	}
+
+	parts := strings.Split(hash, "$")
+
+	if len(parts) != 4 || len(parts[3]) != 53 {
+		return nil, fmt.Errorf("Bcrypt hash is malformed (%s)", hash)
+	}
+
+	return &PasswordHash{
+		Algorithm:  HashingAlgorithmBcrypt,
+		Iterations: cost,
+		Salt:       parts[3][:22],
+		Key:        parts[3][22:],
+		KeyLength:  23,
+		hash:       hash,
+	}, nil
+}
+
+// parseScryptHash parses a scrypt hash in the format used by passlib, i.e. $scrypt$ln=<log2 N>,r=<r>,p=<p>$<salt>$<key>.
+func parseScryptHash(hash string) (h *PasswordHash, err error) {
+	parts := strings.Split(hash, "$")
+
+	if len(parts) != 5 {
+		return nil, fmt.Errorf("Scrypt hash is malformed (%s)", hash)
+	}
+
+	h = &PasswordHash{
+		Algorithm: HashingAlgorithmScrypt,
+		Salt:      parts[3],
+		Key:       parts[4],
+	}
+
+	for _, parameter := range strings.Split(parts[2], ",") {
+		name, value, _ := strings.Cut(parameter, "=")
+
+		var v int
+
+		if v, err = strconv.Atoi(value); err != nil {
+			return nil, fmt.Errorf("Scrypt parameter '%s' is not numeric (%s)", name, value)
+		}
+
+		switch name {
+		case "ln":
+			h.Iterations = v
+		case "r":
+			h.BlockSize = v
+		case "p":
+			h.Parallelism = v
+		default:
+			return nil, fmt.Errorf("Scrypt parameter '%s' is unknown (%s)", name, hash)
+		}
+	}
+
+	if h.Iterations < 1 || h.Iterations > 31 || h.BlockSize < 1 || h.Parallelism < 1 {
+		return nil, fmt.Errorf("Scrypt parameters are invalid (%s)", parts[2])
+	}
+
+	if h.KeyLength, err = decodePasswordHashBase64Length(h.Salt, h.Key); err != nil {
+		return nil, fmt.Errorf("Scrypt hash is malformed (%s): %w", hash, err)
 	}
 
 	return h, nil
 }
 
+// parsePBKDF2SHA256Hash parses a PBKDF2-SHA256 hash in the format used by passlib, i.e.
+// $pbkdf2-sha256$<rounds>$<salt>$<key>.
+func parsePBKDF2SHA256Hash(hash string) (h *PasswordHash, err error) {
+	parts := strings.Split(hash, "$")
+
+	if len(parts) != 5 {
+		return nil, fmt.Errorf("PBKDF2-SHA256 hash is malformed (%s)", hash)
+	}
+
+	h = &PasswordHash{
+		Algorithm: HashingAlgorithmPBKDF2SHA256,
+		Salt:      parts[3],
+		Key:       parts[4],
+	}
+
+	if h.Iterations, err = strconv.Atoi(strings.TrimPrefix(parts[2], "i=")); err != nil || h.Iterations < 1 {
+		return nil, fmt.Errorf("PBKDF2-SHA256 iterations is not a positive number (%s)", parts[2])
+	}
+
+	if h.KeyLength, err = decodePasswordHashBase64Length(h.Salt, h.Key); err != nil {
+		return nil, fmt.Errorf("PBKDF2-SHA256 hash is malformed (%s): %w", hash, err)
+	}
+
+	return h, nil
+}
+
+// decodePasswordHashBase64 decodes the adapted base64 encoding used by passlib and similar libraries which uses '.'
+// instead of '+' and omits the padding. The standard base64 encoding is also accepted.
+func decodePasswordHashBase64(value string) (decoded []byte, err error) {
+	return base64.RawStdEncoding.DecodeString(strings.TrimRight(strings.ReplaceAll(value, ".", "+"), "="))
+}
+
+// decodePasswordHashBase64Length checks the salt and key are valid and returns the length of the decoded key.
+func decodePasswordHashBase64Length(salt, key string) (length int, err error) {
+	if _, err = decodePasswordHashBase64(salt); err != nil {
+		return 0, errors.New("salt contains invalid base64 characters")
+	}
+
+	decoded, err := decodePasswordHashBase64(key)
+	if err != nil {
+		return 0, errors.New("key contains invalid base64 characters")
+	}
+
+	if len(decoded) == 0 {
+		return 0, errors.New("key contains no characters")
+	}
+
+	return len(decoded), nil
+}
+
 // HashPassword generate a salt and hash the password with the salt and a constant number of rounds.
 func HashPassword(password, salt string, algorithm CryptAlgo, iterations, memory, parallelism, keyLength, saltLength int) (hash string, err error) {
 	var settings string
@@ -137,11 +276,82 @@ func HashPassword(password, salt string, algorithm CryptAlgo, iterations, memory
 	return hash, nil
 }
 
+// HashPasswordWithConfig generates a salt and hashes the password using the algorithm and parameters of the password
+// configuration.
+func HashPasswordWithConfig(password string, config *schema.PasswordConfiguration) (hash string, err error) {
+	algorithm, err := ConfigAlgoToCryptoAlgo(config.Algorithm)
+	if err != nil {
+		return "", err
+	}
+
+	return HashPassword(password, "", algorithm, config.Iterations, config.Memory*1024, config.Parallelism, config.KeyLength, config.SaltLength)
+}
+
 // CheckPassword check a password against a hash.
 func CheckPassword(password, hash string) (ok bool, err error) {
+	ok, _, err = CheckPasswordWithConfig(password, hash, nil)
+
+	return ok, err
+}
+
+// CheckPasswordWithConfig checks a password against a hash and if the password is valid reports if the hash should be
+// replaced by a hash using the algorithm and parameters of the password configuration.
+func CheckPasswordWithConfig(password, hash string, config *schema.PasswordConfiguration) (ok, rehash bool, err error) {
 	expectedHash, err := ParseHash(hash)
 	if err != nil {
-		return false, err
+		return false, false, err
+	}
+
+	if ok, err = checkPasswordHash(password, expectedHash); err != nil || !ok {
+		return false, false, err
+	}
+
+	return true, config != nil && !expectedHash.IsConfigured(config), nil
+}
+
+// IsConfigured returns true if the hash uses the algorithm and parameters of the password configuration.
+func (h *PasswordHash) IsConfigured(config *schema.PasswordConfiguration) bool {
+	algorithm, err := ConfigAlgoToCryptoAlgo(config.Algorithm)
+	if err != nil || h.Algorithm != algorithm {
+		return false
+	}
+
+	switch h.Algorithm {
+	case HashingAlgorithmArgon2id:
+		return h.Iterations == config.Iterations && h.Memory == config.Memory*1024 &&
+			h.Parallelism == config.Parallelism && h.KeyLength == config.KeyLength
+	default:
+		return h.Iterations == config.Iterations
+	}
+}
+
+func checkPasswordHash(password string, expectedHash *PasswordHash) (ok bool, err error) {
+	switch expectedHash.Algorithm {
+	case HashingAlgorithmBcrypt:
+		if err = bcrypt.CompareHashAndPassword([]byte(expectedHash.hash), []byte(password)); err != nil {
+			if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+				return false, nil
+			}
+
+			return false, err
+		}
+
+		return true, nil
+	case HashingAlgorithmScrypt, HashingAlgorithmPBKDF2SHA256:
+		salt, _ := decodePasswordHashBase64(expectedHash.Salt)
+		expected, _ := decodePasswordHashBase64(expectedHash.Key)
+
+		var key []byte
+
+		if expectedHash.Algorithm == HashingAlgorithmScrypt {
+			if key, err = scrypt.Key([]byte(password), salt, 1<<expectedHash.Iterations, expectedHash.BlockSize, expectedHash.Parallelism, len(expected)); err != nil {
+				return false, err
+			}
+		} else {
+			key = pbkdf2.Key([]byte(password), salt, expectedHash.Iterations, len(expected), sha256.New)
+		}
+
+		return subtle.ConstantTimeCompare(key, expected) == 1, nil
 	}
 
 	passwordHashString, err := HashPassword(password, expectedHash.Salt, expectedHash.Algorithm, expectedHash.Iterations, expectedHash.Memory, expectedHash.Parallelism, expectedHash.KeyLength, len(expectedHash.Salt))
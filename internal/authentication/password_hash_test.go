@@ -251,13 +251,128 @@ func TestCannotParseArgon2idHash(t *testing.T) {
 	assert.False(t, ok)
 }
 
-func TestOnlySupportSHA512AndArgon2id(t *testing.T) {
+func TestOnlySupportKnownAlgorithms(t *testing.T) {
 	ok, err := CheckPassword("password", "$8$rounds=50000$aFr56HjK3DrB8t3S$zhPQiS85cgBlNhUKKE6n/AHMlpqrvYSnSL3fEVkK0yHFQ.oFFAd8D4OhPAy18K5U61Z2eBhxQXExGU/eknXlY1")
 
-	assert.EqualError(t, err, "Authelia only supports salted SHA512 hashing ($6$) and salted argon2id ($argon2id$), not $8$")
+	assert.EqualError(t, err, "Authelia only supports salted SHA512 hashing ($6$), salted argon2id ($argon2id$), bcrypt ($2a$, $2b$, $2y$), scrypt ($scrypt$), and PBKDF2-SHA256 ($pbkdf2-sha256$), not $8$")
 	assert.False(t, ok)
 }
 
+func TestShouldCheckAdditionalAlgorithmPasswords(t *testing.T) {
+	testCases := []struct {
+		name      string
+		hash      string
+		algorithm CryptAlgo
+	}{
+		{"ShouldCheckBcrypt2b", "$2b$10$hbmlHQoYjbqQvfa3CrVwXe/7FdEJWnrVlCtrtl/RPTUMInVhutTSi", HashingAlgorithmBcrypt},
+		{"ShouldCheckBcrypt2y", "$2y$04$abcdefghijklmnopqrstuughE8Ev8uGFaUgY2cNEySvxngrb/Jzdm", HashingAlgorithmBcrypt},
+		{"ShouldCheckScrypt", "$scrypt$ln=14,r=8,p=1$YXV0aGVsaWEtc2NyeXB0IQ$Egu3uhcQ9I7HHRLHS0dvOkHUOw1q/FPRY20dsH.tMmk", HashingAlgorithmScrypt},
+		{"ShouldCheckPBKDF2SHA256", "$pbkdf2-sha256$29000$YXV0aGVsaWEtcGJrZGYyIQ$zg9Fen9cXyD4Ct5dryfTgwq6N2tayPqEPXekUldKQCM", HashingAlgorithmPBKDF2SHA256},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			passwordHash, err := ParseHash(tc.hash)
+			require.NoError(t, err)
+			assert.Equal(t, tc.algorithm, passwordHash.Algorithm)
+
+			ok, err := CheckPassword("password", tc.hash)
+			assert.NoError(t, err)
+			assert.True(t, ok)
+
+			ok, err = CheckPassword("wrong", tc.hash)
+			assert.NoError(t, err)
+			assert.False(t, ok)
+
+			ok, rehash, err := CheckPasswordWithConfig("password", tc.hash, &schema.DefaultCIPasswordConfiguration)
+			assert.NoError(t, err)
+			assert.True(t, ok)
+			assert.True(t, rehash)
+
+			ok, rehash, err = CheckPasswordWithConfig("wrong", tc.hash, &schema.DefaultCIPasswordConfiguration)
+			assert.NoError(t, err)
+			assert.False(t, ok)
+			assert.False(t, rehash)
+		})
+	}
+}
+
+func TestShouldParseAdditionalAlgorithmParameters(t *testing.T) {
+	passwordHash, err := ParseHash("$2b$10$hbmlHQoYjbqQvfa3CrVwXe/7FdEJWnrVlCtrtl/RPTUMInVhutTSi")
+	require.NoError(t, err)
+	assert.Equal(t, 10, passwordHash.Iterations)
+	assert.Equal(t, "hbmlHQoYjbqQvfa3CrVwXe", passwordHash.Salt)
+
+	passwordHash, err = ParseHash("$scrypt$ln=14,r=8,p=1$YXV0aGVsaWEtc2NyeXB0IQ$Egu3uhcQ9I7HHRLHS0dvOkHUOw1q/FPRY20dsH.tMmk")
+	require.NoError(t, err)
+	assert.Equal(t, 14, passwordHash.Iterations)
+	assert.Equal(t, 8, passwordHash.BlockSize)
+	assert.Equal(t, 1, passwordHash.Parallelism)
+	assert.Equal(t, 32, passwordHash.KeyLength)
+
+	passwordHash, err = ParseHash("$pbkdf2-sha256$29000$YXV0aGVsaWEtcGJrZGYyIQ$zg9Fen9cXyD4Ct5dryfTgwq6N2tayPqEPXekUldKQCM")
+	require.NoError(t, err)
+	assert.Equal(t, 29000, passwordHash.Iterations)
+	assert.Equal(t, 32, passwordHash.KeyLength)
+}
+
+func TestShouldNotParseMalformedAdditionalAlgorithmHashes(t *testing.T) {
+	testCases := []struct {
+		hash, expected string
+	}{
+		{"$2b$10$hbmlHQoYjbqQvfa3CrVwXe", "Bcrypt hash is malformed ($2b$10$hbmlHQoYjbqQvfa3CrVwXe): crypto/bcrypt: hashedSecret too short to be a bcrypted password"},
+		{"$scrypt$ln=14,r=8$YXV0aGVsaWEtc2NyeXB0IQ", "Scrypt hash is malformed ($scrypt$ln=14,r=8$YXV0aGVsaWEtc2NyeXB0IQ)"},
+		{"$scrypt$ln=abc,r=8,p=1$YXV0aGVsaWEtc2NyeXB0IQ$Egu3uhcQ9I7HHRLHS0dvOkHUOw1q", "Scrypt parameter 'ln' is not numeric (abc)"},
+		{"$scrypt$ln=14,r=8,x=1$YXV0aGVsaWEtc2NyeXB0IQ$Egu3uhcQ9I7HHRLHS0dvOkHUOw1q", "Scrypt parameter 'x' is unknown ($scrypt$ln=14,r=8,x=1$YXV0aGVsaWEtc2NyeXB0IQ$Egu3uhcQ9I7HHRLHS0dvOkHUOw1q)"},
+		{"$scrypt$ln=0,r=8,p=1$YXV0aGVsaWEtc2NyeXB0IQ$Egu3uhcQ9I7HHRLHS0dvOkHUOw1q", "Scrypt parameters are invalid (ln=0,r=8,p=1)"},
+		{"$pbkdf2-sha256$abc$YXV0aGVsaWEtcGJrZGYyIQ$zg9Fen9cXyD4Ct5dryfTgwq6N2tayPqEPXekUldKQCM", "PBKDF2-SHA256 iterations is not a positive number (abc)"},
+		{"$pbkdf2-sha256$29000$YXV0aGVsaWEtcGJrZGYyIQ$", "PBKDF2-SHA256 hash is malformed ($pbkdf2-sha256$29000$YXV0aGVsaWEtcGJrZGYyIQ$): key contains no characters"},
+		{"$pbkdf2-sha256$29000$YXV0aGVsaWEtcGJrZGYyIQ$zg9Fen9cXyD4Ct5!", "PBKDF2-SHA256 hash is malformed ($pbkdf2-sha256$29000$YXV0aGVsaWEtcGJrZGYyIQ$zg9Fen9cXyD4Ct5!): key contains invalid base64 characters"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.hash, func(t *testing.T) {
+			passwordHash, err := ParseHash(tc.hash)
+			assert.EqualError(t, err, tc.expected)
+			assert.Nil(t, passwordHash)
+		})
+	}
+}
+
+func TestShouldDetermineIfHashIsConfigured(t *testing.T) {
+	config := schema.DefaultCIPasswordConfiguration
+
+	hash, err := HashPasswordWithConfig("password", &config)
+	require.NoError(t, err)
+
+	ok, rehash, err := CheckPasswordWithConfig("password", hash, &config)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.False(t, rehash)
+
+	config.Iterations++
+
+	ok, rehash, err = CheckPasswordWithConfig("password", hash, &config)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.True(t, rehash)
+
+	config = schema.DefaultPasswordSHA512Configuration
+
+	ok, rehash, err = CheckPasswordWithConfig("password", hash, &config)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.True(t, rehash)
+
+	hash, err = HashPasswordWithConfig("password", &config)
+	require.NoError(t, err)
+
+	ok, rehash, err = CheckPasswordWithConfig("password", hash, &config)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.False(t, rehash)
+}
+
 func TestCannotFindNumberOfRounds(t *testing.T) {
 	hash := "$6$rounds50000$aFr56HjK3DrB8t3S$zhPQiS85cgBlNhUKKE6n/AHMlpqrvYSnSL3fEVkK0yHFQ.oFFAd8D4OhPAy18K5U61Z2eBhxQXExGU/eknXlY1"
 	ok, err := CheckPassword("password", hash)
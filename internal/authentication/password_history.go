@@ -0,0 +1,85 @@
+package authentication
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/storage"
+)
+
+// PasswordHistory enforces the password history of the password policy by checking new passwords against the previous
+// password hashes of the user which are kept in the storage provider. The history is kept separately from the
+// authentication backend so it's enforced regardless of the backend which stores the password.
+type PasswordHistory struct {
+	count    int
+	password *schema.PasswordConfiguration
+	storage  storage.UserPasswordHistoryProvider
+}
+
+// NewPasswordHistory creates a new instance of PasswordHistory. The passwords in the history are hashed with the password
+// configuration of the file or SQL authentication backend, or the default password configuration for other backends.
+func NewPasswordHistory(policy schema.PasswordPolicyConfiguration, backend schema.AuthenticationBackendConfiguration, provider storage.UserPasswordHistoryProvider) *PasswordHistory {
+	password := &schema.DefaultPasswordConfiguration
+
+	switch {
+	case backend.File != nil && backend.File.Password != nil:
+		password = backend.File.Password
+	case backend.SQL != nil && backend.SQL.Password != nil:
+		password = backend.SQL.Password
+	}
+
+	return &PasswordHistory{
+		count:    policy.History,
+		password: password,
+		storage:  provider,
+	}
+}
+
+// Enabled returns true if the password history is enforced.
+func (h *PasswordHistory) Enabled() bool {
+	return h != nil && h.count > 0
+}
+
+// Check returns ErrPasswordReused if the password matches one of the previous passwords of the user.
+func (h *PasswordHistory) Check(ctx context.Context, username, password string) (err error) {
+	if !h.Enabled() {
+		return nil
+	}
+
+	var history []model.UserPasswordHistory
+
+	if history, err = h.storage.LoadUserPasswordHistory(ctx, username, h.count); err != nil {
+		return err
+	}
+
+	var valid bool
+
+	for _, entry := range history {
+		if valid, err = CheckPassword(password, entry.Password); err != nil {
+			return fmt.Errorf("error checking the password history of user '%s': %w", username, err)
+		}
+
+		if valid {
+			return ErrPasswordReused
+		}
+	}
+
+	return nil
+}
+
+// Save adds the password to the history of the user, forgetting the passwords which are no longer part of the history.
+func (h *PasswordHistory) Save(ctx context.Context, username, password string) (err error) {
+	if !h.Enabled() {
+		return nil
+	}
+
+	var hash string
+
+	if hash, err = HashPasswordWithConfig(password, h.password); err != nil {
+		return fmt.Errorf("error hashing the password history of user '%s': %w", username, err)
+	}
+
+	return h.storage.SaveUserPasswordHistory(ctx, model.UserPasswordHistory{Username: username, Password: hash}, h.count)
+}
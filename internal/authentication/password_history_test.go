@@ -0,0 +1,96 @@
+package authentication
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/model"
+)
+
+func TestPasswordHistoryShouldRejectReusedPasswords(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	config := DefaultSQLAuthenticationBackendConfiguration
+	mock := NewMockUserPasswordHistoryProvider(ctrl)
+	history := NewPasswordHistory(schema.PasswordPolicyConfiguration{History: 3}, schema.AuthenticationBackendConfiguration{SQL: &config}, mock)
+
+	entries := []model.UserPasswordHistory{
+		{Username: "john", Password: "$6$rounds=1000$abcdefghijklmnop$yPaT7hGyfdMjnNkZEwc900g9ThWrc25AS/MrRHIZ1zurM.zlnknm1THckI9lpRCzs1cC2GelQzlngczULmWtp/"},
+		{Username: "john", Password: "$2y$04$abcdefghijklmnopqrstuughE8Ev8uGFaUgY2cNEySvxngrb/Jzdm"},
+	}
+
+	mock.EXPECT().LoadUserPasswordHistory(context.Background(), "john", 3).Return(entries, nil).Times(3)
+
+	assert.ErrorIs(t, history.Check(context.Background(), "john", "letmein"), ErrPasswordReused)
+	assert.ErrorIs(t, history.Check(context.Background(), "john", "password"), ErrPasswordReused)
+	assert.NoError(t, history.Check(context.Background(), "john", "correct horse battery staple"))
+}
+
+func TestPasswordHistoryShouldReturnErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	config := DefaultSQLAuthenticationBackendConfiguration
+	mock := NewMockUserPasswordHistoryProvider(ctrl)
+	history := NewPasswordHistory(schema.PasswordPolicyConfiguration{History: 3}, schema.AuthenticationBackendConfiguration{SQL: &config}, mock)
+
+	gomock.InOrder(
+		mock.EXPECT().LoadUserPasswordHistory(context.Background(), "john", 3).Return(nil, errors.New("database is locked")),
+		mock.EXPECT().LoadUserPasswordHistory(context.Background(), "john", 3).Return([]model.UserPasswordHistory{{Username: "john", Password: "$8$rounds=50000$aFr56HjK3DrB8t3S$zhPQiS85cgBlNhUKKE6n/AHMlpqrvYSnSL3fEVkK0yHFQ.oFFAd8D4OhPAy18K5U61Z2eBhxQXExGU/eknXlY1"}}, nil),
+	)
+
+	assert.EqualError(t, history.Check(context.Background(), "john", "password"), "database is locked")
+	assert.EqualError(t, history.Check(context.Background(), "john", "password"), "error checking the password history of user 'john': Authelia only supports salted SHA512 hashing ($6$), salted argon2id ($argon2id$), bcrypt ($2a$, $2b$, $2y$), scrypt ($scrypt$), and PBKDF2-SHA256 ($pbkdf2-sha256$), not $8$")
+}
+
+func TestPasswordHistoryShouldSaveHashedPasswords(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	config := DefaultSQLAuthenticationBackendConfiguration
+	mock := NewMockUserPasswordHistoryProvider(ctrl)
+	history := NewPasswordHistory(schema.PasswordPolicyConfiguration{History: 5}, schema.AuthenticationBackendConfiguration{SQL: &config}, mock)
+
+	var saved model.UserPasswordHistory
+
+	mock.EXPECT().SaveUserPasswordHistory(context.Background(), gomock.Any(), 5).DoAndReturn(func(_ context.Context, entry model.UserPasswordHistory, _ int) error {
+		saved = entry
+
+		return nil
+	})
+
+	require.NoError(t, history.Save(context.Background(), "john", "newpassword"))
+
+	assert.Equal(t, "john", saved.Username)
+	assert.True(t, strings.HasPrefix(saved.Password, "$6$rounds=1000$"))
+
+	valid, err := CheckPassword("newpassword", saved.Password)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+}
+
+func TestPasswordHistoryShouldDoNothingWhenDisabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	config := DefaultSQLAuthenticationBackendConfiguration
+	mock := NewMockUserPasswordHistoryProvider(ctrl)
+	history := NewPasswordHistory(schema.PasswordPolicyConfiguration{History: 0}, schema.AuthenticationBackendConfiguration{SQL: &config}, mock)
+
+	assert.False(t, history.Enabled())
+	assert.NoError(t, history.Check(context.Background(), "john", "password"))
+	assert.NoError(t, history.Save(context.Background(), "john", "password"))
+
+	history = nil
+
+	assert.False(t, history.Enabled())
+	assert.NoError(t, history.Check(context.Background(), "john", "password"))
+}
@@ -0,0 +1,155 @@
+package authentication
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/logging"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/storage"
+)
+
+// SQLUserProvider is a provider reading details from the user accounts stored in the storage database.
+type SQLUserProvider struct {
+	configuration *schema.SQLAuthenticationBackendConfiguration
+	storage       storage.UserAccountProvider
+}
+
+// NewSQLUserProvider creates a new instance of SQLUserProvider.
+func NewSQLUserProvider(configuration *schema.SQLAuthenticationBackendConfiguration, provider storage.UserAccountProvider) *SQLUserProvider {
+	return &SQLUserProvider{
+		configuration: configuration,
+		storage:       provider,
+	}
+}
+
+// CheckUserPassword checks if provided password matches for the given user. If the password matches but the hash doesn't
+// use the configured algorithm and parameters the password is rehashed so hashes are upgraded over time.
+func (p *SQLUserProvider) CheckUserPassword(username string, password string) (valid bool, err error) {
+	account, err := p.load(username)
+	if err != nil {
+		return false, err
+	}
+
+	var rehash bool
+
+	if valid, rehash, err = CheckPasswordWithConfig(password, account.Password, p.configuration.Password); err != nil {
+		return false, err
+	}
+
+	if rehash {
+		p.rehash(username, password, account.Password)
+	}
+
+	return valid, nil
+}
+
+// rehash replaces the hash of the password of the user with a hash using the configured algorithm and parameters
+// provided the hash hasn't changed since the password was checked. Failures are logged as the password was valid.
+func (p *SQLUserProvider) rehash(username, password, hash string) {
+	logger := logging.Logger()
+
+	newHash, err := HashPasswordWithConfig(password, p.configuration.Password)
+	if err != nil {
+		logger.WithError(err).Errorf("Error occurred rehashing the password of user '%s'", username)
+
+		return
+	}
+
+	replaced, err := p.storage.ReplaceUserAccountPassword(context.Background(), username, hash, newHash)
+
+	switch {
+	case err != nil:
+		logger.WithError(err).Errorf("Error occurred saving the rehashed password of user '%s'", username)
+	case !replaced:
+		logger.Debugf("Skipped rehashing the password of user '%s' as it was changed while it was checked", username)
+	default:
+		logger.Debugf("Rehashed the password of user '%s' with the configured algorithm and parameters", username)
+	}
+}
+
+// GetDetails retrieve the details of a user.
+func (p *SQLUserProvider) GetDetails(username string) (details *UserDetails, err error) {
+	account, err := p.load(username)
+	if err != nil {
+		return nil, err
+	}
+
+	details = &UserDetails{
+		Username:    account.Username,
+		DisplayName: account.DisplayName,
+		Groups:      account.Groups,
+	}
+
+	if account.Email != "" {
+		details.Emails = []string{account.Email}
+	}
+
+	return details, nil
+}
+
+// UpdatePassword update the password of the given user.
+func (p *SQLUserProvider) UpdatePassword(username string, newPassword string) (err error) {
+	hash, err := HashPasswordWithConfig(newPassword, p.configuration.Password)
+	if err != nil {
+		return err
+	}
+
+	if err = p.storage.UpdateUserAccountPassword(context.Background(), username, hash); err != nil {
+		if errors.Is(err, storage.ErrNoUserAccount) {
+			return ErrUserNotFound
+		}
+
+		return fmt.Errorf("unable to update password for user '%s': %w", username, err)
+	}
+
+	return nil
+}
+
+// CreateUser creates a new user account with the password hashed using the configured algorithm and parameters.
+func (p *SQLUserProvider) CreateUser(username, displayName, email, password string, groups []string) (err error) {
+	if _, err = p.load(username); err == nil {
+		return ErrUserAlreadyExists
+	} else if !errors.Is(err, ErrUserNotFound) {
+		return err
+	}
+
+	hash, err := HashPasswordWithConfig(password, p.configuration.Password)
+	if err != nil {
+		return err
+	}
+
+	account := model.UserAccount{
+		Username:    username,
+		Password:    hash,
+		DisplayName: displayName,
+		Email:       email,
+		Groups:      groups,
+	}
+
+	if err = p.storage.SaveUserAccount(context.Background(), account); err != nil {
+		return fmt.Errorf("unable to create user account for user '%s': %w", username, err)
+	}
+
+	return nil
+}
+
+// StartupCheck implements the startup check provider interface. The tables are created by the storage provider
+// startup check which migrates the schema.
+func (p *SQLUserProvider) StartupCheck() (err error) {
+	return nil
+}
+
+func (p *SQLUserProvider) load(username string) (account *model.UserAccount, err error) {
+	if account, err = p.storage.LoadUserAccount(context.Background(), username); err != nil {
+		if errors.Is(err, storage.ErrNoUserAccount) {
+			return nil, ErrUserNotFound
+		}
+
+		return nil, fmt.Errorf("unable to retrieve user account for user '%s': %w", username, err)
+	}
+
+	return account, nil
+}
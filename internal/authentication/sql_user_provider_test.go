@@ -0,0 +1,214 @@
+package authentication
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/storage"
+)
+
+func TestSQLUserProviderShouldCheckUserPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	config := DefaultSQLAuthenticationBackendConfiguration
+	mock := NewMockUserAccountProvider(ctrl)
+	provider := NewSQLUserProvider(&config, mock)
+
+	hash, err := HashPasswordWithConfig("password", provider.configuration.Password)
+	require.NoError(t, err)
+
+	mock.EXPECT().LoadUserAccount(context.Background(), "john").Return(&model.UserAccount{Username: "john", Password: hash}, nil).Times(2)
+
+	valid, err := provider.CheckUserPassword("john", "password")
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	valid, err = provider.CheckUserPassword("john", "wrong")
+	assert.NoError(t, err)
+	assert.False(t, valid)
+}
+
+func TestSQLUserProviderShouldRehashPasswordOnLogin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	config := DefaultSQLAuthenticationBackendConfiguration
+	mock := NewMockUserAccountProvider(ctrl)
+	provider := NewSQLUserProvider(&config, mock)
+
+	hash := "$2b$10$hbmlHQoYjbqQvfa3CrVwXe/7FdEJWnrVlCtrtl/RPTUMInVhutTSi"
+
+	var rehashed string
+
+	gomock.InOrder(
+		mock.EXPECT().LoadUserAccount(context.Background(), "john").Return(&model.UserAccount{Username: "john", Password: hash}, nil).Times(2),
+		mock.EXPECT().ReplaceUserAccountPassword(context.Background(), "john", hash, gomock.Any()).DoAndReturn(func(_ context.Context, _, _, password string) (bool, error) {
+			rehashed = password
+
+			return true, nil
+		}),
+		mock.EXPECT().LoadUserAccount(context.Background(), "john").Return(&model.UserAccount{Username: "john", Password: hash}, nil),
+		mock.EXPECT().ReplaceUserAccountPassword(context.Background(), "john", hash, gomock.Any()).Return(false, nil),
+	)
+
+	valid, err := provider.CheckUserPassword("john", "wrong")
+	assert.NoError(t, err)
+	assert.False(t, valid)
+
+	valid, err = provider.CheckUserPassword("john", "password")
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	assert.True(t, strings.HasPrefix(rehashed, "$6$rounds=1000$"))
+
+	// The password is still valid when the hash was changed while it was checked and isn't replaced.
+	valid, err = provider.CheckUserPassword("john", "password")
+	assert.NoError(t, err)
+	assert.True(t, valid)
+}
+
+func TestSQLUserProviderShouldReturnUserNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	config := DefaultSQLAuthenticationBackendConfiguration
+	mock := NewMockUserAccountProvider(ctrl)
+	provider := NewSQLUserProvider(&config, mock)
+
+	gomock.InOrder(
+		mock.EXPECT().LoadUserAccount(context.Background(), "fred").Return(nil, storage.ErrNoUserAccount).Times(2),
+		mock.EXPECT().UpdateUserAccountPassword(context.Background(), "fred", gomock.Any()).Return(storage.ErrNoUserAccount),
+	)
+
+	valid, err := provider.CheckUserPassword("fred", "password")
+	assert.ErrorIs(t, err, ErrUserNotFound)
+	assert.False(t, valid)
+
+	details, err := provider.GetDetails("fred")
+	assert.ErrorIs(t, err, ErrUserNotFound)
+	assert.Nil(t, details)
+
+	assert.ErrorIs(t, provider.UpdatePassword("fred", "password"), ErrUserNotFound)
+}
+
+func TestSQLUserProviderShouldGetDetails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	config := DefaultSQLAuthenticationBackendConfiguration
+	mock := NewMockUserAccountProvider(ctrl)
+	provider := NewSQLUserProvider(&config, mock)
+
+	gomock.InOrder(
+		mock.EXPECT().LoadUserAccount(context.Background(), "john").Return(&model.UserAccount{
+			Username:    "john",
+			DisplayName: "John Doe",
+			Email:       "john.doe@example.com",
+			Groups:      []string{"admins", "dev"},
+		}, nil),
+		mock.EXPECT().LoadUserAccount(context.Background(), "bob").Return(&model.UserAccount{
+			Username:    "bob",
+			DisplayName: "Bob Dylan",
+		}, nil),
+		mock.EXPECT().LoadUserAccount(context.Background(), "harry").Return(nil, errors.New("database is locked")),
+	)
+
+	details, err := provider.GetDetails("john")
+	require.NoError(t, err)
+
+	assert.Equal(t, "john", details.Username)
+	assert.Equal(t, "John Doe", details.DisplayName)
+	assert.Equal(t, []string{"john.doe@example.com"}, details.Emails)
+	assert.Equal(t, []string{"admins", "dev"}, details.Groups)
+
+	details, err = provider.GetDetails("bob")
+	require.NoError(t, err)
+
+	assert.Equal(t, "Bob Dylan", details.DisplayName)
+	assert.Nil(t, details.Emails)
+	assert.Nil(t, details.Groups)
+
+	details, err = provider.GetDetails("harry")
+	assert.EqualError(t, err, "unable to retrieve user account for user 'harry': database is locked")
+	assert.Nil(t, details)
+}
+
+func TestSQLUserProviderShouldUpdatePassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	config := DefaultSQLAuthenticationBackendConfiguration
+	mock := NewMockUserAccountProvider(ctrl)
+	provider := NewSQLUserProvider(&config, mock)
+
+	var hash string
+
+	mock.EXPECT().UpdateUserAccountPassword(context.Background(), "john", gomock.Any()).DoAndReturn(func(_ context.Context, _, password string) error {
+		hash = password
+
+		return nil
+	})
+
+	require.NoError(t, provider.UpdatePassword("john", "newpassword"))
+
+	assert.True(t, strings.HasPrefix(hash, "$6$rounds=1000$"))
+
+	valid, err := CheckPassword("newpassword", hash)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+}
+
+func TestSQLUserProviderShouldCreateUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	config := DefaultSQLAuthenticationBackendConfiguration
+	mock := NewMockUserAccountProvider(ctrl)
+	provider := NewSQLUserProvider(&config, mock)
+
+	var account model.UserAccount
+
+	gomock.InOrder(
+		mock.EXPECT().LoadUserAccount(context.Background(), "fred").Return(nil, storage.ErrNoUserAccount),
+		mock.EXPECT().SaveUserAccount(context.Background(), gomock.Any()).DoAndReturn(func(_ context.Context, a model.UserAccount) error {
+			account = a
+
+			return nil
+		}),
+		mock.EXPECT().LoadUserAccount(context.Background(), "john").Return(&model.UserAccount{Username: "john"}, nil),
+		mock.EXPECT().LoadUserAccount(context.Background(), "sam").Return(nil, errors.New("database is locked")),
+	)
+
+	require.NoError(t, provider.CreateUser("fred", "Fred", "fred@example.com", "password", []string{"users"}))
+
+	assert.Equal(t, "fred", account.Username)
+	assert.Equal(t, "Fred", account.DisplayName)
+	assert.Equal(t, "fred@example.com", account.Email)
+	assert.Equal(t, []string{"users"}, account.Groups)
+
+	valid, err := CheckPassword("password", account.Password)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	assert.ErrorIs(t, provider.CreateUser("john", "John", "john@example.com", "password", nil), ErrUserAlreadyExists)
+	assert.EqualError(t, provider.CreateUser("sam", "Sam", "sam@example.com", "password", nil), "unable to retrieve user account for user 'sam': database is locked")
+}
+
+var (
+	DefaultSQLAuthenticationBackendConfiguration = schema.SQLAuthenticationBackendConfiguration{
+		Password: &schema.PasswordConfiguration{
+			Algorithm:  "sha512",
+			Iterations: 1000,
+			SaltLength: 16,
+		},
+	}
+)
@@ -21,6 +21,7 @@ type LDAPClient interface {
 	StartTLS(config *tls.Config) (err error)
 
 	Bind(username, password string) (err error)
+	SimpleBind(simpleBindRequest *ldap.SimpleBindRequest) (simpleBindResult *ldap.SimpleBindResult, err error)
 	UnauthenticatedBind(username string) (err error)
 
 	Modify(modifyRequest *ldap.ModifyRequest) (err error)
@@ -35,6 +36,9 @@ type UserDetails struct {
 	DisplayName string
 	Emails      []string
 	Groups      []string
+
+	// Attributes are the configured extra attributes of the user keyed by the lowercase attribute name.
+	Attributes map[string][]string
 }
 
 // Addresses returns the Emails []string as []mail.Address formatted with DisplayName as the Name attribute.
@@ -60,12 +64,14 @@ type ldapUserProfile struct {
 	Emails      []string
 	DisplayName string
 	Username    string
+	Attributes  map[string][]string
 }
 
 // LDAPSupportedFeatures represents features which a server may support which are implemented in code.
 type LDAPSupportedFeatures struct {
 	Extensions   LDAPSupportedExtensions
 	ControlTypes LDAPSupportedControlTypes
+	Capabilities LDAPSupportedCapabilities
 }
 
 // LDAPSupportedExtensions represents extensions which a server may support which are implemented in code.
@@ -80,4 +86,9 @@ type LDAPSupportedControlTypes struct {
 	MsftPwdPolHintsDeprecated bool
 }
 
+// LDAPSupportedCapabilities represents capabilities which a server may support which are implemented in code.
+type LDAPSupportedCapabilities struct {
+	ActiveDirectory bool
+}
+
 var utf16LittleEndian = unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM)
@@ -1,6 +1,8 @@
 package authentication
 
 import (
+	"context"
+
 	"github.com/authelia/authelia/v4/internal/model"
 )
 
@@ -13,3 +15,15 @@ type UserProvider interface {
 	GetDetails(username string) (details *UserDetails, err error)
 	UpdatePassword(username string, newPassword string) (err error)
 }
+
+// UserCreator is the interface implemented by the user providers which are able to create users, i.e. for the
+// self-service registration.
+type UserCreator interface {
+	CreateUser(username, displayName, email, password string, groups []string) (err error)
+}
+
+// UserWatcher is the interface implemented by the user providers which are able to watch their backend for changes,
+// i.e. the file provider when the watch option is enabled. Watch blocks until the context is done.
+type UserWatcher interface {
+	Watch(ctx context.Context)
+}
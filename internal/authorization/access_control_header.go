@@ -0,0 +1,68 @@
+package authorization
+
+import (
+	"net/http"
+	"regexp"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+// NewAccessControlHeaders creates a new AccessControlHeaders given a schema.ACLHeaderRule slice.
+func NewAccessControlHeaders(rules []schema.ACLHeaderRule) (headers AccessControlHeaders) {
+	for _, rule := range rules {
+		name := http.CanonicalHeaderKey(rule.Name)
+
+		if rule.Value == nil {
+			headers.Matchers = append(headers.Matchers, AccessControlHeaderMatcherPresent{Name: name})
+
+			continue
+		}
+
+		headers.Matchers = append(headers.Matchers, AccessControlHeaderMatcherPattern{Name: name, Pattern: *rule.Value})
+	}
+
+	return headers
+}
+
+// AccessControlHeaders represents an ACL request header condition where all matchers must match.
+type AccessControlHeaders struct {
+	Matchers []ObjectMatcher
+}
+
+// IsMatch returns true if all the ACL header matchers match the object.
+func (ach AccessControlHeaders) IsMatch(object Object) (match bool) {
+	for _, matcher := range ach.Matchers {
+		if !matcher.IsMatch(object) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// AccessControlHeaderMatcherPresent matches when the header is present in the object.
+type AccessControlHeaderMatcherPresent struct {
+	Name string
+}
+
+// IsMatch returns true if the header is present in the object headers.
+func (m AccessControlHeaderMatcherPresent) IsMatch(object Object) (match bool) {
+	return len(object.Header.Values(m.Name)) != 0
+}
+
+// AccessControlHeaderMatcherPattern matches when a value of the header matches the pattern.
+type AccessControlHeaderMatcherPattern struct {
+	Name    string
+	Pattern regexp.Regexp
+}
+
+// IsMatch returns true if any value of the header in the object headers matches the pattern.
+func (m AccessControlHeaderMatcherPattern) IsMatch(object Object) (match bool) {
+	for _, value := range object.Header.Values(m.Name) {
+		if m.Pattern.MatchString(value) {
+			return true
+		}
+	}
+
+	return false
+}
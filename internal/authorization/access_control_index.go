@@ -0,0 +1,104 @@
+package authorization
+
+import (
+	"sort"
+	"strings"
+)
+
+// NewAccessControlIndex precompiles the rules into an index keyed by the exact domains and the wildcard domain
+// suffixes. Rules which can't be indexed by domain such as those which have domain regex or user/group wildcard
+// domains, or have no domains at all, are placed in the fallback bucket and are candidates for every domain.
+func NewAccessControlIndex(rules []*AccessControlRule) (index *AccessControlIndex) {
+	index = &AccessControlIndex{
+		exact:    map[string][]int{},
+		wildcard: map[string][]int{},
+	}
+
+	for i, rule := range rules {
+		if !isRuleIndexable(rule) {
+			index.fallback = append(index.fallback, i)
+
+			continue
+		}
+
+		for _, domain := range rule.Domains {
+			m := domain.Matcher.(*AccessControlDomainMatcher)
+
+			if m.Wildcard {
+				index.wildcard[m.Name] = appendIndexUnique(index.wildcard[m.Name], i)
+			} else {
+				index.exact[m.Name] = appendIndexUnique(index.exact[m.Name], i)
+			}
+		}
+	}
+
+	return index
+}
+
+// AccessControlIndex is a domain index of the rules which allows finding the candidate rules for a domain without
+// evaluating every rule.
+type AccessControlIndex struct {
+	exact    map[string][]int
+	wildcard map[string][]int
+	fallback []int
+}
+
+// Candidates returns the indexes of the rules which may match the domain in the same order as they are configured.
+// Rules which are not returned are guaranteed to not match the domain.
+func (idx *AccessControlIndex) Candidates(domain string) (candidates []int) {
+	candidates = append(candidates, idx.fallback...)
+	candidates = append(candidates, idx.exact[strings.ToLower(domain)]...)
+
+	// The wildcard domain matcher matches every domain with the suffix which includes the leading period, so each of
+	// the suffixes starting at a period are looked up.
+	for i := strings.IndexByte(domain, '.'); i != -1; {
+		candidates = append(candidates, idx.wildcard[domain[i:]]...)
+
+		next := strings.IndexByte(domain[i+1:], '.')
+		if next == -1 {
+			break
+		}
+
+		i += next + 1
+	}
+
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	sort.Ints(candidates)
+
+	n := 1
+
+	for i := 1; i < len(candidates); i++ {
+		if candidates[i] != candidates[n-1] {
+			candidates[n] = candidates[i]
+			n++
+		}
+	}
+
+	return candidates[:n]
+}
+
+func isRuleIndexable(rule *AccessControlRule) bool {
+	if len(rule.Domains) == 0 {
+		return false
+	}
+
+	for _, domain := range rule.Domains {
+		m, ok := domain.Matcher.(*AccessControlDomainMatcher)
+		if !ok || m.UserWildcard || m.GroupWildcard {
+			return false
+		}
+	}
+
+	return true
+}
+
+func appendIndexUnique(indexes []int, i int) []int {
+	if len(indexes) != 0 && indexes[len(indexes)-1] == i {
+		return indexes
+	}
+
+	return append(indexes, i)
+}
@@ -0,0 +1,144 @@
+package authorization
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+func TestAccessControlIndexCandidates(t *testing.T) {
+	rules := NewAccessControlRules(schema.AccessControlConfiguration{
+		Rules: []schema.ACLRule{
+			{Domains: []string{"*.example.com"}, Policy: oneFactor},
+			{DomainsRegex: []regexp.Regexp{*regexp.MustCompile(`^api\.`)}, Policy: bypass},
+			{Domains: []string{"app.example.com", "App.Example.com", "*.app.example.com"}, Policy: twoFactor},
+			{Domains: []string{"{user}.example.com"}, Policy: oneFactor},
+			{Policy: deny},
+			{Domains: []string{"other.com"}, Policy: deny},
+		},
+	})
+
+	index := NewAccessControlIndex(rules)
+
+	testCases := []struct {
+		name     string
+		domain   string
+		expected []int
+	}{
+		{"ShouldReturnExactAndWildcardInOrder", "app.example.com", []int{0, 1, 2, 3, 4}},
+		{"ShouldReturnExactCaseInsensitive", "APP.EXAMPLE.COM", []int{1, 2, 3, 4}},
+		{"ShouldReturnNestedWildcard", "x.app.example.com", []int{0, 1, 2, 3, 4}},
+		{"ShouldReturnExact", "other.com", []int{1, 3, 4, 5}},
+		{"ShouldReturnOnlyFallback", "example.org", []int{1, 3, 4}},
+		{"ShouldReturnOnlyFallbackNoPeriod", "localhost", []int{1, 3, 4}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, index.Candidates(tc.domain))
+		})
+	}
+}
+
+func TestAccessControlIndexShouldMatchLinearEvaluation(t *testing.T) {
+	authorizer := newBenchmarkAuthorizer(200)
+
+	domains := []string{
+		"example.com", "app.example.com", "APP.example.com", "x.app.example.com", "public.example.com",
+		"regex-10.example.com", "john.example.com", "app-50.example.com", "a.wildcard-73.example.com",
+		"wildcard-73.example.com", "app-199.example.com", "app-200.example.com", "unknown.org",
+	}
+
+	subjects := []Subject{AnonymousUser, John, Bob}
+
+	for _, domain := range domains {
+		for _, subject := range subjects {
+			object := NewObject(&url.URL{Scheme: "https", Host: domain, Path: "/"}, "GET")
+
+			hasSubjects, level := authorizer.GetRequiredLevel(subject, object)
+			expectedHasSubjects, expectedLevel := getRequiredLevelLinear(authorizer, subject, object)
+
+			assert.Equal(t, expectedLevel, level, "domain %s subject %s", domain, subject)
+			assert.Equal(t, expectedHasSubjects, hasSubjects, "domain %s subject %s", domain, subject)
+		}
+	}
+}
+
+func BenchmarkGetRequiredLevel(b *testing.B) {
+	authorizer := newBenchmarkAuthorizer(2000)
+
+	objects := []Object{
+		NewObject(&url.URL{Scheme: "https", Host: "app-1.example.com", Path: "/"}, "GET"),
+		NewObject(&url.URL{Scheme: "https", Host: "app-1999.example.com", Path: "/"}, "GET"),
+		NewObject(&url.URL{Scheme: "https", Host: "a.wildcard-1500.example.com", Path: "/"}, "GET"),
+		NewObject(&url.URL{Scheme: "https", Host: "unknown.example.org", Path: "/"}, "GET"),
+	}
+
+	b.Run("Indexed", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			authorizer.GetRequiredLevel(John, objects[i%len(objects)])
+		}
+	})
+
+	b.Run("Linear", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			getRequiredLevelLinear(authorizer, John, objects[i%len(objects)])
+		}
+	})
+}
+
+// getRequiredLevelLinear is the reference implementation which evaluates every rule in order.
+func getRequiredLevelLinear(authorizer *Authorizer, subject Subject, object Object) (hasSubjects bool, level Level) {
+	now := authorizer.clock.Now()
+
+	for _, rule := range authorizer.rules {
+		if rule.IsMatch(subject, object) && rule.IsActive(now) {
+			return len(rule.Subjects) > 0, rule.Policy
+		}
+	}
+
+	return false, authorizer.defaultPolicy
+}
+
+// newBenchmarkAuthorizer creates an Authorizer with a mix of exact, wildcard, and regex domain rules.
+func newBenchmarkAuthorizer(n int) *Authorizer {
+	config := schema.AccessControlConfiguration{DefaultPolicy: deny}
+
+	for i := 0; i < n; i++ {
+		switch i % 4 {
+		case 0, 1:
+			config.Rules = append(config.Rules, schema.ACLRule{
+				Domains:  []string{fmt.Sprintf("app-%d.example.com", i)},
+				Subjects: [][]string{{"group:dev"}},
+				Policy:   twoFactor,
+			})
+		case 2:
+			config.Rules = append(config.Rules, schema.ACLRule{
+				Domains: []string{fmt.Sprintf("*.wildcard-%d.example.com", i)},
+				Policy:  oneFactor,
+			})
+		default:
+			config.Rules = append(config.Rules, schema.ACLRule{
+				DomainsRegex: []regexp.Regexp{*regexp.MustCompile(fmt.Sprintf(`^regex-%d\.example\.com$`, i))},
+				Policy:       bypass,
+			})
+		}
+	}
+
+	config.Rules = append(config.Rules,
+		schema.ACLRule{Domains: []string{"{user}.example.com"}, Policy: oneFactor},
+		schema.ACLRule{Domains: []string{"*.example.com"}, Policy: twoFactor},
+	)
+
+	return NewAuthorizer(&schema.Configuration{AccessControl: config}, utils.RealClock{}, nil)
+}
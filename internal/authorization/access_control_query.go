@@ -0,0 +1,65 @@
+package authorization
+
+import (
+	"regexp"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+// NewAccessControlQuery creates a new AccessControlQuery given a schema.ACLQueryRule slice.
+func NewAccessControlQuery(rules []schema.ACLQueryRule) (query AccessControlQuery) {
+	for _, rule := range rules {
+		if rule.Value == nil {
+			query.Matchers = append(query.Matchers, AccessControlQueryMatcherPresent{Key: rule.Key})
+
+			continue
+		}
+
+		query.Matchers = append(query.Matchers, AccessControlQueryMatcherPattern{Key: rule.Key, Pattern: *rule.Value})
+	}
+
+	return query
+}
+
+// AccessControlQuery represents an ACL query condition where all matchers must match.
+type AccessControlQuery struct {
+	Matchers []ObjectMatcher
+}
+
+// IsMatch returns true if all the ACL query matchers match the object.
+func (acq AccessControlQuery) IsMatch(object Object) (match bool) {
+	for _, matcher := range acq.Matchers {
+		if !matcher.IsMatch(object) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// AccessControlQueryMatcherPresent matches when the query parameter is present in the object.
+type AccessControlQueryMatcherPresent struct {
+	Key string
+}
+
+// IsMatch returns true if the query parameter is present in the object URL.
+func (m AccessControlQueryMatcherPresent) IsMatch(object Object) (match bool) {
+	return object.URL.Query().Has(m.Key)
+}
+
+// AccessControlQueryMatcherPattern matches when a value of the query parameter matches the pattern.
+type AccessControlQueryMatcherPattern struct {
+	Key     string
+	Pattern regexp.Regexp
+}
+
+// IsMatch returns true if any value of the query parameter in the object URL matches the pattern.
+func (m AccessControlQueryMatcherPattern) IsMatch(object Object) (match bool) {
+	for _, value := range object.URL.Query()[m.Key] {
+		if m.Pattern.MatchString(value) {
+			return true
+		}
+	}
+
+	return false
+}
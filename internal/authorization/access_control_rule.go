@@ -2,6 +2,7 @@ package authorization
 
 import (
 	"net"
+	"time"
 
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/utils"
@@ -26,8 +27,16 @@ func NewAccessControlRule(pos int, rule schema.ACLRule, networksMap map[string][
 		Resources: schemaResourcesToACL(rule.Resources),
 		Methods:   schemaMethodsToACL(rule.Methods),
 		Networks:  schemaNetworksToACL(rule.Networks, networksMap, networksCacheMap),
+		Countries: schemaCountriesToACL(rule.Countries),
+		ASNs:      rule.ASNs,
 		Subjects:  schemaSubjectsToACL(rule.Subjects),
+		Query:     schemaQueryToACL(rule.Query),
+		Headers:   schemaHeadersToACL(rule.Headers),
+		Schedule:  schemaScheduleToACL(rule.Schedule),
 		Policy:    StringToLevel(rule.Policy),
+
+		AuthenticationMethods: schemaAuthenticationMethodsToACL(rule.AuthenticationMethods),
+		MaxAuthenticationAge:  rule.MaxAuthenticationAge,
 	}
 }
 
@@ -38,8 +47,42 @@ type AccessControlRule struct {
 	Resources []AccessControlResource
 	Methods   []string
 	Networks  []*net.IPNet
+	Countries []string
+	ASNs      []uint
 	Subjects  []AccessControlSubjects
+	Query     []AccessControlQuery
+	Headers   []AccessControlHeaders
+	Schedule  []AccessControlSchedule
 	Policy    Level
+
+	AuthenticationMethods []string
+	MaxAuthenticationAge  time.Duration
+}
+
+// IsAuthenticationAgeSufficient returns true if the rule doesn't have a maximum authentication age or the provided time
+// elapsed since the relevant factor of authentication was completed doesn't exceed it.
+func (acr *AccessControlRule) IsAuthenticationAgeSufficient(age time.Duration) bool {
+	if acr.MaxAuthenticationAge <= 0 {
+		return true
+	}
+
+	return age <= acr.MaxAuthenticationAge
+}
+
+// IsAuthenticationMethodsSufficient returns true if the rule doesn't require any particular authentication methods or
+// at least one of the required authentication methods is in the provided list of completed methods.
+func (acr *AccessControlRule) IsAuthenticationMethodsSufficient(methods []string) bool {
+	if len(acr.AuthenticationMethods) == 0 {
+		return true
+	}
+
+	for _, method := range acr.AuthenticationMethods {
+		if utils.IsStringInSlice(method, methods) {
+			return true
+		}
+	}
+
+	return false
 }
 
 // IsMatch returns true if all elements of an AccessControlRule match the object and subject.
@@ -60,6 +103,18 @@ func (acr *AccessControlRule) IsMatch(subject Subject, object Object) (match boo
 		return false
 	}
 
+	if !isMatchForLocation(subject, acr) {
+		return false
+	}
+
+	if !isMatchForQuery(object, acr) {
+		return false
+	}
+
+	if !isMatchForHeaders(object, acr) {
+		return false
+	}
+
 	if !isMatchForSubjects(subject, acr) {
 		return false
 	}
@@ -67,6 +122,11 @@ func (acr *AccessControlRule) IsMatch(subject Subject, object Object) (match boo
 	return true
 }
 
+// IsActive returns true if the AccessControlRule schedule allows it to apply at the given time.
+func (acr *AccessControlRule) IsActive(now time.Time) (active bool) {
+	return isMatchForSchedule(now, acr)
+}
+
 func isMatchForDomains(subject Subject, object Object, acl *AccessControlRule) (match bool) {
 	// If there are no domains in this rule then the domain condition is a match.
 	if len(acl.Domains) == 0 {
@@ -124,6 +184,86 @@ func isMatchForNetworks(subject Subject, acl *AccessControlRule) (match bool) {
 	return false
 }
 
+func isMatchForLocation(subject Subject, acl *AccessControlRule) (match bool) {
+	// If there are no countries or ASNs in this rule then the location condition is a match.
+	if len(acl.Countries) == 0 && len(acl.ASNs) == 0 {
+		return true
+	}
+
+	// If the location of the subject couldn't be looked up only the rules which deny access are a match, so a failed
+	// lookup never grants access.
+	if subject.LocationLookupFailed {
+		return acl.Policy == Denied
+	}
+
+	// If there are no countries in this rule or the subject country is one of them then the country condition is a match.
+	if len(acl.Countries) != 0 && !utils.IsStringInSlice(subject.Country, acl.Countries) {
+		return false
+	}
+
+	// If there are no ASNs in this rule then the ASN condition is a match.
+	if len(acl.ASNs) == 0 {
+		return true
+	}
+
+	// Iterate over the ASNs until we find a match (return true) or until we exit the loop (return false).
+	for _, asn := range acl.ASNs {
+		if asn == subject.ASN {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isMatchForQuery(object Object, acl *AccessControlRule) (match bool) {
+	// If there are no query conditions in this rule then the query condition is a match.
+	if len(acl.Query) == 0 {
+		return true
+	}
+
+	// Iterate over the query conditions until we find a match (return true) or until we exit the loop (return false).
+	for _, query := range acl.Query {
+		if query.IsMatch(object) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isMatchForHeaders(object Object, acl *AccessControlRule) (match bool) {
+	// If there are no header conditions in this rule then the header condition is a match.
+	if len(acl.Headers) == 0 {
+		return true
+	}
+
+	// Iterate over the header conditions until we find a match (return true) or until we exit the loop (return false).
+	for _, headers := range acl.Headers {
+		if headers.IsMatch(object) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isMatchForSchedule(now time.Time, acl *AccessControlRule) (match bool) {
+	// If there is no schedule in this rule then the schedule condition is a match.
+	if len(acl.Schedule) == 0 {
+		return true
+	}
+
+	// Iterate over the schedule windows until we find a match (return true) or until we exit the loop (return false).
+	for _, schedule := range acl.Schedule {
+		if schedule.IsMatch(now) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // Same as isExactMatchForSubjects except it theoretically matches if subject is anonymous since they'd need to authenticate.
 func isMatchForSubjects(subject Subject, acl *AccessControlRule) (match bool) {
 	if subject.IsAnonymous() {
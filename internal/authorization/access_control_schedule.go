@@ -0,0 +1,129 @@
+package authorization
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+// NewAccessControlSchedule creates a new AccessControlSchedule given a schema.ACLSchedule.
+func NewAccessControlSchedule(config schema.ACLSchedule) (schedule AccessControlSchedule, err error) {
+	schedule.Location = time.Local
+
+	for _, weekday := range config.Weekdays {
+		var day time.Weekday
+
+		if day, err = ParseScheduleWeekday(weekday); err != nil {
+			return schedule, err
+		}
+
+		schedule.Weekdays = append(schedule.Weekdays, day)
+	}
+
+	if config.Start != "" {
+		if schedule.Start, err = ParseScheduleTimeOfDay(config.Start); err != nil {
+			return schedule, err
+		}
+	}
+
+	if config.End != "" {
+		if schedule.End, err = ParseScheduleTimeOfDay(config.End); err != nil {
+			return schedule, err
+		}
+	}
+
+	if config.TimeZone != "" {
+		if schedule.Location, err = time.LoadLocation(config.TimeZone); err != nil {
+			return schedule, err
+		}
+	}
+
+	return schedule, nil
+}
+
+// AccessControlSchedule represents an ACL schedule window.
+type AccessControlSchedule struct {
+	Weekdays []time.Weekday
+	Start    time.Duration
+	End      time.Duration
+	Location *time.Location
+
+	// never is true for a window which couldn't be parsed, which never matches.
+	never bool
+}
+
+// IsMatch returns true if the time is within the schedule window. When the start of the window is after the end of
+// the window the window spans midnight, and when they are equal the window spans the whole day. The weekdays are always
+// compared with the weekday of the time, so a window which spans midnight matches the part before midnight and the part
+// after midnight of each of the weekdays, not the morning after each of the weekdays.
+func (s AccessControlSchedule) IsMatch(now time.Time) (match bool) {
+	if s.never {
+		return false
+	}
+
+	now = now.In(s.Location)
+
+	if len(s.Weekdays) != 0 && !isWeekdayInSlice(now.Weekday(), s.Weekdays) {
+		return false
+	}
+
+	offset := time.Duration(now.Hour())*time.Hour + time.Duration(now.Minute())*time.Minute + time.Duration(now.Second())*time.Second
+
+	switch {
+	case s.Start == s.End:
+		return true
+	case s.Start < s.End:
+		return offset >= s.Start && offset < s.End
+	default:
+		return offset >= s.Start || offset < s.End
+	}
+}
+
+// ParseScheduleWeekday parses a full or abbreviated case-insensitive weekday name.
+func ParseScheduleWeekday(value string) (weekday time.Weekday, err error) {
+	lower := strings.ToLower(value)
+
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		name := strings.ToLower(day.String())
+
+		if lower == name || lower == name[:3] {
+			return day, nil
+		}
+	}
+
+	return weekday, fmt.Errorf("weekday '%s' is not a known weekday name", value)
+}
+
+// ParseScheduleTimeOfDay parses a time of day in the 24-hour HH:MM format into the duration since midnight. The value
+// 24:00 is accepted to represent the end of the day.
+func ParseScheduleTimeOfDay(value string) (offset time.Duration, err error) {
+	hours, minutes, found := strings.Cut(value, ":")
+	if !found || len(hours) != 2 || len(minutes) != 2 {
+		return 0, fmt.Errorf("time of day '%s' is not in the 'HH:MM' format", value)
+	}
+
+	var h, m int
+
+	if h, err = strconv.Atoi(hours); err != nil || h < 0 || h > 24 {
+		return 0, fmt.Errorf("time of day '%s' has an invalid hour", value)
+	}
+
+	if m, err = strconv.Atoi(minutes); err != nil || m < 0 || m > 59 || (h == 24 && m != 0) {
+		return 0, fmt.Errorf("time of day '%s' has an invalid minute", value)
+	}
+
+	return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute, nil
+}
+
+func isWeekdayInSlice(weekday time.Weekday, weekdays []time.Weekday) bool {
+	for _, day := range weekdays {
+		if day == weekday {
+			return true
+		}
+	}
+
+	return false
+}
@@ -48,3 +48,15 @@ type AccessControlGroup struct {
 func (acg AccessControlGroup) IsMatch(subject Subject) (match bool) {
 	return utils.IsStringInSlice(acg.Name, subject.Groups)
 }
+
+// AccessControlAttribute represents an ACL subject of type `attr:`.
+type AccessControlAttribute struct {
+	Name  string
+	Value string
+}
+
+// IsMatch returns true if one of the values of the Subject attribute with the AccessControlAttribute name matches the
+// AccessControlAttribute value.
+func (aca AccessControlAttribute) IsMatch(subject Subject) (match bool) {
+	return utils.IsStringInSlice(aca.Value, subject.Attributes[aca.Name])
+}
@@ -2,23 +2,43 @@ package authorization
 
 import (
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/geoip"
 	"github.com/authelia/authelia/v4/internal/logging"
+	"github.com/authelia/authelia/v4/internal/utils"
 )
 
 // Authorizer the component in charge of checking whether a user can access a given resource.
 type Authorizer struct {
 	defaultPolicy Level
 	rules         []*AccessControlRule
+	index         *AccessControlIndex
 	mfa           bool
 	configuration *schema.Configuration
+	clock         utils.Clock
+	geoip         geoip.Provider
+	external      *ExternalPolicy
 }
 
-// NewAuthorizer create an instance of authorizer with a given access control configuration.
-func NewAuthorizer(configuration *schema.Configuration) (authorizer *Authorizer) {
+// NewAuthorizer create an instance of authorizer with a given access control configuration. The geoip.Provider is
+// optional and is only used to lookup the location of the subject when a rule has location criteria.
+func NewAuthorizer(configuration *schema.Configuration, clock utils.Clock, provider geoip.Provider) (authorizer *Authorizer) {
 	authorizer = &Authorizer{
 		defaultPolicy: StringToLevel(configuration.AccessControl.DefaultPolicy),
 		rules:         NewAccessControlRules(configuration.AccessControl),
 		configuration: configuration,
+		clock:         clock,
+	}
+
+	authorizer.index = NewAccessControlIndex(authorizer.rules)
+
+	for _, rule := range authorizer.rules {
+		if len(rule.Countries) != 0 || len(rule.ASNs) != 0 {
+			authorizer.geoip = provider
+		}
+
+		if rule.Policy == External && authorizer.external == nil {
+			authorizer.external = NewExternalPolicy(configuration.AccessControl.External, clock)
+		}
 	}
 
 	if authorizer.defaultPolicy == TwoFactor {
@@ -28,7 +48,8 @@ func NewAuthorizer(configuration *schema.Configuration) (authorizer *Authorizer)
 	}
 
 	for _, rule := range authorizer.rules {
-		if rule.Policy == TwoFactor {
+		// The external policy decision point may decide two factor is required.
+		if rule.Policy == TwoFactor || rule.Policy == External {
 			authorizer.mfa = true
 
 			return authorizer
@@ -55,16 +76,37 @@ func (p Authorizer) IsSecondFactorEnabled() bool {
 
 // GetRequiredLevel retrieve the required level of authorization to access the object.
 func (p Authorizer) GetRequiredLevel(subject Subject, object Object) (bool, Level) {
+	hasSubjects, level, _ := p.GetRequiredAuthorization(subject, object)
+
+	return hasSubjects, level
+}
+
+// GetRequiredAuthorization retrieve the required level of authorization to access the object and the rule which
+// applies to the request, the rule is nil when the default policy applies.
+func (p Authorizer) GetRequiredAuthorization(subject Subject, object Object) (hasSubjects bool, level Level, rule *AccessControlRule) {
 	logger := logging.Logger()
 
 	logger.Debugf("Check authorization of subject %s and object %s (method %s).",
 		subject.String(), object.String(), object.Method)
 
-	for _, rule := range p.rules {
-		if rule.IsMatch(subject, object) {
+	now := p.clock.Now()
+
+	subject = p.locate(subject)
+
+	// Only the rules which may match the domain are evaluated, the index returns them in the configured order.
+	for _, i := range p.index.Candidates(object.Domain) {
+		rule = p.rules[i]
+
+		if rule.IsMatch(subject, object) && rule.IsActive(now) {
 			logger.Tracef(traceFmtACLHitMiss, "HIT", rule.Position, subject.String(), object.String(), object.Method)
 
-			return len(rule.Subjects) > 0, rule.Policy
+			// The external policy decision point may decide based on the subject, so an anonymous subject which is
+			// denied is treated like one which doesn't match the subjects of a rule and is asked to authenticate.
+			if rule.Policy == External {
+				return true, p.decide(subject, object, rule), rule
+			}
+
+			return len(rule.Subjects) > 0, rule.Policy, rule
 		}
 
 		logger.Tracef(traceFmtACLHitMiss, "MISS", rule.Position, subject.String(), object.String(), object.Method)
@@ -73,13 +115,17 @@ func (p Authorizer) GetRequiredLevel(subject Subject, object Object) (bool, Leve
 	logger.Debugf("No matching rule for subject %s and url %s... Applying default policy.",
 		subject.String(), object.String())
 
-	return false, p.defaultPolicy
+	return false, p.defaultPolicy, nil
 }
 
 // GetRuleMatchResults iterates through the rules and produces a list of RuleMatchResult provided a subject and object.
 func (p Authorizer) GetRuleMatchResults(subject Subject, object Object) (results []RuleMatchResult) {
 	skipped := false
 
+	now := p.clock.Now()
+
+	subject = p.locate(subject)
+
 	results = make([]RuleMatchResult, len(p.rules))
 
 	for i, rule := range p.rules {
@@ -91,6 +137,10 @@ func (p Authorizer) GetRuleMatchResults(subject Subject, object Object) (results
 			MatchResources:     isMatchForResources(subject, object, rule),
 			MatchMethods:       isMatchForMethods(object, rule),
 			MatchNetworks:      isMatchForNetworks(subject, rule),
+			MatchLocation:      isMatchForLocation(subject, rule),
+			MatchQuery:         isMatchForQuery(object, rule),
+			MatchHeaders:       isMatchForHeaders(object, rule),
+			MatchSchedule:      isMatchForSchedule(now, rule),
 			MatchSubjects:      isMatchForSubjects(subject, rule),
 			MatchSubjectsExact: isExactMatchForSubjects(subject, rule),
 		}
@@ -100,3 +150,38 @@ func (p Authorizer) GetRuleMatchResults(subject Subject, object Object) (results
 
 	return results
 }
+
+// locate returns the subject with the country and ASN of the subject IP when the location is required by any of the
+// rules and the location isn't already known.
+func (p Authorizer) locate(subject Subject) Subject {
+	if p.geoip == nil || subject.IP == nil || subject.Country != "" || subject.ASN != 0 {
+		return subject
+	}
+
+	location, err := p.geoip.Lookup(subject.IP)
+	if err != nil {
+		logging.Logger().WithError(err).Errorf("Failed to lookup the location of IP %s", subject.IP)
+
+		subject.LocationLookupFailed = true
+
+		return subject
+	}
+
+	subject.Country, subject.ASN = location.Country, location.ASN
+
+	return subject
+}
+
+// decide returns the level decided by the external policy decision point for a rule with the external policy.
+func (p Authorizer) decide(subject Subject, object Object, rule *AccessControlRule) Level {
+	if p.external == nil {
+		return Denied
+	}
+
+	level := p.external.Decide(subject, object, rule)
+
+	logging.Logger().Debugf("External policy decision for subject %s and object %s (method %s) from rule %d is '%s'",
+		subject.String(), object.String(), object.Method, rule.Position, LevelToString(level))
+
+	return level
+}
@@ -1,16 +1,21 @@
 package authorization
 
 import (
+	"errors"
 	"net"
+	"net/http"
 	"net/url"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/geoip"
+	"github.com/authelia/authelia/v4/internal/utils"
 )
 
 type AuthorizerSuite struct {
@@ -21,13 +26,13 @@ type AuthorizerTester struct {
 	*Authorizer
 }
 
-func NewAuthorizerTester(config schema.AccessControlConfiguration) *AuthorizerTester {
+func NewAuthorizerTester(config schema.AccessControlConfiguration, clock utils.Clock) *AuthorizerTester {
 	fullConfig := &schema.Configuration{
 		AccessControl: config,
 	}
 
 	return &AuthorizerTester{
-		NewAuthorizer(fullConfig),
+		NewAuthorizer(fullConfig, clock, nil),
 	}
 }
 
@@ -41,6 +46,16 @@ func (s *AuthorizerTester) CheckAuthorizations(t *testing.T, subject Subject, re
 	assert.Equal(t, expectedLevel, level)
 }
 
+func (s *AuthorizerTester) CheckAuthorizationsWithHeader(t *testing.T, subject Subject, requestURI, method string, header http.Header, expectedLevel Level) {
+	targetURL, _ := url.ParseRequestURI(requestURI)
+
+	object := NewObjectWithHeader(targetURL, method, header)
+
+	_, level := s.GetRequiredLevel(subject, object)
+
+	assert.Equal(t, expectedLevel, level)
+}
+
 func (s *AuthorizerTester) GetRuleMatchResults(subject Subject, requestURI, method string) (results []RuleMatchResult) {
 	targetURL, _ := url.ParseRequestURI(requestURI)
 
@@ -51,10 +66,11 @@ func (s *AuthorizerTester) GetRuleMatchResults(subject Subject, requestURI, meth
 
 type AuthorizerTesterBuilder struct {
 	config schema.AccessControlConfiguration
+	clock  utils.Clock
 }
 
 func NewAuthorizerBuilder() *AuthorizerTesterBuilder {
-	return &AuthorizerTesterBuilder{}
+	return &AuthorizerTesterBuilder{clock: utils.RealClock{}}
 }
 
 func (b *AuthorizerTesterBuilder) WithDefaultPolicy(policy string) *AuthorizerTesterBuilder {
@@ -67,8 +83,18 @@ func (b *AuthorizerTesterBuilder) WithRule(rule schema.ACLRule) *AuthorizerTeste
 	return b
 }
 
+func (b *AuthorizerTesterBuilder) WithExternal(external schema.ACLExternal) *AuthorizerTesterBuilder {
+	b.config.External = external
+	return b
+}
+
+func (b *AuthorizerTesterBuilder) WithClock(clock utils.Clock) *AuthorizerTesterBuilder {
+	b.clock = clock
+	return b
+}
+
 func (b *AuthorizerTesterBuilder) Build() *AuthorizerTester {
-	return NewAuthorizerTester(b.config)
+	return NewAuthorizerTester(b.config, b.clock)
 }
 
 var AnonymousUser = Subject{
@@ -530,6 +556,27 @@ func (s *AuthorizerSuite) TestShouldCheckGroupMatching() {
 	tester.CheckAuthorizations(s.T(), Bob, "https://protected.example.com/", "GET", Denied)
 }
 
+func (s *AuthorizerSuite) TestShouldCheckAttributeMatching() {
+	tester := NewAuthorizerBuilder().
+		WithDefaultPolicy(deny).
+		WithRule(schema.ACLRule{
+			Domains:  []string{"protected.example.com"},
+			Policy:   oneFactor,
+			Subjects: [][]string{{"attr:Department=engineering"}},
+		}).
+		Build()
+
+	engineer := Subject{Username: "alice", Attributes: map[string][]string{"department": {"sales", "engineering"}}}
+	salesperson := Subject{Username: "carol", Attributes: map[string][]string{"department": {"sales"}}}
+	contractor := Subject{Username: "dave", Attributes: map[string][]string{"employeetype": {"engineering"}}}
+
+	tester.CheckAuthorizations(s.T(), engineer, "https://protected.example.com/", "GET", OneFactor)
+	tester.CheckAuthorizations(s.T(), salesperson, "https://protected.example.com/", "GET", Denied)
+	tester.CheckAuthorizations(s.T(), contractor, "https://protected.example.com/", "GET", Denied)
+	tester.CheckAuthorizations(s.T(), John, "https://protected.example.com/", "GET", Denied)
+	tester.CheckAuthorizations(s.T(), AnonymousUser, "https://protected.example.com/", "GET", OneFactor)
+}
+
 func (s *AuthorizerSuite) TestShouldCheckSubjectsMatching() {
 	tester := NewAuthorizerBuilder().
 		WithDefaultPolicy(deny).
@@ -605,6 +652,42 @@ func (s *AuthorizerSuite) TestShouldCheckIPMatching() {
 	tester.CheckAuthorizations(s.T(), Sam, "https://ipv6.example.com/", "GET", TwoFactor)
 }
 
+func (s *AuthorizerSuite) TestShouldCheckLocationMatching() {
+	tester := NewAuthorizerBuilder().
+		WithDefaultPolicy(deny).
+		WithRule(schema.ACLRule{
+			Domains:   []string{"protected.example.com"},
+			Policy:    oneFactor,
+			Countries: []string{"de", "FR"},
+		}).
+		WithRule(schema.ACLRule{
+			Domains: []string{"protected.example.com"},
+			Policy:  twoFactor,
+			ASNs:    []uint{3320, 15169},
+		}).
+		WithRule(schema.ACLRule{
+			Domains:   []string{"both.example.com"},
+			Policy:    bypass,
+			Countries: []string{"US"},
+			ASNs:      []uint{15169},
+		}).
+		Build()
+
+	german := Subject{Username: "john", IP: net.ParseIP("192.168.1.8"), Country: "DE", ASN: 3320}
+	french := Subject{Username: "bob", IP: net.ParseIP("192.168.1.9"), Country: "FR"}
+	american := Subject{Username: "sam", IP: net.ParseIP("192.168.1.10"), Country: "US", ASN: 15169}
+	unknown := Subject{Username: "sally", IP: net.ParseIP("192.168.1.11")}
+
+	tester.CheckAuthorizations(s.T(), german, "https://protected.example.com/", "GET", OneFactor)
+	tester.CheckAuthorizations(s.T(), french, "https://protected.example.com/", "GET", OneFactor)
+	tester.CheckAuthorizations(s.T(), american, "https://protected.example.com/", "GET", TwoFactor)
+	tester.CheckAuthorizations(s.T(), unknown, "https://protected.example.com/", "GET", Denied)
+
+	tester.CheckAuthorizations(s.T(), american, "https://both.example.com/", "GET", Bypass)
+	tester.CheckAuthorizations(s.T(), Subject{Username: "sam", Country: "US", ASN: 3320}, "https://both.example.com/", "GET", Denied)
+	tester.CheckAuthorizations(s.T(), Subject{Username: "sam", Country: "DE", ASN: 15169}, "https://both.example.com/", "GET", Denied)
+}
+
 func (s *AuthorizerSuite) TestShouldCheckMethodMatching() {
 	tester := NewAuthorizerBuilder().
 		WithDefaultPolicy(deny).
@@ -706,6 +789,182 @@ func (s *AuthorizerSuite) TestShouldCheckResourceMatching() {
 	tester.CheckAuthorizations(s.T(), John, "https://resource.example.com/bypass/%2E%2E%2Fan/exact/path/", "GET", TwoFactor)
 }
 
+func (s *AuthorizerSuite) TestShouldCheckQueryMatching() {
+	tester := NewAuthorizerBuilder().
+		WithDefaultPolicy(deny).
+		WithRule(schema.ACLRule{
+			Domains: []string{"query.example.com"},
+			Policy:  bypass,
+			Query: [][]schema.ACLQueryRule{
+				{
+					{Key: "token", Value: regexp.MustCompile(`^[a-f0-9]{4}$`)},
+					{Key: "public"},
+				},
+				{
+					{Key: "anonymous"},
+				},
+			},
+		}).
+		WithRule(schema.ACLRule{
+			Domains: []string{"query.example.com"},
+			Policy:  oneFactor,
+			Query: [][]schema.ACLQueryRule{
+				{
+					{Key: "token"},
+				},
+			},
+		}).
+		Build()
+
+	tester.CheckAuthorizations(s.T(), AnonymousUser, "https://query.example.com/?token=abcd&public", "GET", Bypass)
+	tester.CheckAuthorizations(s.T(), AnonymousUser, "https://query.example.com/?public=1&token=zzzz&token=abcd", "GET", Bypass)
+	tester.CheckAuthorizations(s.T(), AnonymousUser, "https://query.example.com/?anonymous", "GET", Bypass)
+	tester.CheckAuthorizations(s.T(), AnonymousUser, "https://query.example.com/?token=abcd", "GET", OneFactor)
+	tester.CheckAuthorizations(s.T(), AnonymousUser, "https://query.example.com/?token=zzzz&public", "GET", OneFactor)
+	tester.CheckAuthorizations(s.T(), AnonymousUser, "https://query.example.com/?public", "GET", Denied)
+	tester.CheckAuthorizations(s.T(), AnonymousUser, "https://query.example.com/", "GET", Denied)
+}
+
+func (s *AuthorizerSuite) TestShouldCheckHeadersMatching() {
+	tester := NewAuthorizerBuilder().
+		WithDefaultPolicy(deny).
+		WithRule(schema.ACLRule{
+			Domains: []string{"headers.example.com"},
+			Policy:  bypass,
+			Headers: [][]schema.ACLHeaderRule{
+				{
+					{Name: "x-api-key", Value: regexp.MustCompile(`^secret$`)},
+				},
+			},
+		}).
+		WithRule(schema.ACLRule{
+			Domains: []string{"headers.example.com"},
+			Policy:  twoFactor,
+			Headers: [][]schema.ACLHeaderRule{
+				{
+					{Name: "X-Api-Key"},
+				},
+			},
+		}).
+		WithRule(schema.ACLRule{
+			Domains: []string{"headers.example.com"},
+			Policy:  oneFactor,
+		}).
+		Build()
+
+	tester.CheckAuthorizationsWithHeader(s.T(), AnonymousUser, "https://headers.example.com/", "GET", http.Header{"X-Api-Key": []string{"secret"}}, Bypass)
+	tester.CheckAuthorizationsWithHeader(s.T(), AnonymousUser, "https://headers.example.com/", "GET", http.Header{"X-Api-Key": []string{"other", "secret"}}, Bypass)
+	tester.CheckAuthorizationsWithHeader(s.T(), AnonymousUser, "https://headers.example.com/", "GET", http.Header{"X-Api-Key": []string{"other"}}, TwoFactor)
+	tester.CheckAuthorizationsWithHeader(s.T(), AnonymousUser, "https://headers.example.com/", "GET", http.Header{"X-Other": []string{"secret"}}, OneFactor)
+	tester.CheckAuthorizationsWithHeader(s.T(), AnonymousUser, "https://headers.example.com/", "GET", nil, OneFactor)
+
+	results := tester.Authorizer.GetRuleMatchResults(AnonymousUser, NewObjectWithHeader(&url.URL{Scheme: "https", Host: "headers.example.com", Path: "/"}, "GET", http.Header{"X-Api-Key": []string{"other"}}))
+
+	s.Require().Len(results, 3)
+	s.Assert().False(results[0].MatchHeaders)
+	s.Assert().False(results[0].IsMatch())
+	s.Assert().True(results[1].MatchHeaders)
+	s.Assert().True(results[1].IsMatch())
+	s.Assert().True(results[2].Skipped)
+}
+
+func (s *AuthorizerSuite) TestShouldCheckScheduleMatching() {
+	clock := &utils.TestingClock{}
+
+	tester := NewAuthorizerBuilder().
+		WithDefaultPolicy(deny).
+		WithClock(clock).
+		WithRule(schema.ACLRule{
+			Domains: []string{"schedule.example.com"},
+			Policy:  oneFactor,
+			Schedule: []schema.ACLSchedule{
+				{
+					Weekdays: []string{"monday", "tue", "Wednesday", "thu", "fri"},
+					Start:    "08:00",
+					End:      "18:00",
+					TimeZone: "Europe/Paris",
+				},
+			},
+		}).
+		WithRule(schema.ACLRule{
+			Domains: []string{"schedule.example.com"},
+			Policy:  twoFactor,
+			Schedule: []schema.ACLSchedule{
+				{
+					Start:    "22:00",
+					End:      "06:00",
+					TimeZone: "UTC",
+				},
+			},
+		}).
+		Build()
+
+	// Monday 07:30 UTC is 09:30 in Paris.
+	clock.Set(time.Date(2022, time.October, 10, 7, 30, 0, 0, time.UTC))
+	tester.CheckAuthorizations(s.T(), John, "https://schedule.example.com/", "GET", OneFactor)
+
+	// Monday 16:30 UTC is 18:30 in Paris.
+	clock.Set(time.Date(2022, time.October, 10, 16, 30, 0, 0, time.UTC))
+	tester.CheckAuthorizations(s.T(), John, "https://schedule.example.com/", "GET", Denied)
+
+	// Saturday 10:00 UTC is 12:00 in Paris.
+	clock.Set(time.Date(2022, time.October, 15, 10, 0, 0, 0, time.UTC))
+	tester.CheckAuthorizations(s.T(), John, "https://schedule.example.com/", "GET", Denied)
+
+	clock.Set(time.Date(2022, time.October, 15, 23, 0, 0, 0, time.UTC))
+	tester.CheckAuthorizations(s.T(), John, "https://schedule.example.com/", "GET", TwoFactor)
+
+	clock.Set(time.Date(2022, time.October, 16, 5, 59, 0, 0, time.UTC))
+	tester.CheckAuthorizations(s.T(), John, "https://schedule.example.com/", "GET", TwoFactor)
+
+	clock.Set(time.Date(2022, time.October, 16, 6, 0, 0, 0, time.UTC))
+	tester.CheckAuthorizations(s.T(), John, "https://schedule.example.com/", "GET", Denied)
+
+	results := tester.GetRuleMatchResults(John, "https://schedule.example.com/", "GET")
+
+	s.Require().Len(results, 2)
+	s.Assert().False(results[0].MatchSchedule)
+	s.Assert().False(results[1].MatchSchedule)
+	s.Assert().True(results[0].MatchDomain)
+}
+
+func (s *AuthorizerSuite) TestShouldNeverMatchInvalidScheduleWindows() {
+	tester := NewAuthorizerBuilder().
+		WithDefaultPolicy(deny).
+		WithRule(schema.ACLRule{
+			Domains: []string{"schedule.example.com"},
+			Policy:  bypass,
+			Schedule: []schema.ACLSchedule{
+				{
+					Start: "25:00",
+					End:   "06:00",
+				},
+			},
+		}).
+		WithRule(schema.ACLRule{
+			Domains: []string{"schedule.example.com"},
+			Policy:  oneFactor,
+			Schedule: []schema.ACLSchedule{
+				{
+					Weekdays: []string{"someday"},
+				},
+				{
+					Start: "00:00",
+					End:   "00:00",
+				},
+			},
+		}).
+		Build()
+
+	tester.CheckAuthorizations(s.T(), John, "https://schedule.example.com/", "GET", OneFactor)
+
+	results := tester.GetRuleMatchResults(John, "https://schedule.example.com/", "GET")
+
+	s.Require().Len(results, 2)
+	s.Assert().False(results[0].MatchSchedule)
+	s.Assert().True(results[1].MatchSchedule)
+}
+
 // This test assures that rules without domains (not allowed by schema validator at this time) will pass validation correctly.
 func (s *AuthorizerSuite) TestShouldMatchAnyDomainIfBlank() {
 	tester := NewAuthorizerBuilder().
@@ -869,6 +1128,7 @@ func (s *AuthorizerSuite) TestPolicyToLevel() {
 	s.Assert().Equal(OneFactor, StringToLevel(oneFactor))
 	s.Assert().Equal(TwoFactor, StringToLevel(twoFactor))
 	s.Assert().Equal(Denied, StringToLevel(deny))
+	s.Assert().Equal(External, StringToLevel(external))
 
 	s.Assert().Equal(Denied, StringToLevel("whatever"))
 }
@@ -899,7 +1159,7 @@ func TestNewAuthorizer(t *testing.T) {
 		},
 	}
 
-	authorizer := NewAuthorizer(config)
+	authorizer := NewAuthorizer(config, utils.RealClock{}, nil)
 
 	assert.Equal(t, Denied, authorizer.defaultPolicy)
 	assert.Equal(t, TwoFactor, authorizer.rules[0].Policy)
@@ -913,6 +1173,145 @@ func TestNewAuthorizer(t *testing.T) {
 	assert.Equal(t, "admins", group.Name)
 }
 
+func TestAuthorizerGetRequiredAuthorizationAuthenticationMethods(t *testing.T) {
+	config := &schema.Configuration{
+		AccessControl: schema.AccessControlConfiguration{
+			DefaultPolicy: twoFactor,
+			Rules: []schema.ACLRule{
+				{
+					Domains:               []string{"secure.example.com"},
+					Policy:                twoFactor,
+					AuthenticationMethods: []string{"Webauthn_User_Verified", "duo"},
+				},
+			},
+		},
+	}
+
+	authorizer := NewAuthorizer(config, utils.RealClock{}, nil)
+
+	_, level, rule := authorizer.GetRequiredAuthorization(Subject{}, NewObject(&url.URL{Scheme: "https", Host: "secure.example.com", Path: "/"}, "GET"))
+
+	assert.Equal(t, TwoFactor, level)
+	require.NotNil(t, rule)
+	assert.Equal(t, []string{AuthenticationMethodWebauthnUserVerified, AuthenticationMethodDuo}, rule.AuthenticationMethods)
+
+	assert.False(t, rule.IsAuthenticationMethodsSufficient(nil))
+	assert.False(t, rule.IsAuthenticationMethodsSufficient([]string{AuthenticationMethodWebauthn, AuthenticationMethodTOTP}))
+	assert.True(t, rule.IsAuthenticationMethodsSufficient([]string{AuthenticationMethodWebauthn, AuthenticationMethodWebauthnUserVerified}))
+	assert.True(t, rule.IsAuthenticationMethodsSufficient([]string{AuthenticationMethodDuo}))
+
+	_, level, rule = authorizer.GetRequiredAuthorization(Subject{}, NewObject(&url.URL{Scheme: "https", Host: "other.example.com", Path: "/"}, "GET"))
+
+	assert.Equal(t, TwoFactor, level)
+	assert.Nil(t, rule)
+}
+
+type staticGeoIPProvider map[string]geoip.Location
+
+func (p staticGeoIPProvider) StartupCheck() (err error) {
+	return nil
+}
+
+func (p staticGeoIPProvider) Lookup(ip net.IP) (location geoip.Location, err error) {
+	if ip.String() == "192.0.2.1" {
+		return location, errors.New("lookup failed")
+	}
+
+	return p[ip.String()], nil
+}
+
+func TestAuthorizerShouldLookupSubjectLocation(t *testing.T) {
+	config := &schema.Configuration{
+		AccessControl: schema.AccessControlConfiguration{
+			DefaultPolicy: deny,
+			Rules: []schema.ACLRule{
+				{
+					Domains:   []string{"secure.example.com"},
+					Policy:    oneFactor,
+					Countries: []string{"DE"},
+				},
+			},
+		},
+	}
+
+	provider := staticGeoIPProvider{
+		"203.0.113.10": {Country: "DE", ASN: 3320},
+		"203.0.113.20": {Country: "US", ASN: 15169},
+	}
+
+	authorizer := NewAuthorizer(config, utils.RealClock{}, provider)
+
+	object := NewObject(&url.URL{Scheme: "https", Host: "secure.example.com", Path: "/"}, "GET")
+
+	_, level := authorizer.GetRequiredLevel(Subject{IP: net.ParseIP("203.0.113.10")}, object)
+	assert.Equal(t, OneFactor, level)
+
+	_, level = authorizer.GetRequiredLevel(Subject{IP: net.ParseIP("203.0.113.20")}, object)
+	assert.Equal(t, Denied, level)
+
+	_, level = authorizer.GetRequiredLevel(Subject{IP: net.ParseIP("192.0.2.1")}, object)
+	assert.Equal(t, Denied, level)
+
+	// A known location is not looked up again.
+	_, level = authorizer.GetRequiredLevel(Subject{IP: net.ParseIP("203.0.113.20"), Country: "DE"}, object)
+	assert.Equal(t, OneFactor, level)
+
+	results := authorizer.GetRuleMatchResults(Subject{IP: net.ParseIP("203.0.113.10")}, object)
+	require.Len(t, results, 1)
+	assert.True(t, results[0].MatchLocation)
+	assert.True(t, results[0].IsMatch())
+
+	// The provider is not used when no rules have location criteria.
+	config.AccessControl.Rules[0].Countries = nil
+
+	authorizer = NewAuthorizer(config, utils.RealClock{}, provider)
+	assert.Nil(t, authorizer.geoip)
+}
+
+func TestAuthorizerShouldDenyWhenSubjectLocationLookupFails(t *testing.T) {
+	config := &schema.Configuration{
+		AccessControl: schema.AccessControlConfiguration{
+			DefaultPolicy: deny,
+			Rules: []schema.ACLRule{
+				{
+					Domains:   []string{"secure.example.com"},
+					Policy:    deny,
+					Countries: []string{"RU"},
+				},
+				{
+					Domains: []string{"secure.example.com"},
+					Policy:  oneFactor,
+					ASNs:    []uint{15169},
+				},
+				{
+					Domains: []string{"secure.example.com"},
+					Policy:  bypass,
+				},
+			},
+		},
+	}
+
+	provider := staticGeoIPProvider{
+		"203.0.113.20": {Country: "US", ASN: 15169},
+	}
+
+	authorizer := NewAuthorizer(config, utils.RealClock{}, provider)
+
+	object := NewObject(&url.URL{Scheme: "https", Host: "secure.example.com", Path: "/"}, "GET")
+
+	_, level := authorizer.GetRequiredLevel(Subject{IP: net.ParseIP("203.0.113.20")}, object)
+	assert.Equal(t, OneFactor, level)
+
+	_, level = authorizer.GetRequiredLevel(Subject{IP: net.ParseIP("192.0.2.1")}, object)
+	assert.Equal(t, Denied, level)
+
+	results := authorizer.GetRuleMatchResults(Subject{IP: net.ParseIP("192.0.2.1")}, object)
+	require.Len(t, results, 3)
+	assert.True(t, results[0].MatchLocation)
+	assert.False(t, results[1].MatchLocation)
+	assert.True(t, results[2].MatchLocation)
+}
+
 func TestAuthorizerIsSecondFactorEnabledRuleWithNoOIDC(t *testing.T) {
 	config := &schema.Configuration{
 		AccessControl: schema.AccessControlConfiguration{
@@ -926,11 +1325,11 @@ func TestAuthorizerIsSecondFactorEnabledRuleWithNoOIDC(t *testing.T) {
 		},
 	}
 
-	authorizer := NewAuthorizer(config)
+	authorizer := NewAuthorizer(config, utils.RealClock{}, nil)
 	assert.False(t, authorizer.IsSecondFactorEnabled())
 
 	config.AccessControl.Rules[0].Policy = twoFactor
-	authorizer = NewAuthorizer(config)
+	authorizer = NewAuthorizer(config, utils.RealClock{}, nil)
 	assert.True(t, authorizer.IsSecondFactorEnabled())
 }
 
@@ -956,27 +1355,27 @@ func TestAuthorizerIsSecondFactorEnabledRuleWithOIDC(t *testing.T) {
 		},
 	}
 
-	authorizer := NewAuthorizer(config)
+	authorizer := NewAuthorizer(config, utils.RealClock{}, nil)
 	assert.False(t, authorizer.IsSecondFactorEnabled())
 
 	config.AccessControl.Rules[0].Policy = twoFactor
-	authorizer = NewAuthorizer(config)
+	authorizer = NewAuthorizer(config, utils.RealClock{}, nil)
 	assert.True(t, authorizer.IsSecondFactorEnabled())
 
 	config.AccessControl.Rules[0].Policy = oneFactor
-	authorizer = NewAuthorizer(config)
+	authorizer = NewAuthorizer(config, utils.RealClock{}, nil)
 	assert.False(t, authorizer.IsSecondFactorEnabled())
 
 	config.IdentityProviders.OIDC.Clients[0].Policy = twoFactor
-	authorizer = NewAuthorizer(config)
+	authorizer = NewAuthorizer(config, utils.RealClock{}, nil)
 	assert.True(t, authorizer.IsSecondFactorEnabled())
 
 	config.AccessControl.Rules[0].Policy = oneFactor
 	config.IdentityProviders.OIDC.Clients[0].Policy = oneFactor
-	authorizer = NewAuthorizer(config)
+	authorizer = NewAuthorizer(config, utils.RealClock{}, nil)
 	assert.False(t, authorizer.IsSecondFactorEnabled())
 
 	config.AccessControl.DefaultPolicy = twoFactor
-	authorizer = NewAuthorizer(config)
+	authorizer = NewAuthorizer(config, utils.RealClock{}, nil)
 	assert.True(t, authorizer.IsSecondFactorEnabled())
 }
@@ -12,11 +12,14 @@ const (
 	TwoFactor
 	// Denied denied level.
 	Denied
+	// External external level, the level is decided by the external policy decision point when the rule is matched.
+	External
 )
 
 const (
-	prefixUser  = "user:"
-	prefixGroup = "group:"
+	prefixUser      = "user:"
+	prefixGroup     = "group:"
+	prefixAttribute = "attr:"
 )
 
 const (
@@ -24,6 +27,23 @@ const (
 	oneFactor = "one_factor"
 	twoFactor = "two_factor"
 	deny      = "deny"
+	external  = "external"
+)
+
+// Authentication methods which can be required by a rule.
+const (
+	// AuthenticationMethodWebauthn is the Webauthn authentication method.
+	AuthenticationMethodWebauthn = "webauthn"
+
+	// AuthenticationMethodWebauthnUserVerified is the Webauthn authentication method where the authenticator verified
+	// the user for example with a PIN or biometrics.
+	AuthenticationMethodWebauthnUserVerified = "webauthn_user_verified"
+
+	// AuthenticationMethodTOTP is the TOTP authentication method.
+	AuthenticationMethodTOTP = "totp"
+
+	// AuthenticationMethodDuo is the Duo push notification authentication method.
+	AuthenticationMethodDuo = "duo"
 )
 
 const (
@@ -34,6 +54,14 @@ const (
 var (
 	// IdentitySubexpNames is a list of valid regex subexp names.
 	IdentitySubexpNames = []string{subexpNameUser, subexpNameGroup}
+
+	// AuthenticationMethods is a list of valid authentication methods which can be required by a rule.
+	AuthenticationMethods = []string{AuthenticationMethodWebauthn, AuthenticationMethodWebauthnUserVerified, AuthenticationMethodTOTP, AuthenticationMethodDuo}
 )
 
 const traceFmtACLHitMiss = "ACL %s Position %d for subject %s and object %s (Method %s)"
+
+const (
+	headerContentType = "Content-Type"
+	contentTypeJSON   = "application/json"
+)
@@ -0,0 +1,208 @@
+package authorization
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/logging"
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+// NewExternalPolicy creates a new ExternalPolicy given the configuration of the external policy decision point.
+func NewExternalPolicy(config schema.ACLExternal, clock utils.Clock) (policy *ExternalPolicy) {
+	policy = &ExternalPolicy{
+		client:        &http.Client{Timeout: config.Timeout},
+		failure:       StringToLevel(config.FailurePolicy),
+		cacheDuration: config.CacheDuration,
+		clock:         clock,
+		cache:         map[string]externalPolicyDecision{},
+	}
+
+	if config.URL != nil {
+		policy.url = config.URL.String()
+	}
+
+	return policy
+}
+
+// ExternalPolicy is the policy decision point which decides the level of the rules with the external policy. It sends
+// the subject and object to an external HTTP endpoint which responds with the policy to apply, and caches the decisions
+// for a short duration.
+type ExternalPolicy struct {
+	url           string
+	client        *http.Client
+	failure       Level
+	cacheDuration time.Duration
+	clock         utils.Clock
+
+	mutex     sync.Mutex
+	cache     map[string]externalPolicyDecision
+	nextPurge time.Time
+}
+
+// ExternalPolicyRequest is the body of the request sent to the external policy decision point.
+type ExternalPolicyRequest struct {
+	Subject ExternalPolicySubject `json:"subject"`
+	Object  ExternalPolicyObject  `json:"object"`
+	Rule    int                   `json:"rule"`
+}
+
+// ExternalPolicySubject is the Subject as sent to the external policy decision point.
+type ExternalPolicySubject struct {
+	Username   string              `json:"username"`
+	Groups     []string            `json:"groups"`
+	Attributes map[string][]string `json:"attributes,omitempty"`
+	IP         string              `json:"ip"`
+	Country    string              `json:"country,omitempty"`
+	ASN        uint                `json:"asn,omitempty"`
+}
+
+// ExternalPolicyObject is the Object as sent to the external policy decision point.
+type ExternalPolicyObject struct {
+	URL    string `json:"url"`
+	Domain string `json:"domain"`
+	Path   string `json:"path"`
+	Method string `json:"method"`
+}
+
+// ExternalPolicyResponse is the body of the response expected from the external policy decision point.
+type ExternalPolicyResponse struct {
+	Policy string `json:"policy"`
+}
+
+type externalPolicyDecision struct {
+	level   Level
+	expires time.Time
+}
+
+// Decide returns the level the external policy decision point decided for the subject and object matched by the rule.
+// If the decision can't be obtained the failure policy is returned.
+func (e *ExternalPolicy) Decide(subject Subject, object Object, rule *AccessControlRule) (level Level) {
+	body, err := json.Marshal(newExternalPolicyRequest(subject, object, rule))
+	if err != nil {
+		logging.Logger().WithError(err).Errorf("Failed to encode the external policy request for subject %s and object %s, applying the failure policy '%s'", subject, object, LevelToString(e.failure))
+
+		return e.failure
+	}
+
+	key := string(body)
+
+	if level, ok := e.load(key); ok {
+		return level
+	}
+
+	if level, err = e.request(body); err != nil {
+		logging.Logger().WithError(err).Errorf("Failed to obtain the external policy decision for subject %s and object %s, applying the failure policy '%s'", subject, object, LevelToString(e.failure))
+
+		return e.failure
+	}
+
+	e.store(key, level)
+
+	return level
+}
+
+func (e *ExternalPolicy) request(body []byte) (level Level, err error) {
+	req, err := http.NewRequest(http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return Denied, err
+	}
+
+	req.Header.Set(headerContentType, contentTypeJSON)
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return Denied, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return Denied, fmt.Errorf("the external policy decision point responded with status code %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return Denied, err
+	}
+
+	decision := ExternalPolicyResponse{}
+
+	if err = json.Unmarshal(data, &decision); err != nil {
+		return Denied, fmt.Errorf("the external policy decision point response could not be decoded: %w", err)
+	}
+
+	switch decision.Policy {
+	case bypass, oneFactor, twoFactor, deny:
+		return StringToLevel(decision.Policy), nil
+	default:
+		return Denied, fmt.Errorf("the external policy decision point responded with the invalid policy '%s'", decision.Policy)
+	}
+}
+
+func (e *ExternalPolicy) load(key string) (level Level, ok bool) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	decision, ok := e.cache[key]
+	if !ok || e.clock.Now().After(decision.expires) {
+		return Denied, false
+	}
+
+	return decision.level, true
+}
+
+func (e *ExternalPolicy) store(key string, level Level) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	now := e.clock.Now()
+
+	// Expired decisions are purged at most once per cache duration so the cache doesn't grow indefinitely.
+	if now.After(e.nextPurge) {
+		for k, decision := range e.cache {
+			if now.After(decision.expires) {
+				delete(e.cache, k)
+			}
+		}
+
+		e.nextPurge = now.Add(e.cacheDuration)
+	}
+
+	e.cache[key] = externalPolicyDecision{level: level, expires: now.Add(e.cacheDuration)}
+}
+
+func newExternalPolicyRequest(subject Subject, object Object, rule *AccessControlRule) ExternalPolicyRequest {
+	request := ExternalPolicyRequest{
+		Subject: ExternalPolicySubject{
+			Username:   subject.Username,
+			Groups:     subject.Groups,
+			Attributes: subject.Attributes,
+			Country:    subject.Country,
+			ASN:        subject.ASN,
+		},
+		Object: ExternalPolicyObject{
+			URL:    object.URL.String(),
+			Domain: object.Domain,
+			Path:   object.Path,
+			Method: object.Method,
+		},
+		Rule: rule.Position,
+	}
+
+	if subject.IP != nil {
+		request.Subject.IP = subject.IP.String()
+	}
+
+	if request.Subject.Groups == nil {
+		request.Subject.Groups = []string{}
+	}
+
+	return request
+}
@@ -0,0 +1,220 @@
+package authorization
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+func TestExternalPolicyShouldDecideLevel(t *testing.T) {
+	var requests int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, contentTypeJSON, r.Header.Get(headerContentType))
+
+		request := ExternalPolicyRequest{}
+
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+
+		assert.Equal(t, "external.example.com", request.Object.Domain)
+		assert.Equal(t, "https://external.example.com/admin", request.Object.URL)
+		assert.Equal(t, "/admin", request.Object.Path)
+		assert.Equal(t, "GET", request.Object.Method)
+		assert.Equal(t, 1, request.Rule)
+
+		policy := oneFactor
+
+		switch {
+		case request.Subject.Username == "":
+			policy = deny
+		case utils.IsStringInSlice("admins", request.Subject.Groups):
+			assert.Equal(t, "192.168.1.10", request.Subject.IP)
+			assert.Equal(t, map[string][]string{"department": {"engineering"}}, request.Subject.Attributes)
+
+			policy = twoFactor
+		}
+
+		w.Header().Set(headerContentType, contentTypeJSON)
+
+		_, _ = w.Write([]byte(`{"policy":"` + policy + `"}`))
+	}))
+
+	defer server.Close()
+
+	clock := &utils.TestingClock{}
+	clock.Set(time.Now())
+
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	authorizer := NewAuthorizerBuilder().
+		WithDefaultPolicy(deny).
+		WithExternal(schema.ACLExternal{URL: u, Timeout: time.Second, FailurePolicy: deny, CacheDuration: time.Minute}).
+		WithRule(schema.ACLRule{Domains: []string{"external.example.com"}, Policy: external}).
+		WithClock(clock).
+		Build()
+
+	assert.True(t, authorizer.IsSecondFactorEnabled())
+
+	object := NewObject(&url.URL{Scheme: "https", Host: "external.example.com", Path: "/admin"}, "GET")
+
+	admin := Subject{
+		Username:   "john",
+		Groups:     []string{"admins"},
+		Attributes: map[string][]string{"department": {"engineering"}},
+		IP:         net.ParseIP("192.168.1.10"),
+	}
+
+	_, level, rule := authorizer.GetRequiredAuthorization(admin, object)
+	assert.Equal(t, TwoFactor, level)
+	require.NotNil(t, rule)
+	assert.Equal(t, External, rule.Policy)
+
+	_, level = authorizer.GetRequiredLevel(Subject{Username: "bob"}, object)
+	assert.Equal(t, OneFactor, level)
+
+	hasSubjects, level := authorizer.GetRequiredLevel(Subject{}, object)
+	assert.True(t, hasSubjects)
+	assert.Equal(t, Denied, level)
+
+	assert.Equal(t, int32(3), atomic.LoadInt32(&requests))
+
+	// The decision is cached.
+	_, level = authorizer.GetRequiredLevel(admin, object)
+	assert.Equal(t, TwoFactor, level)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&requests))
+
+	// The decision expires after the cache duration.
+	clock.Set(clock.Now().Add(time.Minute * 2))
+
+	_, level = authorizer.GetRequiredLevel(admin, object)
+	assert.Equal(t, TwoFactor, level)
+	assert.Equal(t, int32(4), atomic.LoadInt32(&requests))
+
+	// Rules which don't have the external policy don't query the external policy decision point.
+	_, level = authorizer.GetRequiredLevel(admin, NewObject(&url.URL{Scheme: "https", Host: "other.example.com", Path: "/"}, "GET"))
+	assert.Equal(t, Denied, level)
+	assert.Equal(t, int32(4), atomic.LoadInt32(&requests))
+}
+
+func TestExternalPolicyShouldApplyFailurePolicy(t *testing.T) {
+	testCases := []struct {
+		name          string
+		handler       http.HandlerFunc
+		failurePolicy string
+		expected      Level
+	}{
+		{
+			"ShouldFailClosedOnStatusCode",
+			func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+			deny,
+			Denied,
+		},
+		{
+			"ShouldFailOpenOnStatusCode",
+			func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+			twoFactor,
+			TwoFactor,
+		},
+		{
+			"ShouldFailOnInvalidJSON",
+			func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(`{"policy":`))
+			},
+			oneFactor,
+			OneFactor,
+		},
+		{
+			"ShouldFailOnInvalidPolicy",
+			func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(`{"policy":"external"}`))
+			},
+			twoFactor,
+			TwoFactor,
+		},
+		{
+			"ShouldFailOnTimeout",
+			func(w http.ResponseWriter, r *http.Request) {
+				time.Sleep(time.Second * 2)
+
+				_, _ = w.Write([]byte(`{"policy":"bypass"}`))
+			},
+			deny,
+			Denied,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+
+			defer server.Close()
+
+			u, err := url.Parse(server.URL)
+			require.NoError(t, err)
+
+			authorizer := NewAuthorizerBuilder().
+				WithDefaultPolicy(deny).
+				WithExternal(schema.ACLExternal{URL: u, Timeout: time.Second, FailurePolicy: tc.failurePolicy, CacheDuration: time.Minute}).
+				WithRule(schema.ACLRule{Domains: []string{"external.example.com"}, Policy: external}).
+				Build()
+
+			_, level := authorizer.GetRequiredLevel(Subject{Username: "john"}, NewObject(&url.URL{Scheme: "https", Host: "external.example.com", Path: "/"}, "GET"))
+
+			assert.Equal(t, tc.expected, level)
+		})
+	}
+}
+
+func TestExternalPolicyShouldNotCacheFailures(t *testing.T) {
+	var requests int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"policy":"one_factor"}`))
+	}))
+
+	defer server.Close()
+
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	authorizer := NewAuthorizerBuilder().
+		WithDefaultPolicy(deny).
+		WithExternal(schema.ACLExternal{URL: u, Timeout: time.Second, FailurePolicy: deny, CacheDuration: time.Minute}).
+		WithRule(schema.ACLRule{Domains: []string{"external.example.com"}, Policy: external}).
+		Build()
+
+	object := NewObject(&url.URL{Scheme: "https", Host: "external.example.com", Path: "/"}, "GET")
+
+	_, level := authorizer.GetRequiredLevel(Subject{Username: "john"}, object)
+	assert.Equal(t, Denied, level)
+
+	_, level = authorizer.GetRequiredLevel(Subject{Username: "john"}, object)
+	assert.Equal(t, OneFactor, level)
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+}
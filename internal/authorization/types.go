@@ -3,6 +3,7 @@ package authorization
 import (
 	"fmt"
 	"net"
+	"net/http"
 	"net/url"
 	"strings"
 
@@ -24,11 +25,22 @@ type SubjectObjectMatcher interface {
 	IsMatch(subject Subject, object Object) (match bool)
 }
 
+// ObjectMatcher is a matcher that takes an object.
+type ObjectMatcher interface {
+	IsMatch(object Object) (match bool)
+}
+
 // Subject represents the identity of a user for the purposes of ACL matching.
 type Subject struct {
-	Username string
-	Groups   []string
-	IP       net.IP
+	Username   string
+	Groups     []string
+	Attributes map[string][]string
+	IP         net.IP
+	Country    string
+	ASN        uint
+
+	// LocationLookupFailed is true when the country and ASN of the IP couldn't be looked up.
+	LocationLookupFailed bool
 }
 
 // String returns a string representation of the Subject.
@@ -43,7 +55,8 @@ func (s Subject) IsAnonymous() bool {
 
 // Object represents a protected object for the purposes of ACL matching.
 type Object struct {
-	URL url.URL
+	URL    url.URL
+	Header http.Header
 
 	Domain string
 	Path   string
@@ -70,6 +83,15 @@ func NewObject(targetURL *url.URL, method string) (object Object) {
 	}
 }
 
+// NewObjectWithHeader creates a new Object type from a URL, a method header, and the headers of the request.
+func NewObjectWithHeader(targetURL *url.URL, method string, header http.Header) (object Object) {
+	object = NewObject(targetURL, method)
+
+	object.Header = header
+
+	return object
+}
+
 // RuleMatchResult describes how well a rule matched a subject/object combo.
 type RuleMatchResult struct {
 	Rule *AccessControlRule
@@ -80,16 +102,20 @@ type RuleMatchResult struct {
 	MatchResources     bool
 	MatchMethods       bool
 	MatchNetworks      bool
+	MatchLocation      bool
+	MatchQuery         bool
+	MatchHeaders       bool
+	MatchSchedule      bool
 	MatchSubjects      bool
 	MatchSubjectsExact bool
 }
 
 // IsMatch returns true if all the criteria matched.
 func (r RuleMatchResult) IsMatch() (match bool) {
-	return r.MatchDomain && r.MatchResources && r.MatchMethods && r.MatchNetworks && r.MatchSubjectsExact
+	return r.MatchDomain && r.MatchResources && r.MatchMethods && r.MatchNetworks && r.MatchLocation && r.MatchQuery && r.MatchHeaders && r.MatchSchedule && r.MatchSubjectsExact
 }
 
 // IsPotentialMatch returns true if the rule is potentially a match.
 func (r RuleMatchResult) IsPotentialMatch() (match bool) {
-	return r.MatchDomain && r.MatchResources && r.MatchMethods && r.MatchNetworks && r.MatchSubjects && !r.MatchSubjectsExact
+	return r.MatchDomain && r.MatchResources && r.MatchMethods && r.MatchNetworks && r.MatchLocation && r.MatchQuery && r.MatchHeaders && r.MatchSchedule && r.MatchSubjects && !r.MatchSubjectsExact
 }
@@ -7,6 +7,7 @@ import (
 
 	"github.com/authelia/authelia/v4/internal/authentication"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/logging"
 )
 
 // StringToLevel converts a string policy to int authorization level.
@@ -20,6 +21,8 @@ func StringToLevel(policy string) Level {
 		return TwoFactor
 	case deny:
 		return Denied
+	case external:
+		return External
 	}
 	// By default the deny policy applies.
 	return Denied
@@ -36,6 +39,8 @@ func LevelToString(level Level) (policy string) {
 		return twoFactor
 	case Denied:
 		return deny
+	case External:
+		return external
 	}
 
 	return deny
@@ -67,9 +72,23 @@ func schemaSubjectToACLSubject(subjectRule string) (subject SubjectMatcher) {
 		return AccessControlGroup{Name: group}
 	}
 
+	if strings.HasPrefix(subjectRule, prefixAttribute) {
+		if name, value, ok := strings.Cut(subjectRule[len(prefixAttribute):], "="); ok {
+			return AccessControlAttribute{Name: strings.ToLower(strings.Trim(name, " ")), Value: strings.Trim(value, " ")}
+		}
+	}
+
 	return nil
 }
 
+func schemaAuthenticationMethodsToACL(methodRules []string) (methods []string) {
+	for _, method := range methodRules {
+		methods = append(methods, strings.ToLower(method))
+	}
+
+	return methods
+}
+
 func schemaDomainsToACL(domainRules []string, domainRegexRules []regexp.Regexp) (domains []AccessControlDomain) {
 	for _, domainRule := range domainRules {
 		domains = append(domains, NewAccessControlDomain(domainRule))
@@ -122,6 +141,14 @@ func schemaNetworksToACL(networkRules []string, networksMap map[string][]*net.IP
 	return networks
 }
 
+func schemaCountriesToACL(countryRules []string) (countries []string) {
+	for _, country := range countryRules {
+		countries = append(countries, strings.ToUpper(country))
+	}
+
+	return countries
+}
+
 func parseSchemaNetworks(schemaNetworks []schema.ACLNetwork) (networksMap map[string][]*net.IPNet, networksCacheMap map[string]*net.IPNet) {
 	// These maps store pointers to the net.IPNet values so we can reuse them efficiently.
 	// The networksMap contains the named networks as keys, the networksCacheMap contains the CIDR notations as keys.
@@ -182,6 +209,47 @@ func schemaSubjectsToACL(subjectRules [][]string) (subjects []AccessControlSubje
 	return subjects
 }
 
+func schemaQueryToACL(queryRules [][]schema.ACLQueryRule) (query []AccessControlQuery) {
+	for _, queryRule := range queryRules {
+		if len(queryRule) == 0 {
+			continue
+		}
+
+		query = append(query, NewAccessControlQuery(queryRule))
+	}
+
+	return query
+}
+
+func schemaHeadersToACL(headerRules [][]schema.ACLHeaderRule) (headers []AccessControlHeaders) {
+	for _, headerRule := range headerRules {
+		if len(headerRule) == 0 {
+			continue
+		}
+
+		headers = append(headers, NewAccessControlHeaders(headerRule))
+	}
+
+	return headers
+}
+
+func schemaScheduleToACL(scheduleRules []schema.ACLSchedule) (schedules []AccessControlSchedule) {
+	for _, scheduleRule := range scheduleRules {
+		schedule, err := NewAccessControlSchedule(scheduleRule)
+		if err != nil {
+			// The invalid window is kept as a window which never matches, otherwise a rule which only has invalid windows
+			// would have no schedule and would apply at any time.
+			logging.Logger().WithError(err).Error("Access control rule has an invalid schedule window which will never match")
+
+			schedule = AccessControlSchedule{never: true}
+		}
+
+		schedules = append(schedules, schedule)
+	}
+
+	return schedules
+}
+
 func domainToPrefixSuffix(domain string) (prefix, suffix string) {
 	parts := strings.Split(domain, ".")
 
@@ -20,6 +20,7 @@ func TestLevelToString(t *testing.T) {
 		{OneFactor, "one_factor"},
 		{TwoFactor, "two_factor"},
 		{Denied, "deny"},
+		{External, "external"},
 		{99, "deny"},
 	}
 
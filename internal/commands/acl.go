@@ -4,8 +4,11 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"net/http"
 	"net/url"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -13,6 +16,8 @@ import (
 	"github.com/authelia/authelia/v4/internal/configuration"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/configuration/validator"
+	"github.com/authelia/authelia/v4/internal/geoip"
+	"github.com/authelia/authelia/v4/internal/utils"
 )
 
 func newAccessControlCommand() (cmd *cobra.Command) {
@@ -27,6 +32,7 @@ func newAccessControlCommand() (cmd *cobra.Command) {
 
 	cmd.AddCommand(
 		newAccessControlCheckCommand(),
+		newAccessControlTestCommand(),
 	)
 
 	return cmd
@@ -47,34 +53,65 @@ func newAccessControlCheckCommand() (cmd *cobra.Command) {
 
 	cmd.Flags().String("url", "", "the url of the object")
 	cmd.Flags().String("method", "GET", "the HTTP method of the object")
+	cmd.Flags().StringArray("header", nil, "a request header of the object in the format 'Name: value'")
 	cmd.Flags().String("username", "", "the username of the subject")
 	cmd.Flags().StringSlice("groups", nil, "the groups of the subject")
+	cmd.Flags().StringArray("attribute", nil, "an attribute of the subject in the format 'name=value'")
 	cmd.Flags().String("ip", "", "the ip of the subject")
+	cmd.Flags().String("country", "", "the country code of the subject, defaults to the geoip lookup of the ip")
+	cmd.Flags().Uint("asn", 0, "the autonomous system number of the subject, defaults to the geoip lookup of the ip")
+	cmd.Flags().String("time", "", "the time of the request in RFC3339 format, defaults to the current time")
 	cmd.Flags().Bool("verbose", false, "enables verbose output")
 
 	return cmd
 }
 
-func accessControlCheckRunE(cmd *cobra.Command, _ []string) (err error) {
+func loadAccessControlConfig(cmd *cobra.Command) (accessControlConfig *schema.Configuration, val *schema.StructValidator, err error) {
 	configs, err := cmd.Flags().GetStringSlice("config")
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 
-	sources := make([]configuration.Source, len(configs)+2)
+	val = schema.NewStructValidator()
+
+	accessControlConfig = &schema.Configuration{}
 
-	for i, path := range configs {
-		sources[i] = configuration.NewYAMLFileSource(path)
+	if _, err = configuration.LoadAdvanced(val, "access_control", &accessControlConfig.AccessControl, configuration.NewDefaultSources(configs, configuration.DefaultEnvPrefix, configuration.DefaultEnvDelimiter)...); err != nil {
+		return nil, nil, err
 	}
 
-	sources[0+len(configs)] = configuration.NewEnvironmentSource(configuration.DefaultEnvPrefix, configuration.DefaultEnvDelimiter)
-	sources[1+len(configs)] = configuration.NewSecretsSource(configuration.DefaultEnvPrefix, configuration.DefaultEnvDelimiter)
+	// The geoip section is required to validate and evaluate the rules which match the location of the subject.
+	if _, err = configuration.LoadAdvanced(val, "geoip", &accessControlConfig.GeoIP, configuration.NewDefaultSources(configs, configuration.DefaultEnvPrefix, configuration.DefaultEnvDelimiter)...); err != nil {
+		return nil, nil, err
+	}
 
-	val := schema.NewStructValidator()
+	// The authentication_backend section is required to validate the rules which match the attributes of the subject.
+	if _, err = configuration.LoadAdvanced(val, "authentication_backend", &accessControlConfig.AuthenticationBackend, configuration.NewDefaultSources(configs, configuration.DefaultEnvPrefix, configuration.DefaultEnvDelimiter)...); err != nil {
+		return nil, nil, err
+	}
 
-	accessControlConfig := &schema.Configuration{}
+	return accessControlConfig, val, nil
+}
+
+// getAccessControlGeoIPProvider returns a started geoip.Provider if the geoip section of the configuration is
+// configured, otherwise it returns nil.
+func getAccessControlGeoIPProvider(config *schema.Configuration) (provider geoip.Provider, err error) {
+	if config.GeoIP.Path == "" && config.GeoIP.ASNPath == "" {
+		return nil, nil
+	}
+
+	provider = geoip.NewMaxMindProvider(&config.GeoIP)
+
+	if err = provider.StartupCheck(); err != nil {
+		return nil, err
+	}
 
-	if _, err = configuration.LoadAdvanced(val, "access_control", &accessControlConfig.AccessControl, sources...); err != nil {
+	return provider, nil
+}
+
+func accessControlCheckRunE(cmd *cobra.Command, _ []string) (err error) {
+	accessControlConfig, val, err := loadAccessControlConfig(cmd)
+	if err != nil {
 		return err
 	}
 
@@ -84,13 +121,23 @@ func accessControlCheckRunE(cmd *cobra.Command, _ []string) (err error) {
 		return errors.New("your configuration has errors")
 	}
 
-	authorizer := authorization.NewAuthorizer(accessControlConfig)
-
 	subject, object, err := getSubjectAndObjectFromFlags(cmd)
 	if err != nil {
 		return err
 	}
 
+	clock, err := getClockFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	provider, err := getAccessControlGeoIPProvider(accessControlConfig)
+	if err != nil {
+		return err
+	}
+
+	authorizer := authorization.NewAuthorizer(accessControlConfig, clock, provider)
+
 	results := authorizer.GetRuleMatchResults(subject, object)
 
 	if len(results) == 0 {
@@ -104,12 +151,12 @@ func accessControlCheckRunE(cmd *cobra.Command, _ []string) (err error) {
 		return err
 	}
 
-	accessControlCheckWriteOutput(object, subject, results, accessControlConfig.AccessControl.DefaultPolicy, verbose)
+	accessControlCheckWriteOutput(object, subject, clock.Now(), results, accessControlConfig.AccessControl.DefaultPolicy, verbose)
 
 	return nil
 }
 
-func accessControlCheckWriteObjectSubject(object authorization.Object, subject authorization.Subject) {
+func accessControlCheckWriteObjectSubject(object authorization.Object, subject authorization.Subject, now time.Time) {
 	output := strings.Builder{}
 
 	output.WriteString(fmt.Sprintf("Performing policy check for request to '%s'", object.String()))
@@ -126,19 +173,41 @@ func accessControlCheckWriteObjectSubject(object authorization.Object, subject a
 		output.WriteString(fmt.Sprintf(" groups '%s'", strings.Join(subject.Groups, ",")))
 	}
 
+	if len(object.Header) != 0 {
+		names := make([]string, 0, len(object.Header))
+
+		for name := range object.Header {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		output.WriteString(fmt.Sprintf(" headers '%s'", strings.Join(names, ",")))
+	}
+
 	if subject.IP != nil {
 		output.WriteString(fmt.Sprintf(" from IP '%s'", subject.IP.String()))
 	}
 
+	if subject.Country != "" {
+		output.WriteString(fmt.Sprintf(" country '%s'", subject.Country))
+	}
+
+	if subject.ASN != 0 {
+		output.WriteString(fmt.Sprintf(" asn '%d'", subject.ASN))
+	}
+
+	output.WriteString(fmt.Sprintf(" at '%s'", now.Format(time.RFC3339)))
+
 	output.WriteString(".\n")
 
 	fmt.Println(output.String())
 }
 
-func accessControlCheckWriteOutput(object authorization.Object, subject authorization.Subject, results []authorization.RuleMatchResult, defaultPolicy string, verbose bool) {
-	accessControlCheckWriteObjectSubject(object, subject)
+func accessControlCheckWriteOutput(object authorization.Object, subject authorization.Subject, now time.Time, results []authorization.RuleMatchResult, defaultPolicy string, verbose bool) {
+	accessControlCheckWriteObjectSubject(object, subject, now)
 
-	fmt.Printf("  #\tDomain\tResource\tMethod\tNetwork\tSubject\n")
+	fmt.Printf("  #\tDomain\tResource\tMethod\tNetwork\tLocation\tQuery\tHeader\tSchedule\tSubject\n")
 
 	var (
 		appliedPos int
@@ -157,15 +226,15 @@ func accessControlCheckWriteOutput(object authorization.Object, subject authoriz
 		case result.IsMatch() && !result.Skipped:
 			appliedPos, applied = i+1, result
 
-			fmt.Printf("* %d\t%s\t%s\t\t%s\t%s\t%s\n", i+1, hitMissMay(result.MatchDomain), hitMissMay(result.MatchResources), hitMissMay(result.MatchMethods), hitMissMay(result.MatchNetworks), hitMissMay(result.MatchSubjects, result.MatchSubjectsExact))
+			accessControlCheckWriteRow("* ", i+1, result)
 		case result.IsPotentialMatch() && !result.Skipped:
 			if potentialPos == 0 {
 				potentialPos, potential = i+1, result
 			}
 
-			fmt.Printf("~ %d\t%s\t%s\t\t%s\t%s\t%s\n", i+1, hitMissMay(result.MatchDomain), hitMissMay(result.MatchResources), hitMissMay(result.MatchMethods), hitMissMay(result.MatchNetworks), hitMissMay(result.MatchSubjects, result.MatchSubjectsExact))
+			accessControlCheckWriteRow("~ ", i+1, result)
 		default:
-			fmt.Printf("  %d\t%s\t%s\t\t%s\t%s\t%s\n", i+1, hitMissMay(result.MatchDomain), hitMissMay(result.MatchResources), hitMissMay(result.MatchMethods), hitMissMay(result.MatchNetworks), hitMissMay(result.MatchSubjects, result.MatchSubjectsExact))
+			accessControlCheckWriteRow("  ", i+1, result)
 		}
 	}
 
@@ -181,6 +250,10 @@ func accessControlCheckWriteOutput(object authorization.Object, subject authoriz
 	}
 }
 
+func accessControlCheckWriteRow(prefix string, position int, result authorization.RuleMatchResult) {
+	fmt.Printf("%s%d\t%s\t%s\t\t%s\t%s\t%s\t\t%s\t%s\t%s\t\t%s\n", prefix, position, hitMissMay(result.MatchDomain), hitMissMay(result.MatchResources), hitMissMay(result.MatchMethods), hitMissMay(result.MatchNetworks), hitMissMay(result.MatchLocation), hitMissMay(result.MatchQuery), hitMissMay(result.MatchHeaders), hitMissMay(result.MatchSchedule), hitMissMay(result.MatchSubjects, result.MatchSubjectsExact))
+}
+
 func hitMissMay(in ...bool) (out string) {
 	var hit, miss bool
 
@@ -218,6 +291,22 @@ func getSubjectAndObjectFromFlags(cmd *cobra.Command) (subject authorization.Sub
 		return subject, object, err
 	}
 
+	headers, err := cmd.Flags().GetStringArray("header")
+	if err != nil {
+		return subject, object, err
+	}
+
+	header := http.Header{}
+
+	for _, h := range headers {
+		name, value, found := strings.Cut(h, ":")
+		if !found {
+			return subject, object, fmt.Errorf("header '%s' is not in the format 'Name: value'", h)
+		}
+
+		header.Add(strings.TrimSpace(name), strings.TrimSpace(value))
+	}
+
 	username, err := cmd.Flags().GetString("username")
 	if err != nil {
 		return subject, object, err
@@ -228,6 +317,28 @@ func getSubjectAndObjectFromFlags(cmd *cobra.Command) (subject authorization.Sub
 		return subject, object, err
 	}
 
+	attrs, err := cmd.Flags().GetStringArray("attribute")
+	if err != nil {
+		return subject, object, err
+	}
+
+	var attributes map[string][]string
+
+	for _, attr := range attrs {
+		name, value, found := strings.Cut(attr, "=")
+		if !found {
+			return subject, object, fmt.Errorf("attribute '%s' is not in the format 'name=value'", attr)
+		}
+
+		if attributes == nil {
+			attributes = map[string][]string{}
+		}
+
+		name = strings.ToLower(strings.TrimSpace(name))
+
+		attributes[name] = append(attributes[name], strings.TrimSpace(value))
+	}
+
 	remoteIP, err := cmd.Flags().GetString("ip")
 	if err != nil {
 		return subject, object, err
@@ -235,13 +346,59 @@ func getSubjectAndObjectFromFlags(cmd *cobra.Command) (subject authorization.Sub
 
 	parsedIP := net.ParseIP(remoteIP)
 
+	country, err := cmd.Flags().GetString("country")
+	if err != nil {
+		return subject, object, err
+	}
+
+	asn, err := cmd.Flags().GetUint("asn")
+	if err != nil {
+		return subject, object, err
+	}
+
 	subject = authorization.Subject{
-		Username: username,
-		Groups:   groups,
-		IP:       parsedIP,
+		Username:   username,
+		Groups:     groups,
+		Attributes: attributes,
+		IP:         parsedIP,
+		Country:    strings.ToUpper(country),
+		ASN:        asn,
 	}
 
-	object = authorization.NewObject(parsedURL, method)
+	object = authorization.NewObjectWithHeader(parsedURL, method, header)
 
 	return subject, object, nil
 }
+
+// accessControlClock is a utils.Clock which is fixed to the time provided to the check-policy command.
+type accessControlClock struct {
+	now time.Time
+}
+
+// Now returns the fixed time.
+func (c accessControlClock) Now() time.Time {
+	return c.now
+}
+
+// After returns a channel receiving the time after duration has elapsed.
+func (c accessControlClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+func getClockFromFlags(cmd *cobra.Command) (clock utils.Clock, err error) {
+	value, err := cmd.Flags().GetString("time")
+	if err != nil {
+		return nil, err
+	}
+
+	if value == "" {
+		return utils.RealClock{}, nil
+	}
+
+	now, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the time '%s': %w", value, err)
+	}
+
+	return accessControlClock{now: now}, nil
+}
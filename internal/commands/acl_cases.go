@@ -0,0 +1,282 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/authelia/authelia/v4/internal/authorization"
+	"github.com/authelia/authelia/v4/internal/configuration/validator"
+)
+
+func newAccessControlTestCommand() (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "test [cases]",
+		Short:   cmdAutheliaAccessControlTestShort,
+		Long:    cmdAutheliaAccessControlTestLong,
+		Example: cmdAutheliaAccessControlTestExample,
+		Args:    cobra.ExactArgs(1),
+		RunE:    accessControlTestRunE,
+
+		DisableAutoGenTag: true,
+	}
+
+	cmdWithConfigFlags(cmd, false, []string{"configuration.yml"})
+
+	return cmd
+}
+
+func accessControlTestRunE(cmd *cobra.Command, args []string) (err error) {
+	accessControlConfig, val, err := loadAccessControlConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	validator.ValidateAccessControl(accessControlConfig, val)
+	validator.ValidateRules(accessControlConfig, val)
+
+	for _, warning := range val.Warnings() {
+		fmt.Printf("Configuration warning: %v\n", warning)
+	}
+
+	if val.HasErrors() {
+		for _, err = range val.Errors() {
+			fmt.Printf("Configuration error: %v\n", err)
+		}
+
+		return errors.New("your configuration has errors")
+	}
+
+	cases, err := loadAccessControlTestCases(args[0])
+	if err != nil {
+		return err
+	}
+
+	clock := &accessControlClock{}
+
+	provider, err := getAccessControlGeoIPProvider(accessControlConfig)
+	if err != nil {
+		return err
+	}
+
+	authorizer := authorization.NewAuthorizer(accessControlConfig, clock, provider)
+
+	results := runAccessControlTestCases(authorizer, clock, cases)
+
+	failures := accessControlTestWriteOutput(results)
+
+	if failures != 0 {
+		return fmt.Errorf("%d of %d access control test cases failed", failures, len(results))
+	}
+
+	return nil
+}
+
+// AccessControlTestCases represents the YAML file of cases for the access-control test command.
+type AccessControlTestCases struct {
+	Cases []AccessControlTestCase `yaml:"cases"`
+}
+
+// AccessControlTestCase represents a single request and the policy which is expected to be applied to it.
+type AccessControlTestCase struct {
+	Name       string              `yaml:"name"`
+	URL        string              `yaml:"url"`
+	Method     string              `yaml:"method"`
+	Headers    map[string]string   `yaml:"headers"`
+	Username   string              `yaml:"username"`
+	Groups     []string            `yaml:"groups"`
+	Attributes map[string][]string `yaml:"attributes"`
+	IP         string              `yaml:"ip"`
+	Country    string              `yaml:"country"`
+	ASN        uint                `yaml:"asn"`
+	Time       string              `yaml:"time"`
+	Policy     string              `yaml:"policy"`
+
+	subject authorization.Subject
+	object  authorization.Object
+	now     time.Time
+}
+
+func (c AccessControlTestCase) String() string {
+	if c.Name != "" {
+		return c.Name
+	}
+
+	output := strings.Builder{}
+
+	output.WriteString(fmt.Sprintf("%s %s", c.object.Method, c.object.String()))
+
+	if c.Username != "" {
+		output.WriteString(fmt.Sprintf(" username '%s'", c.Username))
+	}
+
+	if len(c.Groups) != 0 {
+		output.WriteString(fmt.Sprintf(" groups '%s'", strings.Join(c.Groups, ",")))
+	}
+
+	if c.IP != "" {
+		output.WriteString(fmt.Sprintf(" from IP '%s'", c.IP))
+	}
+
+	if c.Country != "" {
+		output.WriteString(fmt.Sprintf(" country '%s'", c.Country))
+	}
+
+	if c.ASN != 0 {
+		output.WriteString(fmt.Sprintf(" asn '%d'", c.ASN))
+	}
+
+	return output.String()
+}
+
+// AccessControlTestResult is the result of evaluating an AccessControlTestCase.
+type AccessControlTestResult struct {
+	Case   AccessControlTestCase
+	Actual string
+}
+
+// Passed returns true if the actual policy is the expected policy.
+func (r AccessControlTestResult) Passed() bool {
+	return r.Actual == r.Case.Policy
+}
+
+func loadAccessControlTestCases(path string) (cases []AccessControlTestCase, err error) {
+	var data []byte
+
+	if data, err = os.ReadFile(path); err != nil {
+		return nil, fmt.Errorf("failed to read the test cases file '%s': %w", path, err)
+	}
+
+	file := AccessControlTestCases{}
+
+	if err = yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse the test cases file '%s': %w", path, err)
+	}
+
+	if len(file.Cases) == 0 {
+		return nil, fmt.Errorf("the test cases file '%s' has no cases", path)
+	}
+
+	for i := range file.Cases {
+		if err = parseAccessControlTestCase(&file.Cases[i]); err != nil {
+			return nil, fmt.Errorf("test case #%d is invalid: %w", i+1, err)
+		}
+	}
+
+	return file.Cases, nil
+}
+
+func parseAccessControlTestCase(c *AccessControlTestCase) (err error) {
+	if c.URL == "" {
+		return errors.New("the 'url' option is required")
+	}
+
+	parsedURL, err := url.ParseRequestURI(c.URL)
+	if err != nil {
+		return fmt.Errorf("the 'url' option '%s' is invalid: %w", c.URL, err)
+	}
+
+	if !validator.IsPolicyValid(c.Policy) {
+		return fmt.Errorf("the 'policy' option '%s' is invalid: must be one of 'deny', 'two_factor', 'one_factor' or 'bypass'", c.Policy)
+	}
+
+	if c.Method == "" {
+		c.Method = http.MethodGet
+	}
+
+	header := http.Header{}
+
+	for name, value := range c.Headers {
+		header.Add(name, value)
+	}
+
+	var ip net.IP
+
+	if c.IP != "" {
+		if ip = net.ParseIP(c.IP); ip == nil {
+			return fmt.Errorf("the 'ip' option '%s' is not a valid IP address", c.IP)
+		}
+	}
+
+	if c.Time != "" {
+		if c.now, err = time.Parse(time.RFC3339, c.Time); err != nil {
+			return fmt.Errorf("the 'time' option '%s' is invalid: %w", c.Time, err)
+		}
+	}
+
+	var attributes map[string][]string
+
+	for name, values := range c.Attributes {
+		if attributes == nil {
+			attributes = map[string][]string{}
+		}
+
+		attributes[strings.ToLower(name)] = values
+	}
+
+	c.subject = authorization.Subject{
+		Username:   c.Username,
+		Groups:     c.Groups,
+		Attributes: attributes,
+		IP:         ip,
+		Country:    strings.ToUpper(c.Country),
+		ASN:        c.ASN,
+	}
+
+	c.object = authorization.NewObjectWithHeader(parsedURL, strings.ToUpper(c.Method), header)
+
+	return nil
+}
+
+func runAccessControlTestCases(authorizer *authorization.Authorizer, clock *accessControlClock, cases []AccessControlTestCase) (results []AccessControlTestResult) {
+	results = make([]AccessControlTestResult, len(cases))
+
+	for i, c := range cases {
+		if c.now.IsZero() {
+			clock.now = time.Now()
+		} else {
+			clock.now = c.now
+		}
+
+		_, level := authorizer.GetRequiredLevel(c.subject, c.object)
+
+		results[i] = AccessControlTestResult{
+			Case:   c,
+			Actual: authorization.LevelToString(level),
+		}
+	}
+
+	return results
+}
+
+func accessControlTestWriteOutput(results []AccessControlTestResult) (failures int) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+	_, _ = fmt.Fprintf(w, "  #\tResult\tExpected\tActual\tCase\n")
+
+	for i, result := range results {
+		status := "PASS"
+
+		if !result.Passed() {
+			status = "FAIL"
+			failures++
+		}
+
+		_, _ = fmt.Fprintf(w, "  %d\t%s\t%s\t%s\t%s\n", i+1, status, result.Case.Policy, result.Actual, result.Case.String())
+	}
+
+	_ = w.Flush()
+
+	fmt.Printf("\n%d of %d access control test cases passed.\n\n", len(results)-failures, len(results))
+
+	return failures
+}
@@ -0,0 +1,148 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/authorization"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/configuration/validator"
+)
+
+func TestRunAccessControlTestCases(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cases.yml")
+
+	require.NoError(t, os.WriteFile(path, []byte(`
+cases:
+  - name: 'Public'
+    url: 'https://public.example.com/'
+    policy: 'bypass'
+  - url: 'https://admin.example.com/'
+    method: 'post'
+    username: 'john'
+    groups: ['admins']
+    ip: '192.168.1.10'
+    policy: 'two_factor'
+  - url: 'https://admin.example.com/'
+    username: 'bob'
+    policy: 'one_factor'
+  - url: 'https://office.example.com/'
+    username: 'bob'
+    time: '2022-10-10T10:00:00Z'
+    policy: 'one_factor'
+  - url: 'https://office.example.com/'
+    username: 'bob'
+    time: '2022-10-10T20:00:00Z'
+    policy: 'deny'
+  - url: 'https://geo.example.com/'
+    ip: '203.0.113.10'
+    country: 'de'
+    asn: 3320
+    policy: 'one_factor'
+`), 0600))
+
+	cases, err := loadAccessControlTestCases(path)
+	require.NoError(t, err)
+	require.Len(t, cases, 6)
+
+	config := &schema.Configuration{
+		AccessControl: schema.AccessControlConfiguration{
+			DefaultPolicy: "deny",
+			Rules: []schema.ACLRule{
+				{Domains: []string{"public.example.com"}, Policy: "bypass"},
+				{Domains: []string{"admin.example.com"}, Methods: []string{"POST"}, Subjects: [][]string{{"group:admins"}}, Policy: "two_factor"},
+				{
+					Domains:  []string{"office.example.com"},
+					Schedule: []schema.ACLSchedule{{Start: "08:00", End: "18:00", TimeZone: "UTC"}},
+					Policy:   "one_factor",
+				},
+				{Domains: []string{"geo.example.com"}, Countries: []string{"DE"}, Policy: "one_factor"},
+			},
+		},
+	}
+
+	clock := &accessControlClock{}
+
+	results := runAccessControlTestCases(authorization.NewAuthorizer(config, clock, nil), clock, cases)
+	require.Len(t, results, 6)
+
+	assert.True(t, results[0].Passed())
+	assert.Equal(t, "Public", results[0].Case.String())
+	assert.True(t, results[1].Passed())
+	assert.Equal(t, "POST https://admin.example.com/ username 'john' groups 'admins' from IP '192.168.1.10'", results[1].Case.String())
+	assert.False(t, results[2].Passed())
+	assert.Equal(t, "deny", results[2].Actual)
+	assert.True(t, results[3].Passed())
+	assert.True(t, results[4].Passed())
+	assert.True(t, results[5].Passed())
+	assert.Equal(t, "GET https://geo.example.com/ from IP '203.0.113.10' country 'de' asn '3320'", results[5].Case.String())
+}
+
+func TestLoadAccessControlConfigShouldLoadAttributes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "configuration.yml")
+
+	require.NoError(t, os.WriteFile(path, []byte(`
+authentication_backend:
+  file:
+    path: /config/users_database.yml
+    extra_attributes:
+      - department
+access_control:
+  default_policy: deny
+  rules:
+    - domain: 'app.example.com'
+      policy: one_factor
+      subject: 'attr:department=engineering'
+`), 0600))
+
+	cmd := newAccessControlTestCommand()
+	require.NoError(t, cmd.Flags().Set("config", path))
+
+	accessControlConfig, val, err := loadAccessControlConfig(cmd)
+	require.NoError(t, err)
+
+	require.NotNil(t, accessControlConfig.AuthenticationBackend.File)
+	assert.Equal(t, []string{"department"}, accessControlConfig.AuthenticationBackend.File.ExtraAttributes)
+
+	validator.ValidateRules(accessControlConfig, val)
+
+	assert.Len(t, val.Errors(), 0)
+	assert.Len(t, val.Warnings(), 0)
+}
+
+func TestLoadAccessControlTestCasesInvalid(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     string
+		expected string
+	}{
+		{"ShouldErrNoURL", "cases: [{policy: bypass}]", "test case #1 is invalid: the 'url' option is required"},
+		{"ShouldErrBadPolicy", "cases: [{url: 'https://example.com', policy: 'abc'}]", "test case #1 is invalid: the 'policy' option 'abc' is invalid: must be one of 'deny', 'two_factor', 'one_factor' or 'bypass'"},
+		{"ShouldErrBadIP", "cases: [{url: 'https://example.com', policy: 'deny', ip: 'abc'}]", "test case #1 is invalid: the 'ip' option 'abc' is not a valid IP address"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "cases.yml")
+
+			require.NoError(t, os.WriteFile(path, []byte(tc.have), 0600))
+
+			_, err := loadAccessControlTestCases(path)
+
+			assert.EqualError(t, err, tc.expected)
+		})
+	}
+
+	path := filepath.Join(t.TempDir(), "cases.yml")
+
+	require.NoError(t, os.WriteFile(path, []byte("cases: []"), 0600))
+
+	_, err := loadAccessControlTestCases(path)
+
+	assert.EqualError(t, err, fmt.Sprintf("the test cases file '%s' has no cases", path))
+}
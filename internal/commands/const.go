@@ -72,8 +72,42 @@ Notes:
 	cmdAutheliaAccessControlCheckPolicyExample = `authelia access-control check-policy --config config.yml --url https://example.com
 authelia access-control check-policy --config config.yml --url https://example.com --username john
 authelia access-control check-policy --config config.yml --url https://example.com --groups admin,public
+authelia access-control check-policy --config config.yml --url https://example.com --username john --attribute department=engineering
+authelia access-control check-policy --config config.yml --url https://example.com --ip 203.0.113.10 --country DE
 authelia access-control check-policy --config config.yml --url https://example.com --username john --method GET
-authelia access-control check-policy --config config.yml --url https://example.com --username john --method GET --verbose`
+authelia access-control check-policy --config config.yml --url https://example.com --username john --method GET --verbose
+authelia access-control check-policy --config config.yml --url "https://example.com/?token=abc" --header "X-Api-Key: 123"
+authelia access-control check-policy --config config.yml --url https://example.com --username john --time 2022-10-10T18:00:00+02:00`
+
+	cmdAutheliaAccessControlTestShort = "Tests a list of requests against the access control rules and checks the expected policy is applied"
+
+	cmdAutheliaAccessControlTestLong = `
+Tests a list of requests against the access control rules and checks the expected policy is applied to each of them.
+
+The cases are read from a YAML file which has a list of cases under the 'cases' key. Each case has the url, method,
+headers, username, groups, attributes, ip, country, asn, and time of the request as well as the policy which is expected to be applied. Only the
+url and policy are required, the method defaults to GET and the time defaults to the current time.
+
+Example file:
+
+	cases:
+	  - name: 'Public site is bypassed'
+	    url: 'https://public.example.com/'
+	    policy: 'bypass'
+	  - url: 'https://admin.example.com/'
+	    method: 'POST'
+	    username: 'john'
+	    groups: ['admins']
+	    attributes:
+	      department: ['engineering']
+	    ip: '192.168.1.10'
+	    policy: 'two_factor'
+
+The command exits with a non-zero exit code if the policy applied to any of the cases is not the expected policy.
+`
+
+	cmdAutheliaAccessControlTestExample = `authelia access-control test cases.yml --config config.yml
+authelia access-control test cases.yml --config config.yml --config config.acl.yml`
 
 	cmdAutheliaStorageShort = "Manage the Authelia storage"
 
@@ -219,6 +253,58 @@ authelia storage user totp export --format png --dir ./totp-qr
 authelia storage user totp export --format png --dir ./totp-qr --config config.yml
 authelia storage user totp export --format png --dir ./totp-qr --encryption-key b3453fde-ecc2-4a1f-9422-2707ddbed495 --postgres.host postgres --postgres.password autheliapw`
 
+	cmdAutheliaStorageUserAccountShort = "Manage user accounts"
+
+	cmdAutheliaStorageUserAccountLong = `Manage user accounts.
+
+This subcommand allows adding, deleting, and modifying the user accounts used by the SQL authentication backend.`
+
+	cmdAutheliaStorageUserAccountExample = `authelia storage user account --help`
+
+	cmdAutheliaStorageUserAccountAddShort = "Add a user account"
+
+	cmdAutheliaStorageUserAccountAddLong = `Add a user account.
+
+This subcommand allows adding a user account to the SQL authentication backend. The password is hashed using the
+authentication_backend.sql.password configuration.`
+
+	cmdAutheliaStorageUserAccountAddExample = `authelia storage user account add john --password 'p@ssw0rd' --display-name 'John Doe' --email john.doe@example.com --groups admins,dev
+authelia storage user account add john --password 'p@ssw0rd' --config config.yml
+authelia storage user account add john --password 'p@ssw0rd' --encryption-key b3453fde-ecc2-4a1f-9422-2707ddbed495 --postgres.host postgres --postgres.password autheliapw`
+
+	cmdAutheliaStorageUserAccountDeleteShort = "Delete a user account"
+
+	cmdAutheliaStorageUserAccountDeleteLong = `Delete a user account.
+
+This subcommand allows deleting a user account and the group memberships of the account from the SQL authentication backend.`
+
+	cmdAutheliaStorageUserAccountDeleteExample = `authelia storage user account delete john
+authelia storage user account delete john --config config.yml
+authelia storage user account delete john --encryption-key b3453fde-ecc2-4a1f-9422-2707ddbed495 --postgres.host postgres --postgres.password autheliapw`
+
+	cmdAutheliaStorageUserAccountPasswdShort = "Change the password of a user account"
+
+	cmdAutheliaStorageUserAccountPasswdLong = `Change the password of a user account.
+
+This subcommand allows changing the password of a user account in the SQL authentication backend. The password is hashed
+using the authentication_backend.sql.password configuration.`
+
+	cmdAutheliaStorageUserAccountPasswdExample = `authelia storage user account passwd john --password 'n3wp@ssw0rd'
+authelia storage user account passwd john --password 'n3wp@ssw0rd' --config config.yml
+authelia storage user account passwd john --password 'n3wp@ssw0rd' --encryption-key b3453fde-ecc2-4a1f-9422-2707ddbed495 --postgres.host postgres --postgres.password autheliapw`
+
+	cmdAutheliaStorageUserAccountGroupsShort = "Show or replace the groups of a user account"
+
+	cmdAutheliaStorageUserAccountGroupsLong = `Show or replace the groups of a user account.
+
+This subcommand shows the groups of a user account in the SQL authentication backend, or replaces them when the groups
+flag is specified.`
+
+	cmdAutheliaStorageUserAccountGroupsExample = `authelia storage user account groups john
+authelia storage user account groups john --groups admins,dev
+authelia storage user account groups john --groups ''
+authelia storage user account groups john --groups admins --encryption-key b3453fde-ecc2-4a1f-9422-2707ddbed495 --postgres.host postgres --postgres.password autheliapw`
+
 	cmdAutheliaStorageSchemaInfoShort = "Show the storage information"
 
 	cmdAutheliaStorageSchemaInfoLong = `Show the storage information.
@@ -391,6 +477,102 @@ authelia hash-password --iterations=4 -- 'mypass'
 authelia hash-password --memory=128 -- 'mypass'
 authelia hash-password --parallelism=1 -- 'mypass'
 authelia hash-password --key-length=64 -- 'mypass'`
+
+	cmdAutheliaPasswordPolicyShort = "Helpers for the password policy"
+
+	cmdAutheliaPasswordPolicyLong = `Helpers for the password policy.`
+
+	cmdAutheliaPasswordPolicyExample = `authelia password-policy --help`
+
+	cmdAutheliaPasswordPolicyBuildBreachedFilterShort = "Build a breached password bloom filter from a HIBP SHA-1 dump"
+
+	cmdAutheliaPasswordPolicyBuildBreachedFilterLong = `Build a breached password bloom filter from a HIBP SHA-1 dump.
+
+This subcommand allows building the bloom filter used by the password_policy.breached configuration from a Have I Been
+Pwned SHA-1 dump. The dump is either a single file of hashes ordered by hash, or a directory of range files named after
+the first five characters of the hashes they contain. The dump is read twice, first to size the filter and then to
+populate it, and the filter is built in memory before it's written to the filter file.`
+
+	cmdAutheliaPasswordPolicyBuildBreachedFilterExample = `authelia password-policy build-breached-filter pwned-passwords-sha1-ordered-by-hash-v8.txt breached.bloom
+authelia password-policy build-breached-filter pwnedpasswords/ breached.bloom
+authelia password-policy build-breached-filter pwned-passwords-sha1-ordered-by-hash-v8.txt breached.bloom --false-positive-rate 0.0001
+authelia password-policy build-breached-filter pwned-passwords-sha1-ordered-by-hash-v8.txt breached.bloom --min-occurrences 10`
+
+	cmdAutheliaUsersShort = "Manage the users of the file authentication backend"
+
+	cmdAutheliaUsersLong = `Manage the users of the file authentication backend.
+
+This subcommand allows listing, adding, deleting, and modifying the users in the users database file used by the file
+authentication backend. The users database file is the authentication_backend.file.path configuration unless the path
+flag is specified, and it's validated before it's modified and written atomically. The users database file must exist.`
+
+	cmdAutheliaUsersExample = `authelia users --help`
+
+	cmdAutheliaUsersListShort = "List the users"
+
+	cmdAutheliaUsersListLong = `List the users.
+
+This subcommand lists the users in the users database file with their display name, email address, and groups.`
+
+	cmdAutheliaUsersListExample = `authelia users list
+authelia users list --config config.yml
+authelia users list --path users_database.yml`
+
+	cmdAutheliaUsersAddShort = "Add a user"
+
+	cmdAutheliaUsersAddLong = `Add a user.
+
+This subcommand allows adding a user to the users database file. The password must comply with the password_policy
+configuration and is hashed using the authentication_backend.file.password configuration. When the password history is
+enabled the password is saved to the password history using the storage configuration.`
+
+	cmdAutheliaUsersAddExample = `authelia users add john --password 'p@ssw0rd' --display-name 'John Doe' --email john.doe@example.com --groups admins,dev
+authelia users add john --password 'p@ssw0rd' --config config.yml
+authelia users add john --password 'p@ssw0rd' --path users_database.yml`
+
+	cmdAutheliaUsersDeleteShort = "Delete a user"
+
+	cmdAutheliaUsersDeleteLong = `Delete a user.
+
+This subcommand allows deleting a user from the users database file.`
+
+	cmdAutheliaUsersDeleteExample = `authelia users delete john
+authelia users delete john --config config.yml
+authelia users delete john --path users_database.yml`
+
+	cmdAutheliaUsersSetPasswordShort = "Change the password of a user"
+
+	cmdAutheliaUsersSetPasswordLong = `Change the password of a user.
+
+This subcommand allows changing the password of a user in the users database file. The password must comply with the
+password_policy configuration and is hashed using the authentication_backend.file.password configuration. When the
+password history is enabled the password can't be the current password or one of the previous passwords of the user,
+and the storage configuration is used to check and save the password history.`
+
+	cmdAutheliaUsersSetPasswordExample = `authelia users set-password john --password 'n3wp@ssw0rd'
+authelia users set-password john --password 'n3wp@ssw0rd' --config config.yml
+authelia users set-password john --password 'n3wp@ssw0rd' --path users_database.yml`
+
+	cmdAutheliaUsersSetGroupsShort = "Replace the groups of a user"
+
+	cmdAutheliaUsersSetGroupsLong = `Replace the groups of a user.
+
+This subcommand allows replacing the groups of a user in the users database file.`
+
+	cmdAutheliaUsersSetGroupsExample = `authelia users set-groups john --groups admins,dev
+authelia users set-groups john --groups ''
+authelia users set-groups john --groups admins --path users_database.yml`
+
+	cmdAutheliaUsersDisableShort = "Disable a user"
+
+	cmdAutheliaUsersDisableLong = `Disable a user.
+
+This subcommand allows disabling a user in the users database file so they can no longer log in, or enabling them again
+when the enable flag is specified.`
+
+	cmdAutheliaUsersDisableExample = `authelia users disable john
+authelia users disable john --enable
+authelia users disable john --path users_database.yml`
 )
 
 const (
@@ -1,8 +1,11 @@
 package commands
 
 import (
+	"crypto/x509"
+
 	"github.com/authelia/authelia/v4/internal/authentication"
 	"github.com/authelia/authelia/v4/internal/authorization"
+	"github.com/authelia/authelia/v4/internal/geoip"
 	"github.com/authelia/authelia/v4/internal/metrics"
 	"github.com/authelia/authelia/v4/internal/middlewares"
 	"github.com/authelia/authelia/v4/internal/notification"
@@ -38,16 +41,29 @@ func getProviders() (providers middlewares.Providers, warnings []error, errors [
 
 	storageProvider := getStorageProvider()
 
+	var metricsProvider metrics.Provider
+	if config.Telemetry.Metrics.Enabled {
+		metricsProvider = metrics.NewPrometheus()
+	}
+
 	var (
 		userProvider authentication.UserProvider
 		err          error
 	)
 
 	switch {
+	case len(config.AuthenticationBackend.Chain) != 0:
+		userProvider = getChainUserProvider(storageProvider, autheliaCertPool, metricsProvider)
 	case config.AuthenticationBackend.File != nil:
 		userProvider = authentication.NewFileUserProvider(config.AuthenticationBackend.File)
 	case config.AuthenticationBackend.LDAP != nil:
-		userProvider = authentication.NewLDAPUserProvider(config.AuthenticationBackend, autheliaCertPool)
+		userProvider = authentication.NewLDAPUserProvider(config.AuthenticationBackend, autheliaCertPool, metricsProvider)
+	case config.AuthenticationBackend.SQL != nil:
+		userProvider = authentication.NewSQLUserProvider(config.AuthenticationBackend.SQL, storageProvider)
+	}
+
+	if config.AuthenticationBackend.Cache.Enable {
+		userProvider = authentication.NewCachingUserProvider(config.AuthenticationBackend.Cache, userProvider, metricsProvider)
 	}
 
 	templatesProvider, err := templates.New(templates.Config{EmailTemplatesPath: config.Notifier.TemplatePath})
@@ -66,8 +82,13 @@ func getProviders() (providers middlewares.Providers, warnings []error, errors [
 
 	ntpProvider := ntp.NewProvider(&config.NTP)
 
+	var geoipProvider geoip.Provider
+	if config.GeoIP.Path != "" || config.GeoIP.ASNPath != "" {
+		geoipProvider = geoip.NewMaxMindProvider(&config.GeoIP)
+	}
+
 	clock := utils.RealClock{}
-	authorizer := authorization.NewAuthorizer(config)
+	authorizer := authorization.NewAuthorizer(config, clock, geoipProvider)
 	sessionProvider := session.NewProvider(config.Session, autheliaCertPool)
 	regulator := regulation.NewRegulator(config.Regulation, storageProvider, clock)
 
@@ -80,9 +101,10 @@ func getProviders() (providers middlewares.Providers, warnings []error, errors [
 
 	ppolicyProvider := middlewares.NewPasswordPolicyProvider(config.PasswordPolicy)
 
-	var metricsProvider metrics.Provider
-	if config.Telemetry.Metrics.Enabled {
-		metricsProvider = metrics.NewPrometheus()
+	if config.PasswordPolicy.Breached.Enabled {
+		if ppolicyProvider, err = middlewares.NewBreachedPasswordPolicyProvider(config.PasswordPolicy.Breached, ppolicyProvider); err != nil {
+			errors = append(errors, err)
+		}
 	}
 
 	return middlewares.Providers{
@@ -93,10 +115,36 @@ func getProviders() (providers middlewares.Providers, warnings []error, errors [
 		StorageProvider: storageProvider,
 		Metrics:         metricsProvider,
 		NTP:             ntpProvider,
+		GeoIP:           geoipProvider,
 		Notifier:        notifier,
 		SessionProvider: sessionProvider,
 		Templates:       templatesProvider,
 		TOTP:            totpProvider,
 		PasswordPolicy:  ppolicyProvider,
+		PasswordHistory: authentication.NewPasswordHistory(config.PasswordPolicy, config.AuthenticationBackend, storageProvider),
+		AuthorizerStore: middlewares.NewAuthorizerStore(authorizer),
 	}, warnings, errors
 }
+
+func getChainUserProvider(storageProvider storage.Provider, certPool *x509.CertPool, recorder authentication.LDAPPoolMetricsRecorder) (provider *authentication.ChainUserProvider) {
+	backends := make([]authentication.ChainBackend, len(config.AuthenticationBackend.Chain))
+
+	for i, backend := range config.AuthenticationBackend.Chain {
+		backends[i] = authentication.ChainBackend{
+			Name:   backend.Backend,
+			Prefix: backend.Prefix,
+			Realm:  backend.Realm,
+		}
+
+		switch backend.Backend {
+		case "file":
+			backends[i].Provider = authentication.NewFileUserProvider(config.AuthenticationBackend.File)
+		case "ldap":
+			backends[i].Provider = authentication.NewLDAPUserProvider(config.AuthenticationBackend, certPool, recorder)
+		case "sql":
+			backends[i].Provider = authentication.NewSQLUserProvider(config.AuthenticationBackend.SQL, storageProvider)
+		}
+	}
+
+	return authentication.NewChainUserProvider(backends...)
+}
@@ -0,0 +1,210 @@
+package commands
+
+import (
+	"bufio"
+	"crypto/sha1" //nolint:gosec // The HIBP dumps are SHA-1 hashes.
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/authelia/authelia/v4/internal/middlewares"
+)
+
+func newPasswordPolicyCmd() (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "password-policy",
+		Short:   cmdAutheliaPasswordPolicyShort,
+		Long:    cmdAutheliaPasswordPolicyLong,
+		Example: cmdAutheliaPasswordPolicyExample,
+		Args:    cobra.NoArgs,
+
+		DisableAutoGenTag: true,
+	}
+
+	cmd.AddCommand(
+		newPasswordPolicyBuildBreachedFilterCmd(),
+	)
+
+	return cmd
+}
+
+func newPasswordPolicyBuildBreachedFilterCmd() (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "build-breached-filter <dump> <filter>",
+		Short:   cmdAutheliaPasswordPolicyBuildBreachedFilterShort,
+		Long:    cmdAutheliaPasswordPolicyBuildBreachedFilterLong,
+		Example: cmdAutheliaPasswordPolicyBuildBreachedFilterExample,
+		Args:    cobra.ExactArgs(2),
+		RunE:    passwordPolicyBuildBreachedFilterRunE,
+
+		DisableAutoGenTag: true,
+	}
+
+	cmd.Flags().Float64("false-positive-rate", 0.001, "the probability a password which isn't in the dump is reported as breached")
+	cmd.Flags().Int("min-occurrences", 1, "the minimum number of times a password must appear in the dump to be included in the filter")
+
+	return cmd
+}
+
+func passwordPolicyBuildBreachedFilterRunE(cmd *cobra.Command, args []string) (err error) {
+	var (
+		rate  float64
+		min   int
+		count uint64
+	)
+
+	if rate, err = cmd.Flags().GetFloat64("false-positive-rate"); err != nil {
+		return err
+	}
+
+	if rate <= 0 || rate >= 1 {
+		return fmt.Errorf("the false positive rate must be greater than 0 and less than 1 but it's %g", rate)
+	}
+
+	if min, err = cmd.Flags().GetInt("min-occurrences"); err != nil {
+		return err
+	}
+
+	if count, err = readBreachedPasswordDump(args[0], min, nil); err != nil {
+		return err
+	}
+
+	filter := middlewares.NewBreachedPasswordFilter(count, rate)
+
+	if _, err = readBreachedPasswordDump(args[0], min, filter.Add); err != nil {
+		return err
+	}
+
+	var file *os.File
+
+	if file, err = os.Create(args[1]); err != nil {
+		return fmt.Errorf("error creating the filter file: %w", err)
+	}
+
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+
+	if _, err = filter.WriteTo(writer); err != nil {
+		return fmt.Errorf("error writing the filter file: %w", err)
+	}
+
+	if err = writer.Flush(); err != nil {
+		return fmt.Errorf("error writing the filter file: %w", err)
+	}
+
+	if err = file.Close(); err != nil {
+		return fmt.Errorf("error writing the filter file: %w", err)
+	}
+
+	fmt.Printf("Successfully built the breached password filter '%s' with %d hashes\n", args[1], filter.Count())
+
+	return nil
+}
+
+// readBreachedPasswordDump reads the hashes from a HIBP SHA-1 dump which appear at least the minimum number of times and
+// passes them to the add func if it's not nil, returning the number of hashes read. The dump is either a single file of
+// full hashes or a directory of range files named after the prefix of the hashes they contain.
+func readBreachedPasswordDump(path string, min int, add func(digest [sha1.Size]byte)) (count uint64, err error) {
+	var info os.FileInfo
+
+	if info, err = os.Stat(path); err != nil {
+		return 0, fmt.Errorf("error reading the dump: %w", err)
+	}
+
+	if !info.IsDir() {
+		return readBreachedPasswordDumpFile(path, "", min, add)
+	}
+
+	var entries []os.DirEntry
+
+	if entries, err = os.ReadDir(path); err != nil {
+		return 0, fmt.Errorf("error reading the dump: %w", err)
+	}
+
+	var n uint64
+
+	for _, entry := range entries {
+		prefix := strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name()))
+
+		if entry.IsDir() || len(prefix) != 5 {
+			continue
+		}
+
+		if _, err = hex.DecodeString(prefix + "0"); err != nil {
+			continue
+		}
+
+		if n, err = readBreachedPasswordDumpFile(filepath.Join(path, entry.Name()), prefix, min, add); err != nil {
+			return 0, err
+		}
+
+		count += n
+	}
+
+	return count, nil
+}
+
+func readBreachedPasswordDumpFile(path, prefix string, min int, add func(digest [sha1.Size]byte)) (count uint64, err error) {
+	var file *os.File
+
+	if file, err = os.Open(path); err != nil {
+		return 0, fmt.Errorf("error reading the dump: %w", err)
+	}
+
+	defer file.Close()
+
+	var (
+		scanner = bufio.NewScanner(file)
+		digest  [sha1.Size]byte
+		line    int
+	)
+
+	for scanner.Scan() {
+		line++
+
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		hash, occurrences, found := strings.Cut(text, ":")
+
+		if hash = prefix + hash; len(hash) != hex.EncodedLen(sha1.Size) {
+			return 0, fmt.Errorf("error parsing line %d of the dump file '%s': the line doesn't contain a SHA-1 hash", line, path)
+		}
+
+		if _, err = hex.Decode(digest[:], []byte(hash)); err != nil {
+			return 0, fmt.Errorf("error parsing line %d of the dump file '%s': %w", line, path, err)
+		}
+
+		if found && min > 1 {
+			var n int
+
+			if n, err = strconv.Atoi(occurrences); err != nil {
+				return 0, fmt.Errorf("error parsing line %d of the dump file '%s': %w", line, path, err)
+			}
+
+			if n < min {
+				continue
+			}
+		}
+
+		count++
+
+		if add != nil {
+			add(digest)
+		}
+	}
+
+	if err = scanner.Err(); err != nil {
+		return 0, fmt.Errorf("error reading the dump file '%s': %w", path, err)
+	}
+
+	return count, nil
+}
@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/middlewares"
+)
+
+func TestPasswordPolicyBuildBreachedFilter(t *testing.T) {
+	dir := t.TempDir()
+
+	dump := filepath.Join(dir, "pwned-passwords-sha1-ordered-by-hash.txt")
+
+	require.NoError(t, os.WriteFile(dump, []byte("5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8:9545824\r\n"+
+		"7C4A8D09CA3762AF61E59520943DC26494F8941B:37359195\r\n"+
+		"B7A875FC1EA228B9061041B7CEC4BD3C52AB3CE3:531190\r\n"+
+		"F68E475B6C0482804CA74DF2E897DD13877B01B2:2\r\n"), 0600))
+
+	ranges := filepath.Join(dir, "ranges")
+
+	require.NoError(t, os.Mkdir(ranges, 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(ranges, "5BAA6.txt"), []byte("1E4C9B93F3F0682250B6CF8331B7EE68FD8:9545824\r\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(ranges, "7C4A8.txt"), []byte("D09CA3762AF61E59520943DC26494F8941B:37359195\r\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(ranges, "B7A87.txt"), []byte("5FC1EA228B9061041B7CEC4BD3C52AB3CE3:531190\r\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(ranges, "F68E4.txt"), []byte("75B6C0482804CA74DF2E897DD13877B01B2:2\r\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(ranges, "README.md"), []byte("Not a range file."), 0600))
+
+	testCases := []struct {
+		desc, dump    string
+		args          []string
+		breached, not []string
+	}{
+		{"ShouldBuildFromFile", dump, nil, []string{"password", "123456", "letmein", "rarely-breached"}, []string{"correct horse battery staple"}},
+		{"ShouldBuildFromRanges", ranges, nil, []string{"password", "123456", "letmein", "rarely-breached"}, []string{"correct horse battery staple"}},
+		{"ShouldBuildWithMinOccurrences", dump, []string{"--min-occurrences", "10"}, []string{"password", "123456", "letmein"}, []string{"rarely-breached"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			filter := filepath.Join(t.TempDir(), "breached.bloom")
+
+			cmd := newPasswordPolicyBuildBreachedFilterCmd()
+			cmd.SetArgs(append([]string{tc.dump, filter, "--false-positive-rate", "0.00001"}, tc.args...))
+
+			require.NoError(t, cmd.Execute())
+
+			provider, err := middlewares.NewBreachedPasswordPolicyProvider(schema.PasswordPolicyBreachedParams{Enabled: true, Path: filter}, &middlewares.StandardPasswordPolicyProvider{})
+			require.NoError(t, err)
+
+			for _, password := range tc.breached {
+				assert.ErrorIs(t, provider.Check(password), middlewares.ErrPasswordPolicyBreached, password)
+			}
+
+			for _, password := range tc.not {
+				assert.NoError(t, provider.Check(password), password)
+			}
+		})
+	}
+
+	cmd := newPasswordPolicyBuildBreachedFilterCmd()
+	cmd.SetArgs([]string{dump, filepath.Join(dir, "breached.bloom"), "--false-positive-rate", "1"})
+
+	assert.EqualError(t, cmd.Execute(), "the false positive rate must be greater than 0 and less than 1 but it's 1")
+}
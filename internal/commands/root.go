@@ -14,7 +14,12 @@ import (
 	"github.com/valyala/fasthttp"
 	"golang.org/x/sync/errgroup"
 
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/authorization"
+	"github.com/authelia/authelia/v4/internal/configuration"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/configuration/validator"
+	"github.com/authelia/authelia/v4/internal/geoip"
 	"github.com/authelia/authelia/v4/internal/logging"
 	"github.com/authelia/authelia/v4/internal/middlewares"
 	"github.com/authelia/authelia/v4/internal/model"
@@ -45,7 +50,9 @@ func NewRootCmd() (cmd *cobra.Command) {
 		newBuildInfoCmd(),
 		newCryptoCmd(),
 		newHashPasswordCmd(),
+		newPasswordPolicyCmd(),
 		newStorageCmd(),
+		newUsersCmd(),
 		newValidateConfigCmd(),
 		newAccessControlCommand(),
 	)
@@ -53,16 +60,21 @@ func NewRootCmd() (cmd *cobra.Command) {
 	return cmd
 }
 
-func cmdRootRun(_ *cobra.Command, _ []string) {
+func cmdRootRun(cmd *cobra.Command, _ []string) {
 	logger := logging.Logger()
 
+	configs, err := cmd.Flags().GetStringSlice("config")
+	if err != nil {
+		logger.Fatalf("Error reading flags: %v", err)
+	}
+
 	logger.Infof("Authelia %s is starting", utils.Version())
 
 	if os.Getenv("ENVIRONMENT") == "dev" {
 		logger.Info("===> Authelia is running in development mode. <===")
 	}
 
-	if err := logging.InitializeLogger(config.Log, true); err != nil {
+	if err = logging.InitializeLogger(config.Log, true); err != nil {
 		logger.Fatalf("Cannot initialize logger: %v", err)
 	}
 
@@ -83,11 +95,11 @@ func cmdRootRun(_ *cobra.Command, _ []string) {
 
 	doStartupChecks(config, &providers, logger)
 
-	runServers(config, providers, logger)
+	runServers(config, configs, providers, logger)
 }
 
 //nolint:gocyclo // Complexity is required in this function.
-func runServers(config *schema.Configuration, providers middlewares.Providers, log *logrus.Logger) {
+func runServers(config *schema.Configuration, configs []string, providers middlewares.Providers, log *logrus.Logger) {
 	ctx := context.Background()
 
 	ctx, cancel := context.WithCancel(ctx)
@@ -151,6 +163,37 @@ func runServers(config *schema.Configuration, providers middlewares.Providers, l
 		return nil
 	})
 
+	g.Go(func() (err error) {
+		if watcher, ok := providers.UserProvider.(authentication.UserWatcher); ok {
+			watcher.Watch(ctx)
+		}
+
+		return nil
+	})
+
+	g.Go(func() (err error) {
+		if providers.AuthorizerStore == nil {
+			return nil
+		}
+
+		reload := make(chan os.Signal, 1)
+
+		signal.Notify(reload, syscall.SIGHUP)
+
+		defer signal.Stop(reload)
+
+		for {
+			select {
+			case <-reload:
+				log.Infof("Reloading the access control configuration due to SIGHUP")
+
+				reloadAccessControl(config, configs, providers.AuthorizerStore, providers.GeoIP, log)
+			case <-ctx.Done():
+				return nil
+			}
+		}
+	})
+
 	select {
 	case s := <-quit:
 		switch s {
@@ -186,6 +229,48 @@ func runServers(config *schema.Configuration, providers middlewares.Providers, l
 	}
 }
 
+// reloadAccessControl loads and validates only the access_control section of the configuration files and if it's valid
+// atomically swaps the authorization.Authorizer held by the middlewares.AuthorizerStore. If the configuration is not
+// valid the errors are logged and the existing rules remain in effect.
+func reloadAccessControl(config *schema.Configuration, configs []string, store *middlewares.AuthorizerStore, provider geoip.Provider, log *logrus.Logger) (reloaded bool) {
+	val := schema.NewStructValidator()
+
+	// Only the access control section is replaced, the other sections are required by the authorization.Authorizer
+	// to determine if second factor is enabled.
+	reloadedConfig := *config
+	reloadedConfig.AccessControl = schema.AccessControlConfiguration{}
+
+	if _, err := configuration.LoadAdvanced(val, "access_control", &reloadedConfig.AccessControl,
+		configuration.NewDefaultSources(configs, configuration.DefaultEnvPrefix, configuration.DefaultEnvDelimiter)...); err != nil {
+		log.WithError(err).Errorf("Access control configuration reload rejected: error occurred loading the configuration, the existing rules remain in effect")
+
+		return false
+	}
+
+	validator.ValidateAccessControl(&reloadedConfig, val)
+	validator.ValidateRules(&reloadedConfig, val)
+
+	for _, warning := range val.Warnings() {
+		log.Warnf("Configuration: %+v", warning)
+	}
+
+	if errs := val.Errors(); len(errs) != 0 {
+		for _, err := range errs {
+			log.Errorf("Configuration: %+v", err)
+		}
+
+		log.Errorf("Access control configuration reload rejected: the configuration has errors, the existing rules remain in effect")
+
+		return false
+	}
+
+	store.Store(authorization.NewAuthorizer(&reloadedConfig, utils.RealClock{}, provider))
+
+	log.Infof("Access control configuration reloaded with %d rules", len(reloadedConfig.AccessControl.Rules))
+
+	return true
+}
+
 func doStartupChecks(config *schema.Configuration, providers *middlewares.Providers, log *logrus.Logger) {
 	var (
 		failures []string
@@ -210,6 +295,14 @@ func doStartupChecks(config *schema.Configuration, providers *middlewares.Provid
 		failures = append(failures, "notification")
 	}
 
+	if providers.GeoIP != nil {
+		if err = doStartupCheck(log, "geoip", providers.GeoIP, false); err != nil {
+			log.Errorf("Failure running the geoip provider startup check: %+v", err)
+
+			failures = append(failures, "geoip")
+		}
+	}
+
 	if !config.NTP.DisableStartupCheck && !providers.Authorizer.IsSecondFactorEnabled() {
 		log.Debug("The NTP startup check was skipped due to there being no configured 2FA access control rules")
 	} else if err = doStartupCheck(log, "ntp", providers.NTP, config.NTP.DisableStartupCheck); err != nil {
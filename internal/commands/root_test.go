@@ -0,0 +1,71 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/authorization"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/middlewares"
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+func TestReloadAccessControl(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "configuration.yml")
+
+	original := &schema.Configuration{
+		AccessControl: schema.AccessControlConfiguration{
+			DefaultPolicy: "deny",
+			Rules: []schema.ACLRule{
+				{
+					Domains: []string{"example.com"},
+					Policy:  "one_factor",
+				},
+			},
+		},
+	}
+
+	authorizer := authorization.NewAuthorizer(original, utils.RealClock{}, nil)
+	store := middlewares.NewAuthorizerStore(authorizer)
+
+	logger, hook := test.NewNullLogger()
+
+	require.NoError(t, os.WriteFile(path, []byte(`
+access_control:
+  default_policy: deny
+  rules:
+    - domain: 'example.com'
+      policy: 'two_factor'
+`), 0600))
+
+	assert.True(t, reloadAccessControl(original, []string{path}, store, nil, logger))
+	assert.NotSame(t, authorizer, store.Load())
+	assert.True(t, store.Load().IsSecondFactorEnabled())
+	assert.Equal(t, "one_factor", original.AccessControl.Rules[0].Policy)
+
+	reloaded := store.Load()
+
+	require.NoError(t, os.WriteFile(path, []byte(`
+access_control:
+  default_policy: deny
+  rules:
+    - domain: 'example.com'
+      policy: 'invalid'
+`), 0600))
+
+	hook.Reset()
+
+	assert.False(t, reloadAccessControl(original, []string{path}, store, nil, logger))
+	assert.Same(t, reloaded, store.Load())
+
+	require.NotNil(t, hook.LastEntry())
+	assert.Equal(t, logrus.ErrorLevel, hook.LastEntry().Level)
+	assert.Equal(t, "Access control configuration reload rejected: the configuration has errors, the existing rules remain in effect", hook.LastEntry().Message)
+}
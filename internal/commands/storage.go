@@ -115,6 +115,7 @@ func newStorageUserCmd() (cmd *cobra.Command) {
 	}
 
 	cmd.AddCommand(
+		newStorageUserAccountCmd(),
 		newStorageUserIdentifiersCmd(),
 		newStorageUserTOTPCmd(),
 	)
@@ -122,6 +123,95 @@ func newStorageUserCmd() (cmd *cobra.Command) {
 	return cmd
 }
 
+func newStorageUserAccountCmd() (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "account",
+		Short:   cmdAutheliaStorageUserAccountShort,
+		Long:    cmdAutheliaStorageUserAccountLong,
+		Example: cmdAutheliaStorageUserAccountExample,
+
+		DisableAutoGenTag: true,
+	}
+
+	cmd.AddCommand(
+		newStorageUserAccountAddCmd(),
+		newStorageUserAccountDeleteCmd(),
+		newStorageUserAccountPasswdCmd(),
+		newStorageUserAccountGroupsCmd(),
+	)
+
+	return cmd
+}
+
+func newStorageUserAccountAddCmd() (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "add <username>",
+		Short:   cmdAutheliaStorageUserAccountAddShort,
+		Long:    cmdAutheliaStorageUserAccountAddLong,
+		Example: cmdAutheliaStorageUserAccountAddExample,
+		Args:    cobra.ExactArgs(1),
+		RunE:    storageUserAccountAddRunE,
+
+		DisableAutoGenTag: true,
+	}
+
+	cmd.Flags().String("password", "", "the password of the user account")
+	cmd.Flags().String("display-name", "", "the display name of the user account, defaults to the username")
+	cmd.Flags().String("email", "", "the email address of the user account")
+	cmd.Flags().StringSlice("groups", nil, "the groups of the user account")
+
+	return cmd
+}
+
+func newStorageUserAccountDeleteCmd() (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "delete <username>",
+		Short:   cmdAutheliaStorageUserAccountDeleteShort,
+		Long:    cmdAutheliaStorageUserAccountDeleteLong,
+		Example: cmdAutheliaStorageUserAccountDeleteExample,
+		Args:    cobra.ExactArgs(1),
+		RunE:    storageUserAccountDeleteRunE,
+
+		DisableAutoGenTag: true,
+	}
+
+	return cmd
+}
+
+func newStorageUserAccountPasswdCmd() (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "passwd <username>",
+		Short:   cmdAutheliaStorageUserAccountPasswdShort,
+		Long:    cmdAutheliaStorageUserAccountPasswdLong,
+		Example: cmdAutheliaStorageUserAccountPasswdExample,
+		Args:    cobra.ExactArgs(1),
+		RunE:    storageUserAccountPasswdRunE,
+
+		DisableAutoGenTag: true,
+	}
+
+	cmd.Flags().String("password", "", "the new password of the user account")
+
+	return cmd
+}
+
+func newStorageUserAccountGroupsCmd() (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "groups <username>",
+		Short:   cmdAutheliaStorageUserAccountGroupsShort,
+		Long:    cmdAutheliaStorageUserAccountGroupsLong,
+		Example: cmdAutheliaStorageUserAccountGroupsExample,
+		Args:    cobra.ExactArgs(1),
+		RunE:    storageUserAccountGroupsRunE,
+
+		DisableAutoGenTag: true,
+	}
+
+	cmd.Flags().StringSlice("groups", nil, "replaces the groups of the user account with these groups")
+
+	return cmd
+}
+
 func newStorageUserIdentifiersCmd() (cmd *cobra.Command) {
 	cmd = &cobra.Command{
 		Use:     "identifiers",
@@ -17,6 +17,7 @@ import (
 	"github.com/spf13/pflag"
 	"gopkg.in/yaml.v3"
 
+	"github.com/authelia/authelia/v4/internal/authentication"
 	"github.com/authelia/authelia/v4/internal/configuration"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/configuration/validator"
@@ -905,3 +906,221 @@ func storageUserIdentifiersAdd(cmd *cobra.Command, args []string) (err error) {
 
 	return nil
 }
+
+func storageUserAccountAddRunE(cmd *cobra.Command, args []string) (err error) {
+	var (
+		provider storage.Provider
+
+		ctx = context.Background()
+
+		account = model.UserAccount{Username: args[0]}
+	)
+
+	if account.Password, err = storageUserAccountHashPassword(cmd); err != nil {
+		return err
+	}
+
+	if account.DisplayName, err = cmd.Flags().GetString("display-name"); err != nil {
+		return err
+	}
+
+	if account.DisplayName == "" {
+		account.DisplayName = account.Username
+	}
+
+	if account.Email, err = cmd.Flags().GetString("email"); err != nil {
+		return err
+	}
+
+	if account.Groups, err = cmd.Flags().GetStringSlice("groups"); err != nil {
+		return err
+	}
+
+	provider = getStorageProvider()
+
+	defer func() {
+		_ = provider.Close()
+	}()
+
+	if err = checkStorageSchemaUpToDate(ctx, provider); err != nil {
+		return err
+	}
+
+	if _, err = provider.LoadUserAccount(ctx, account.Username); err == nil {
+		return fmt.Errorf("user account for user '%s' already exists", account.Username)
+	} else if !errors.Is(err, storage.ErrNoUserAccount) {
+		return err
+	}
+
+	if err = provider.SaveUserAccount(ctx, account); err != nil {
+		return err
+	}
+
+	password, _ := cmd.Flags().GetString("password")
+
+	if err = authentication.NewPasswordHistory(config.PasswordPolicy, config.AuthenticationBackend, provider).Save(ctx, account.Username, password); err != nil {
+		return fmt.Errorf("added user account for user '%s' but can't save the password history: %w", account.Username, err)
+	}
+
+	fmt.Printf("Added user account for user '%s'.\n", account.Username)
+
+	return nil
+}
+
+func storageUserAccountDeleteRunE(_ *cobra.Command, args []string) (err error) {
+	var (
+		provider storage.Provider
+
+		ctx = context.Background()
+	)
+
+	provider = getStorageProvider()
+
+	defer func() {
+		_ = provider.Close()
+	}()
+
+	if err = checkStorageSchemaUpToDate(ctx, provider); err != nil {
+		return err
+	}
+
+	if err = provider.DeleteUserAccount(ctx, args[0]); err != nil {
+		return fmt.Errorf("can't delete user account for user '%s': %w", args[0], err)
+	}
+
+	fmt.Printf("Deleted user account for user '%s'.\n", args[0])
+
+	return nil
+}
+
+func storageUserAccountPasswdRunE(cmd *cobra.Command, args []string) (err error) {
+	var (
+		provider storage.Provider
+
+		ctx = context.Background()
+
+		hash, password string
+	)
+
+	if hash, err = storageUserAccountHashPassword(cmd); err != nil {
+		return err
+	}
+
+	if password, err = cmd.Flags().GetString("password"); err != nil {
+		return err
+	}
+
+	provider = getStorageProvider()
+
+	defer func() {
+		_ = provider.Close()
+	}()
+
+	if err = checkStorageSchemaUpToDate(ctx, provider); err != nil {
+		return err
+	}
+
+	history := authentication.NewPasswordHistory(config.PasswordPolicy, config.AuthenticationBackend, provider)
+
+	if err = history.Check(ctx, args[0], password); err != nil {
+		return fmt.Errorf("can't change the password of the user account for user '%s': %w", args[0], err)
+	}
+
+	if err = provider.UpdateUserAccountPassword(ctx, args[0], hash); err != nil {
+		return fmt.Errorf("can't change the password of the user account for user '%s': %w", args[0], err)
+	}
+
+	if err = history.Save(ctx, args[0], password); err != nil {
+		return fmt.Errorf("changed the password of the user account for user '%s' but can't save the password history: %w", args[0], err)
+	}
+
+	fmt.Printf("Changed the password of the user account for user '%s'.\n", args[0])
+
+	return nil
+}
+
+func storageUserAccountGroupsRunE(cmd *cobra.Command, args []string) (err error) {
+	var (
+		provider storage.Provider
+
+		ctx = context.Background()
+
+		account *model.UserAccount
+		groups  []string
+	)
+
+	if groups, err = cmd.Flags().GetStringSlice("groups"); err != nil {
+		return err
+	}
+
+	provider = getStorageProvider()
+
+	defer func() {
+		_ = provider.Close()
+	}()
+
+	if err = checkStorageSchemaUpToDate(ctx, provider); err != nil {
+		return err
+	}
+
+	if cmd.Flags().Changed("groups") {
+		if err = provider.UpdateUserAccountGroups(ctx, args[0], groups); err != nil {
+			return fmt.Errorf("can't replace the groups of the user account for user '%s': %w", args[0], err)
+		}
+
+		fmt.Printf("Replaced the groups of the user account for user '%s' with: %s\n", args[0], strings.Join(groups, ", "))
+
+		return nil
+	}
+
+	if account, err = provider.LoadUserAccount(ctx, args[0]); err != nil {
+		return fmt.Errorf("can't load the groups of the user account for user '%s': %w", args[0], err)
+	}
+
+	fmt.Printf("Groups of the user account for user '%s': %s\n", args[0], strings.Join(account.Groups, ", "))
+
+	return nil
+}
+
+// storageUserAccountHashPassword hashes the password flag with the SQL authentication backend password configuration
+// if it's configured, otherwise with the default password configuration.
+func storageUserAccountHashPassword(cmd *cobra.Command) (hash string, err error) {
+	var password string
+
+	if password, err = cmd.Flags().GetString("password"); err != nil {
+		return "", err
+	}
+
+	if password == "" {
+		return "", errors.New("the password must be specified using the --password flag")
+	}
+
+	passwordConfig := schema.DefaultPasswordConfiguration
+
+	if config.AuthenticationBackend.SQL != nil && config.AuthenticationBackend.SQL.Password != nil {
+		passwordConfig = *config.AuthenticationBackend.SQL.Password
+
+		val := schema.NewStructValidator()
+
+		validator.ValidatePasswordConfiguration(&passwordConfig, val)
+
+		if val.HasErrors() {
+			for i, e := range val.Errors() {
+				if i == 0 {
+					err = e
+					continue
+				}
+
+				err = fmt.Errorf("%v, %w", err, e)
+			}
+
+			return "", fmt.Errorf("errors occurred validating the password configuration: %w", err)
+		}
+	}
+
+	if hash, err = authentication.HashPasswordWithConfig(password, &passwordConfig); err != nil {
+		return "", fmt.Errorf("error during password hashing: %w", err)
+	}
+
+	return hash, nil
+}
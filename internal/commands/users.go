@@ -0,0 +1,555 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/configuration"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/configuration/validator"
+	"github.com/authelia/authelia/v4/internal/middlewares"
+	"github.com/authelia/authelia/v4/internal/storage"
+)
+
+func newUsersCmd() (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:               "users",
+		Short:             cmdAutheliaUsersShort,
+		Long:              cmdAutheliaUsersLong,
+		Example:           cmdAutheliaUsersExample,
+		Args:              cobra.NoArgs,
+		PersistentPreRunE: usersPersistentPreRunE,
+
+		DisableAutoGenTag: true,
+	}
+
+	cmdWithConfigFlags(cmd, true, []string{"configuration.yml"})
+
+	cmd.PersistentFlags().String("path", "", "the users database file path, defaults to the authentication_backend.file.path configuration")
+
+	cmd.AddCommand(
+		newUsersListCmd(),
+		newUsersAddCmd(),
+		newUsersDeleteCmd(),
+		newUsersSetPasswordCmd(),
+		newUsersSetGroupsCmd(),
+		newUsersDisableCmd(),
+	)
+
+	return cmd
+}
+
+func newUsersListCmd() (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "list",
+		Short:   cmdAutheliaUsersListShort,
+		Long:    cmdAutheliaUsersListLong,
+		Example: cmdAutheliaUsersListExample,
+		Args:    cobra.NoArgs,
+		RunE:    usersListRunE,
+
+		DisableAutoGenTag: true,
+	}
+
+	return cmd
+}
+
+func newUsersAddCmd() (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "add <username>",
+		Short:   cmdAutheliaUsersAddShort,
+		Long:    cmdAutheliaUsersAddLong,
+		Example: cmdAutheliaUsersAddExample,
+		Args:    cobra.ExactArgs(1),
+		RunE:    usersAddRunE,
+
+		DisableAutoGenTag: true,
+	}
+
+	cmd.Flags().String("password", "", "the password of the user")
+	cmd.Flags().String("display-name", "", "the display name of the user, defaults to the username")
+	cmd.Flags().String("email", "", "the email address of the user")
+	cmd.Flags().StringSlice("groups", nil, "the groups of the user")
+
+	return cmd
+}
+
+func newUsersDeleteCmd() (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "delete <username>",
+		Short:   cmdAutheliaUsersDeleteShort,
+		Long:    cmdAutheliaUsersDeleteLong,
+		Example: cmdAutheliaUsersDeleteExample,
+		Args:    cobra.ExactArgs(1),
+		RunE:    usersDeleteRunE,
+
+		DisableAutoGenTag: true,
+	}
+
+	return cmd
+}
+
+func newUsersSetPasswordCmd() (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "set-password <username>",
+		Short:   cmdAutheliaUsersSetPasswordShort,
+		Long:    cmdAutheliaUsersSetPasswordLong,
+		Example: cmdAutheliaUsersSetPasswordExample,
+		Args:    cobra.ExactArgs(1),
+		RunE:    usersSetPasswordRunE,
+
+		DisableAutoGenTag: true,
+	}
+
+	cmd.Flags().String("password", "", "the new password of the user")
+
+	return cmd
+}
+
+func newUsersSetGroupsCmd() (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "set-groups <username>",
+		Short:   cmdAutheliaUsersSetGroupsShort,
+		Long:    cmdAutheliaUsersSetGroupsLong,
+		Example: cmdAutheliaUsersSetGroupsExample,
+		Args:    cobra.ExactArgs(1),
+		RunE:    usersSetGroupsRunE,
+
+		DisableAutoGenTag: true,
+	}
+
+	cmd.Flags().StringSlice("groups", nil, "replaces the groups of the user with these groups")
+
+	return cmd
+}
+
+func newUsersDisableCmd() (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "disable <username>",
+		Short:   cmdAutheliaUsersDisableShort,
+		Long:    cmdAutheliaUsersDisableLong,
+		Example: cmdAutheliaUsersDisableExample,
+		Args:    cobra.ExactArgs(1),
+		RunE:    usersDisableRunE,
+
+		DisableAutoGenTag: true,
+	}
+
+	cmd.Flags().Bool("enable", false, "enables the user again instead of disabling it")
+
+	return cmd
+}
+
+func usersPersistentPreRunE(cmd *cobra.Command, _ []string) (err error) {
+	var configs []string
+
+	if configs, err = cmd.Flags().GetStringSlice("config"); err != nil {
+		return err
+	}
+
+	sources := make([]configuration.Source, 0, len(configs)+3)
+
+	if cmd.Flags().Changed("config") {
+		for _, configFile := range configs {
+			if _, err := os.Stat(configFile); os.IsNotExist(err) {
+				return fmt.Errorf("could not load the provided configuration file %s: %w", configFile, err)
+			}
+
+			sources = append(sources, configuration.NewYAMLFileSource(configFile))
+		}
+	} else if _, err := os.Stat(configs[0]); err == nil {
+		sources = append(sources, configuration.NewYAMLFileSource(configs[0]))
+	}
+
+	mapping := map[string]string{
+		"path": "authentication_backend.file.path",
+	}
+
+	sources = append(sources, configuration.NewEnvironmentSource(configuration.DefaultEnvPrefix, configuration.DefaultEnvDelimiter))
+	sources = append(sources, configuration.NewSecretsSource(configuration.DefaultEnvPrefix, configuration.DefaultEnvDelimiter))
+	sources = append(sources, configuration.NewCommandLineSourceWithMapping(cmd.Flags(), mapping, true, false))
+
+	val := schema.NewStructValidator()
+
+	config = &schema.Configuration{}
+
+	if _, err = configuration.LoadAdvanced(val, "", &config, sources...); err != nil {
+		return err
+	}
+
+	if config.AuthenticationBackend.File == nil || config.AuthenticationBackend.File.Path == "" {
+		return errors.New("the users database file path must be specified using the --path flag or the authentication_backend.file.path configuration")
+	}
+
+	if config.AuthenticationBackend.File.Password == nil {
+		passwordConfig := schema.DefaultPasswordConfiguration
+
+		config.AuthenticationBackend.File.Password = &passwordConfig
+	}
+
+	validator.ValidatePasswordConfiguration(config.AuthenticationBackend.File.Password, val)
+
+	validator.ValidatePasswordPolicy(&config.PasswordPolicy, val)
+
+	if val.HasErrors() {
+		var finalErr error
+
+		for i, err := range val.Errors() {
+			if i == 0 {
+				finalErr = err
+				continue
+			}
+
+			finalErr = fmt.Errorf("%w, %v", finalErr, err)
+		}
+
+		return finalErr
+	}
+
+	return nil
+}
+
+func usersListRunE(_ *cobra.Command, _ []string) (err error) {
+	var database *authentication.DatabaseModel
+
+	if database, err = authentication.ReadFileUserDatabase(config.AuthenticationBackend.File.Path); err != nil {
+		return err
+	}
+
+	usernames := make([]string, 0, len(database.Users))
+
+	for username := range database.Users {
+		usernames = append(usernames, username)
+	}
+
+	sort.Strings(usernames)
+
+	fmt.Printf("Users in the users database '%s':\n\n", config.AuthenticationBackend.File.Path)
+
+	for _, username := range usernames {
+		details := database.Users[username]
+
+		fmt.Printf("\t%s\n\t\tDisplay Name: %s\n\t\tEmail: %s\n\t\tGroups: %s\n", username, details.DisplayName, details.Email, strings.Join(details.Groups, ", "))
+
+		if details.Disabled {
+			fmt.Printf("\t\tDisabled: true\n")
+		}
+	}
+
+	return nil
+}
+
+func usersAddRunE(cmd *cobra.Command, args []string) (err error) {
+	var (
+		database *authentication.DatabaseModel
+		history  *authentication.PasswordHistory
+		closer   func()
+
+		ctx = context.Background()
+
+		details  = authentication.UserDetailsModel{}
+		password string
+	)
+
+	if details.HashedPassword, err = usersHashPassword(cmd); err != nil {
+		return err
+	}
+
+	if password, err = cmd.Flags().GetString("password"); err != nil {
+		return err
+	}
+
+	if details.DisplayName, err = cmd.Flags().GetString("display-name"); err != nil {
+		return err
+	}
+
+	if details.DisplayName == "" {
+		details.DisplayName = args[0]
+	}
+
+	if details.Email, err = cmd.Flags().GetString("email"); err != nil {
+		return err
+	}
+
+	if details.Groups, err = cmd.Flags().GetStringSlice("groups"); err != nil {
+		return err
+	}
+
+	if history, closer, err = usersPasswordHistory(ctx); err != nil {
+		return err
+	}
+
+	defer closer()
+
+	if database, err = authentication.ReadFileUserDatabase(config.AuthenticationBackend.File.Path); err != nil {
+		return err
+	}
+
+	if _, ok := database.Users[args[0]]; ok {
+		return fmt.Errorf("user '%s' already exists", args[0])
+	}
+
+	if database.Users == nil {
+		database.Users = map[string]authentication.UserDetailsModel{}
+	}
+
+	database.Users[args[0]] = details
+
+	if err = authentication.WriteFileUserDatabase(config.AuthenticationBackend.File.Path, database); err != nil {
+		return fmt.Errorf("can't add user '%s': %w", args[0], err)
+	}
+
+	if err = history.Save(ctx, args[0], password); err != nil {
+		return fmt.Errorf("added user '%s' but can't save the password history: %w", args[0], err)
+	}
+
+	fmt.Printf("Added user '%s'.\n", args[0])
+
+	return nil
+}
+
+func usersDeleteRunE(_ *cobra.Command, args []string) (err error) {
+	err = usersUpdate(args[0], func(database *authentication.DatabaseModel, _ authentication.UserDetailsModel) (err error) {
+		delete(database.Users, args[0])
+
+		return nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("can't delete user '%s': %w", args[0], err)
+	}
+
+	fmt.Printf("Deleted user '%s'.\n", args[0])
+
+	return nil
+}
+
+func usersSetPasswordRunE(cmd *cobra.Command, args []string) (err error) {
+	var (
+		history *authentication.PasswordHistory
+		closer  func()
+
+		ctx = context.Background()
+
+		hash, password string
+	)
+
+	if hash, err = usersHashPassword(cmd); err != nil {
+		return err
+	}
+
+	if password, err = cmd.Flags().GetString("password"); err != nil {
+		return err
+	}
+
+	if history, closer, err = usersPasswordHistory(ctx); err != nil {
+		return err
+	}
+
+	defer closer()
+
+	if err = history.Check(ctx, args[0], password); err != nil {
+		return fmt.Errorf("can't change the password of user '%s': %w", args[0], err)
+	}
+
+	err = usersUpdate(args[0], func(database *authentication.DatabaseModel, details authentication.UserDetailsModel) (err error) {
+		// The current password only becomes part of the history once it's replaced so it's checked separately.
+		if history.Enabled() {
+			var valid bool
+
+			if valid, err = authentication.CheckPassword(password, details.HashedPassword); err != nil {
+				return err
+			}
+
+			if valid {
+				return authentication.ErrPasswordReused
+			}
+		}
+
+		details.HashedPassword = hash
+
+		database.Users[args[0]] = details
+
+		return nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("can't change the password of user '%s': %w", args[0], err)
+	}
+
+	if err = history.Save(ctx, args[0], password); err != nil {
+		return fmt.Errorf("changed the password of user '%s' but can't save the password history: %w", args[0], err)
+	}
+
+	fmt.Printf("Changed the password of user '%s'.\n", args[0])
+
+	return nil
+}
+
+func usersSetGroupsRunE(cmd *cobra.Command, args []string) (err error) {
+	var groups []string
+
+	if groups, err = cmd.Flags().GetStringSlice("groups"); err != nil {
+		return err
+	}
+
+	if !cmd.Flags().Changed("groups") {
+		return errors.New("the groups must be specified using the --groups flag")
+	}
+
+	err = usersUpdate(args[0], func(database *authentication.DatabaseModel, details authentication.UserDetailsModel) (err error) {
+		details.Groups = groups
+
+		database.Users[args[0]] = details
+
+		return nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("can't replace the groups of user '%s': %w", args[0], err)
+	}
+
+	fmt.Printf("Replaced the groups of user '%s' with: %s\n", args[0], strings.Join(groups, ", "))
+
+	return nil
+}
+
+func usersDisableRunE(cmd *cobra.Command, args []string) (err error) {
+	var enable bool
+
+	if enable, err = cmd.Flags().GetBool("enable"); err != nil {
+		return err
+	}
+
+	err = usersUpdate(args[0], func(database *authentication.DatabaseModel, details authentication.UserDetailsModel) (err error) {
+		details.Disabled = !enable
+
+		database.Users[args[0]] = details
+
+		return nil
+	})
+
+	switch {
+	case err != nil && enable:
+		return fmt.Errorf("can't enable user '%s': %w", args[0], err)
+	case err != nil:
+		return fmt.Errorf("can't disable user '%s': %w", args[0], err)
+	case enable:
+		fmt.Printf("Enabled user '%s'.\n", args[0])
+	default:
+		fmt.Printf("Disabled user '%s'.\n", args[0])
+	}
+
+	return nil
+}
+
+// usersUpdate reads the users database, applies the update to the existing user, and writes the users database unless
+// the update returns an error.
+func usersUpdate(username string, update func(database *authentication.DatabaseModel, details authentication.UserDetailsModel) (err error)) (err error) {
+	var database *authentication.DatabaseModel
+
+	if database, err = authentication.ReadFileUserDatabase(config.AuthenticationBackend.File.Path); err != nil {
+		return err
+	}
+
+	details, ok := database.Users[username]
+	if !ok {
+		return authentication.ErrUserNotFound
+	}
+
+	if err = update(database, details); err != nil {
+		return err
+	}
+
+	return authentication.WriteFileUserDatabase(config.AuthenticationBackend.File.Path, database)
+}
+
+// usersPasswordHistory returns the password history of the password policy and a function which closes the storage
+// provider it uses. The password history is nil if it's disabled.
+func usersPasswordHistory(ctx context.Context) (history *authentication.PasswordHistory, closer func(), err error) {
+	if config.PasswordPolicy.History <= 0 {
+		return nil, func() {}, nil
+	}
+
+	var provider storage.Provider
+
+	if provider, err = usersStorageProvider(ctx); err != nil {
+		return nil, nil, err
+	}
+
+	return authentication.NewPasswordHistory(config.PasswordPolicy, config.AuthenticationBackend, provider), func() {
+		_ = provider.Close()
+	}, nil
+}
+
+// usersStorageProvider returns the storage provider used to enforce the password history of the password policy.
+func usersStorageProvider(ctx context.Context) (provider storage.Provider, err error) {
+	val := schema.NewStructValidator()
+
+	validator.ValidateStorage(config.Storage, val)
+
+	if val.HasErrors() {
+		for i, e := range val.Errors() {
+			if i == 0 {
+				err = e
+				continue
+			}
+
+			err = fmt.Errorf("%w, %v", err, e)
+		}
+
+		return nil, fmt.Errorf("the storage configuration is required to enforce the password history: %w", err)
+	}
+
+	provider = getStorageProvider()
+
+	if err = checkStorageSchemaUpToDate(ctx, provider); err != nil {
+		_ = provider.Close()
+
+		return nil, err
+	}
+
+	return provider, nil
+}
+
+// usersHashPassword checks the password flag against the password policy and hashes it with the file authentication
+// backend password configuration.
+func usersHashPassword(cmd *cobra.Command) (hash string, err error) {
+	var (
+		password string
+		policy   middlewares.PasswordPolicyProvider
+	)
+
+	if password, err = cmd.Flags().GetString("password"); err != nil {
+		return "", err
+	}
+
+	if password == "" {
+		return "", errors.New("the password must be specified using the --password flag")
+	}
+
+	policy = middlewares.NewPasswordPolicyProvider(config.PasswordPolicy)
+
+	if config.PasswordPolicy.Breached.Enabled {
+		if policy, err = middlewares.NewBreachedPasswordPolicyProvider(config.PasswordPolicy.Breached, policy); err != nil {
+			return "", err
+		}
+	}
+
+	if err = policy.Check(password); err != nil {
+		return "", fmt.Errorf("the password doesn't comply with the password policy: %w", err)
+	}
+
+	if hash, err = authentication.HashPasswordWithConfig(password, config.AuthenticationBackend.File.Password); err != nil {
+		return "", fmt.Errorf("error during password hashing: %w", err)
+	}
+
+	return hash, nil
+}
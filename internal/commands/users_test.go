@@ -0,0 +1,223 @@
+package commands
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/storage"
+)
+
+func TestUsersCommands(t *testing.T) {
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "users_database.yml")
+	configPath := filepath.Join(dir, "configuration.yml")
+
+	require.NoError(t, os.WriteFile(path, []byte(`
+users:
+  john:
+    displayname: "John Doe"
+    password: "$2y$04$abcdefghijklmnopqrstuughE8Ev8uGFaUgY2cNEySvxngrb/Jzdm"
+    email: john.doe@authelia.com
+    groups:
+      - admins
+`), 0600))
+
+	require.NoError(t, os.WriteFile(configPath, []byte(`
+authentication_backend:
+  file:
+    path: `+path+`
+    password:
+      algorithm: sha512
+      iterations: 1000
+      salt_length: 16
+`), 0600))
+
+	run := func(args ...string) error {
+		cmd := newUsersCmd()
+		cmd.SetArgs(append(args, "--config", configPath))
+
+		return cmd.Execute()
+	}
+
+	read := func() *authentication.DatabaseModel {
+		database, err := authentication.ReadFileUserDatabase(path)
+		require.NoError(t, err)
+
+		return database
+	}
+
+	require.NoError(t, run("list"))
+
+	require.NoError(t, run("add", "harry", "--password", "p@ssw0rd", "--email", "harry@authelia.com", "--groups", "dev,users"))
+
+	harry := read().Users["harry"]
+	assert.Equal(t, "harry", harry.DisplayName)
+	assert.Equal(t, "harry@authelia.com", harry.Email)
+	assert.Equal(t, []string{"dev", "users"}, harry.Groups)
+	assert.True(t, strings.HasPrefix(harry.HashedPassword, "$6$rounds=1000$"))
+
+	ok, err := authentication.CheckPassword("p@ssw0rd", harry.HashedPassword)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	assert.EqualError(t, run("add", "harry", "--password", "p@ssw0rd"), "user 'harry' already exists")
+	assert.EqualError(t, run("add", "bob"), "the password must be specified using the --password flag")
+
+	require.NoError(t, run("set-password", "john", "--password", "n3wp@ssw0rd"))
+
+	ok, err = authentication.CheckPassword("n3wp@ssw0rd", read().Users["john"].HashedPassword)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	require.NoError(t, run("set-groups", "john", "--groups", "dev"))
+	assert.Equal(t, []string{"dev"}, read().Users["john"].Groups)
+
+	assert.EqualError(t, run("set-groups", "john"), "the groups must be specified using the --groups flag")
+
+	require.NoError(t, run("disable", "john"))
+	assert.True(t, read().Users["john"].Disabled)
+
+	require.NoError(t, run("disable", "john", "--enable"))
+	assert.False(t, read().Users["john"].Disabled)
+
+	require.NoError(t, run("delete", "harry"))
+	assert.NotContains(t, read().Users, "harry")
+
+	assert.EqualError(t, run("delete", "harry"), "can't delete user 'harry': user not found")
+	assert.EqualError(t, run("disable", "harry"), "can't disable user 'harry': user not found")
+
+	assert.Equal(t, "John Doe", read().Users["john"].DisplayName)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
+
+func TestUsersCommandsShouldUsePathFlag(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "users_database.yml")
+
+	require.NoError(t, os.WriteFile(path, []byte(`
+users:
+  john:
+    displayname: "John Doe"
+    password: "$2y$04$abcdefghijklmnopqrstuughE8Ev8uGFaUgY2cNEySvxngrb/Jzdm"
+    email: john.doe@authelia.com
+`), 0600))
+
+	cmd := newUsersCmd()
+	cmd.SetArgs([]string{"set-groups", "john", "--groups", "admins", "--path", path})
+
+	require.NoError(t, cmd.Execute())
+
+	database, err := authentication.ReadFileUserDatabase(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"admins"}, database.Users["john"].Groups)
+}
+
+func TestUsersCommandsShouldRequirePath(t *testing.T) {
+	cmd := newUsersCmd()
+	cmd.SetArgs([]string{"list"})
+
+	assert.EqualError(t, cmd.Execute(), "the users database file path must be specified using the --path flag or the authentication_backend.file.path configuration")
+}
+
+func TestUsersCommandsShouldNotGenerateDatabase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "users_database.yml")
+
+	cmd := newUsersCmd()
+	cmd.SetArgs([]string{"list", "--path", path})
+
+	assert.EqualError(t, cmd.Execute(), "Unable to read database from file "+path+": open "+path+": no such file or directory")
+
+	_, err := os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestUsersCommandsShouldEnforcePasswordPolicy(t *testing.T) {
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "users_database.yml")
+	configPath := filepath.Join(dir, "configuration.yml")
+	storagePath := filepath.Join(dir, "db.sqlite3")
+
+	require.NoError(t, os.WriteFile(path, []byte(`
+users:
+  john:
+    displayname: "John Doe"
+    password: "$2y$04$abcdefghijklmnopqrstuughE8Ev8uGFaUgY2cNEySvxngrb/Jzdm"
+    email: john.doe@authelia.com
+  bob:
+    displayname: "Bob Dylan"
+    password: "$6$abcdefghijklmnop$DWSpvbKoCGOSr.WuzPq/H/rYbJKSkE5p8.Z7IKPpAAKa0uJB.QkZYfxLR5m69KkNzM9Dw6oox6OKhsZc4vzg/0"
+    email: bob.dylan@authelia.com
+`), 0600))
+
+	require.NoError(t, os.WriteFile(configPath, []byte(`
+authentication_backend:
+  file:
+    path: `+path+`
+    password:
+      algorithm: sha512
+      iterations: 1000
+      salt_length: 16
+password_policy:
+  history: 2
+  standard:
+    enabled: true
+    min_length: 10
+storage:
+  encryption_key: a_not_so_secure_encryption_key
+  local:
+    path: `+storagePath+`
+`), 0600))
+
+	provider := storage.NewSQLiteProvider(&schema.Configuration{
+		Storage: schema.StorageConfiguration{
+			EncryptionKey: "a_not_so_secure_encryption_key",
+			Local:         &schema.LocalStorageConfiguration{Path: storagePath},
+		},
+	})
+
+	require.NoError(t, provider.SchemaMigrate(context.Background(), true, storage.SchemaLatest))
+	require.NoError(t, provider.Close())
+
+	run := func(args ...string) error {
+		cmd := newUsersCmd()
+		cmd.SetArgs(append(args, "--config", configPath))
+
+		return cmd.Execute()
+	}
+
+	assert.EqualError(t, run("set-password", "john", "--password", "p@ss"), "the password doesn't comply with the password policy: the supplied password does not met the security policy")
+	assert.EqualError(t, run("add", "harry", "--password", "p@ss"), "the password doesn't comply with the password policy: the supplied password does not met the security policy")
+
+	require.NoError(t, run("set-password", "john", "--password", "n3wp@ssw0rd"))
+	require.NoError(t, run("set-password", "john", "--password", "0th3rp@ssw0rd"))
+
+	assert.EqualError(t, run("set-password", "john", "--password", "n3wp@ssw0rd"), "can't change the password of user 'john': password was used previously")
+
+	// The current password of a user is rejected even if it wasn't set with the commands.
+	assert.EqualError(t, run("set-password", "bob", "--password", "c0rr3ctp@ssw0rd"), "can't change the password of user 'bob': password was used previously")
+
+	// The password of an added user is saved to the password history.
+	require.NoError(t, run("add", "harry", "--password", "h@rryp@ssw0rd"))
+	require.NoError(t, run("set-password", "harry", "--password", "n3wh@rryp@ssw0rd"))
+
+	assert.EqualError(t, run("set-password", "harry", "--password", "h@rryp@ssw0rd"), "can't change the password of user 'harry': password was used previously")
+
+	database, err := authentication.ReadFileUserDatabase(path)
+	require.NoError(t, err)
+
+	ok, err := authentication.CheckPassword("0th3rp@ssw0rd", database.Users["john"].HashedPassword)
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
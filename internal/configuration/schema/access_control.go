@@ -1,16 +1,27 @@
 package schema
 
 import (
+	"net/url"
 	"regexp"
+	"time"
 )
 
 // AccessControlConfiguration represents the configuration related to ACLs.
 type AccessControlConfiguration struct {
 	DefaultPolicy string       `koanf:"default_policy"`
 	Networks      []ACLNetwork `koanf:"networks"`
+	External      ACLExternal  `koanf:"external"`
 	Rules         []ACLRule    `koanf:"rules"`
 }
 
+// ACLExternal represents the external policy decision point which is queried by rules with the external policy.
+type ACLExternal struct {
+	URL           *url.URL      `koanf:"url"`
+	Timeout       time.Duration `koanf:"timeout"`
+	FailurePolicy string        `koanf:"failure_policy"`
+	CacheDuration time.Duration `koanf:"cache_duration"`
+}
+
 // ACLNetwork represents one ACL network group entry.
 type ACLNetwork struct {
 	Name     string   `koanf:"name"`
@@ -19,13 +30,50 @@ type ACLNetwork struct {
 
 // ACLRule represents one ACL rule entry.
 type ACLRule struct {
-	Domains      []string        `koanf:"domain"`
-	DomainsRegex []regexp.Regexp `koanf:"domain_regex"`
-	Policy       string          `koanf:"policy"`
-	Subjects     [][]string      `koanf:"subject"`
-	Networks     []string        `koanf:"networks"`
-	Resources    []regexp.Regexp `koanf:"resources"`
-	Methods      []string        `koanf:"methods"`
+	Domains      []string          `koanf:"domain"`
+	DomainsRegex []regexp.Regexp   `koanf:"domain_regex"`
+	Policy       string            `koanf:"policy"`
+	Subjects     [][]string        `koanf:"subject"`
+	Networks     []string          `koanf:"networks"`
+	Countries    []string          `koanf:"countries"`
+	ASNs         []uint            `koanf:"asns"`
+	Resources    []regexp.Regexp   `koanf:"resources"`
+	Methods      []string          `koanf:"methods"`
+	Query        [][]ACLQueryRule  `koanf:"query"`
+	Headers      [][]ACLHeaderRule `koanf:"headers"`
+	Schedule     []ACLSchedule     `koanf:"schedule"`
+
+	AuthenticationMethods []string      `koanf:"authentication_methods"`
+	MaxAuthenticationAge  time.Duration `koanf:"max_authentication_age"`
+}
+
+// ACLQueryRule represents one ACL query parameter condition.
+type ACLQueryRule struct {
+	Key   string         `koanf:"key"`
+	Value *regexp.Regexp `koanf:"value"`
+}
+
+// ACLHeaderRule represents one ACL request header condition.
+type ACLHeaderRule struct {
+	Name  string         `koanf:"name"`
+	Value *regexp.Regexp `koanf:"value"`
+}
+
+// ACLSchedule represents one ACL schedule window. The Weekdays are compared with the weekday at the time of the request,
+// so a window which spans midnight such as 22:00-06:00 matches the early hours and the late hours of each of the Weekdays
+// rather than the night following each of them.
+type ACLSchedule struct {
+	Weekdays []string `koanf:"weekdays"`
+	Start    string   `koanf:"start"`
+	End      string   `koanf:"end"`
+	TimeZone string   `koanf:"time_zone"`
+}
+
+// DefaultACLExternal represents the default configuration related to the external policy decision point.
+var DefaultACLExternal = ACLExternal{
+	Timeout:       time.Second * 5,
+	FailurePolicy: "deny",
+	CacheDuration: time.Second * 10,
 }
 
 // DefaultACLNetwork represents the default configuration related to access control network group configuration.
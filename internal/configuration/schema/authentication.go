@@ -21,22 +21,64 @@ type LDAPAuthenticationBackendConfiguration struct {
 	AdditionalGroupsDN string `koanf:"additional_groups_dn"`
 	GroupsFilter       string `koanf:"groups_filter"`
 
+	GroupSearchMode     string `koanf:"group_search_mode"`
+	GroupSearchMaxDepth int    `koanf:"group_search_max_depth"`
+
 	GroupNameAttribute   string `koanf:"group_name_attribute"`
 	UsernameAttribute    string `koanf:"username_attribute"`
 	MailAttribute        string `koanf:"mail_attribute"`
 	DisplayNameAttribute string `koanf:"display_name_attribute"`
 
+	ExtraAttributes []string `koanf:"extra_attributes"`
+
 	PermitReferrals           bool `koanf:"permit_referrals"`
 	PermitUnauthenticatedBind bool `koanf:"permit_unauthenticated_bind"`
 
 	User     string `koanf:"user"`
 	Password string `koanf:"password"`
+
+	Pooling LDAPAuthenticationBackendPoolingConfiguration `koanf:"pooling"`
+}
+
+// LDAPAuthenticationBackendPoolingConfiguration represents the configuration related to the pool of LDAP connections
+// bound with the service account.
+type LDAPAuthenticationBackendPoolingConfiguration struct {
+	Enable              bool          `koanf:"enable"`
+	Count               int           `koanf:"count"`
+	Retries             int           `koanf:"retries"`
+	Timeout             time.Duration `koanf:"timeout"`
+	IdleTimeout         time.Duration `koanf:"idle_timeout"`
+	HealthCheckInterval time.Duration `koanf:"health_check_interval"`
 }
 
 // FileAuthenticationBackendConfiguration represents the configuration related to file-based backend.
 type FileAuthenticationBackendConfiguration struct {
 	Path     string                 `koanf:"path"`
+	Watch    bool                   `koanf:"watch"`
 	Password *PasswordConfiguration `koanf:"password"`
+
+	ExtraAttributes []string `koanf:"extra_attributes"`
+}
+
+// SQLAuthenticationBackendConfiguration represents the configuration related to the SQL backend which stores the users
+// in the storage database.
+type SQLAuthenticationBackendConfiguration struct {
+	Password *PasswordConfiguration `koanf:"password"`
+}
+
+// AuthenticationBackendChainConfiguration represents the configuration of a backend in the chain of authentication
+// backends.
+type AuthenticationBackendChainConfiguration struct {
+	Backend string `koanf:"backend"`
+	Prefix  string `koanf:"prefix"`
+	Realm   string `koanf:"realm"`
+}
+
+// CacheAuthenticationBackendConfiguration represents the configuration related to the cache of the details of users.
+type CacheAuthenticationBackendConfiguration struct {
+	Enable bool          `koanf:"enable"`
+	Size   int           `koanf:"size"`
+	TTL    time.Duration `koanf:"ttl"`
 }
 
 // PasswordConfiguration represents the configuration related to password hashing.
@@ -53,8 +95,13 @@ type PasswordConfiguration struct {
 type AuthenticationBackendConfiguration struct {
 	LDAP *LDAPAuthenticationBackendConfiguration `koanf:"ldap"`
 	File *FileAuthenticationBackendConfiguration `koanf:"file"`
+	SQL  *SQLAuthenticationBackendConfiguration  `koanf:"sql"`
+
+	Chain []AuthenticationBackendChainConfiguration `koanf:"chain"`
 
 	PasswordReset PasswordResetAuthenticationBackendConfiguration `koanf:"password_reset"`
+	Registration  RegistrationAuthenticationBackendConfiguration  `koanf:"registration"`
+	Cache         CacheAuthenticationBackendConfiguration         `koanf:"cache"`
 
 	RefreshInterval string `koanf:"refresh_interval"`
 }
@@ -65,6 +112,20 @@ type PasswordResetAuthenticationBackendConfiguration struct {
 	CustomURL url.URL `koanf:"custom_url"`
 }
 
+// RegistrationAuthenticationBackendConfiguration represents the configuration related to self-service registration.
+type RegistrationAuthenticationBackendConfiguration struct {
+	Enable         bool     `koanf:"enable"`
+	AllowedDomains []string `koanf:"allowed_domains"`
+	DefaultGroups  []string `koanf:"default_groups"`
+}
+
+// DefaultCacheAuthenticationBackendConfiguration represents the default configuration related to the cache of the
+// details of users.
+var DefaultCacheAuthenticationBackendConfiguration = CacheAuthenticationBackendConfiguration{
+	Size: 1000,
+	TTL:  time.Minute,
+}
+
 // DefaultPasswordConfiguration represents the default configuration related to Argon2id hashing.
 var DefaultPasswordConfiguration = PasswordConfiguration{
 	Iterations:  3,
@@ -99,10 +160,19 @@ var DefaultLDAPAuthenticationBackendConfiguration = LDAPAuthenticationBackendCon
 	MailAttribute:        "mail",
 	DisplayNameAttribute: "displayName",
 	GroupNameAttribute:   "cn",
+	GroupSearchMode:      LDAPGroupSearchModeFilter,
+	GroupSearchMaxDepth:  10,
 	Timeout:              time.Second * 5,
 	TLS: &TLSConfig{
 		MinimumVersion: "TLS1.2",
 	},
+	Pooling: LDAPAuthenticationBackendPoolingConfiguration{
+		Count:               5,
+		Retries:             2,
+		Timeout:             time.Second * 10,
+		IdleTimeout:         time.Minute * 5,
+		HealthCheckInterval: time.Second * 30,
+	},
 }
 
 // DefaultLDAPAuthenticationBackendImplementationActiveDirectoryConfiguration represents the default LDAP config for the MSAD Implementation.
@@ -16,6 +16,7 @@ type Configuration struct {
 	DuoAPI                DuoAPIConfiguration                `koanf:"duo_api"`
 	AccessControl         AccessControlConfiguration         `koanf:"access_control"`
 	NTP                   NTPConfiguration                   `koanf:"ntp"`
+	GeoIP                 GeoIPConfiguration                 `koanf:"geoip"`
 	Regulation            RegulationConfiguration            `koanf:"regulation"`
 	Storage               StorageConfiguration               `koanf:"storage"`
 	Notifier              NotifierConfiguration              `koanf:"notifier"`
@@ -29,6 +29,24 @@ const (
 	LDAPImplementationActiveDirectory = "activedirectory"
 )
 
+const (
+	// LDAPGroupSearchModeFilter is the string for the group search mode which only retrieves the groups matching the
+	// groups filter.
+	LDAPGroupSearchModeFilter = "filter"
+
+	// LDAPGroupSearchModeRecursive is the string for the group search mode which recursively retrieves the parent groups
+	// of the groups matching the groups filter.
+	LDAPGroupSearchModeRecursive = "recursive"
+
+	// LDAPGroupSearchModeInChain is the string for the group search mode which retrieves the nested groups using the
+	// Active Directory LDAP_MATCHING_RULE_IN_CHAIN matching rule.
+	LDAPGroupSearchModeInChain = "in_chain"
+
+	// LDAPGroupSearchModeAuto is the string for the group search mode which uses the in_chain mode if the server is
+	// Active Directory and the recursive mode otherwise.
+	LDAPGroupSearchModeAuto = "auto"
+)
+
 // TOTP Algorithm.
 const (
 	TOTPAlgorithmSHA1   = "SHA1"
@@ -39,6 +57,10 @@ const (
 const (
 	// RememberMeDisabled represents the duration for a disabled remember me session configuration.
 	RememberMeDisabled = time.Second * -1
+
+	// LDAPPoolingRetriesDisabled represents the retries of the LDAP connection pool configuration when opening a new
+	// connection isn't retried.
+	LDAPPoolingRetriesDisabled = -1
 )
 
 var (
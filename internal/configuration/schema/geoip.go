@@ -0,0 +1,7 @@
+package schema
+
+// GeoIPConfiguration represents the configuration related to the GeoIP database lookups.
+type GeoIPConfiguration struct {
+	Path    string `koanf:"path"`
+	ASNPath string `koanf:"asn_path"`
+}
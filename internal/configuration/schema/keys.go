@@ -57,23 +57,49 @@ var Keys = []string{
 	"authentication_backend.ldap.users_filter",
 	"authentication_backend.ldap.additional_groups_dn",
 	"authentication_backend.ldap.groups_filter",
+	"authentication_backend.ldap.group_search_mode",
+	"authentication_backend.ldap.group_search_max_depth",
 	"authentication_backend.ldap.group_name_attribute",
 	"authentication_backend.ldap.username_attribute",
 	"authentication_backend.ldap.mail_attribute",
 	"authentication_backend.ldap.display_name_attribute",
+	"authentication_backend.ldap.extra_attributes",
 	"authentication_backend.ldap.permit_referrals",
 	"authentication_backend.ldap.permit_unauthenticated_bind",
 	"authentication_backend.ldap.user",
 	"authentication_backend.ldap.password",
+	"authentication_backend.ldap.pooling.enable",
+	"authentication_backend.ldap.pooling.count",
+	"authentication_backend.ldap.pooling.retries",
+	"authentication_backend.ldap.pooling.timeout",
+	"authentication_backend.ldap.pooling.idle_timeout",
+	"authentication_backend.ldap.pooling.health_check_interval",
 	"authentication_backend.file.path",
+	"authentication_backend.file.watch",
 	"authentication_backend.file.password.iterations",
 	"authentication_backend.file.password.key_length",
 	"authentication_backend.file.password.salt_length",
 	"authentication_backend.file.password.algorithm",
 	"authentication_backend.file.password.memory",
 	"authentication_backend.file.password.parallelism",
+	"authentication_backend.file.extra_attributes",
+	"authentication_backend.sql.password.iterations",
+	"authentication_backend.sql.password.key_length",
+	"authentication_backend.sql.password.salt_length",
+	"authentication_backend.sql.password.algorithm",
+	"authentication_backend.sql.password.memory",
+	"authentication_backend.sql.password.parallelism",
+	"authentication_backend.chain[].backend",
+	"authentication_backend.chain[].prefix",
+	"authentication_backend.chain[].realm",
 	"authentication_backend.password_reset.disable",
 	"authentication_backend.password_reset.custom_url",
+	"authentication_backend.registration.enable",
+	"authentication_backend.registration.allowed_domains",
+	"authentication_backend.registration.default_groups",
+	"authentication_backend.cache.enable",
+	"authentication_backend.cache.size",
+	"authentication_backend.cache.ttl",
 	"authentication_backend.refresh_interval",
 	"session.name",
 	"session.domain",
@@ -116,19 +142,36 @@ var Keys = []string{
 	"access_control.networks",
 	"access_control.networks[].name",
 	"access_control.networks[].networks",
+	"access_control.external.url",
+	"access_control.external.timeout",
+	"access_control.external.failure_policy",
+	"access_control.external.cache_duration",
 	"access_control.rules",
 	"access_control.rules[].domain",
 	"access_control.rules[].domain_regex",
 	"access_control.rules[].policy",
 	"access_control.rules[].subject",
 	"access_control.rules[].networks",
+	"access_control.rules[].countries",
+	"access_control.rules[].asns",
 	"access_control.rules[].resources",
 	"access_control.rules[].methods",
+	"access_control.rules[].query",
+	"access_control.rules[].headers",
+	"access_control.rules[].schedule",
+	"access_control.rules[].schedule[].weekdays",
+	"access_control.rules[].schedule[].start",
+	"access_control.rules[].schedule[].end",
+	"access_control.rules[].schedule[].time_zone",
+	"access_control.rules[].authentication_methods",
+	"access_control.rules[].max_authentication_age",
 	"ntp.address",
 	"ntp.version",
 	"ntp.max_desync",
 	"ntp.disable_startup_check",
 	"ntp.disable_failure",
+	"geoip.path",
+	"geoip.asn_path",
 	"regulation.max_retries",
 	"regulation.find_time",
 	"regulation.ban_time",
@@ -205,4 +248,7 @@ var Keys = []string{
 	"password_policy.standard.require_special",
 	"password_policy.zxcvbn.enabled",
 	"password_policy.zxcvbn.min_score",
+	"password_policy.breached.enabled",
+	"password_policy.breached.path",
+	"password_policy.history",
 }
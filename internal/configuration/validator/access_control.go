@@ -3,7 +3,10 @@ package validator
 import (
 	"fmt"
 	"net"
+	"reflect"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/authelia/authelia/v4/internal/authorization"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
@@ -17,6 +20,12 @@ func IsPolicyValid(policy string) (isValid bool) {
 
 // IsSubjectValid check if a subject is valid.
 func IsSubjectValid(subject string) (isValid bool) {
+	if strings.HasPrefix(subject, prefixSubjectAttribute) {
+		name, _, found := strings.Cut(subject[len(prefixSubjectAttribute):], "=")
+
+		return found && strings.TrimSpace(name) != ""
+	}
+
 	return subject == "" || strings.HasPrefix(subject, "user:") || strings.HasPrefix(subject, "group:")
 }
 
@@ -70,6 +79,32 @@ func ValidateAccessControl(config *schema.Configuration, validator *schema.Struc
 			}
 		}
 	}
+
+	validateAccessControlExternal(&config.AccessControl.External, validator)
+}
+
+func validateAccessControlExternal(config *schema.ACLExternal, validator *schema.StructValidator) {
+	if config.URL == nil {
+		return
+	}
+
+	if config.URL.Scheme != schemeHTTP && config.URL.Scheme != schemeHTTPS {
+		validator.Push(fmt.Errorf(errFmtAccessControlExternalURLScheme, config.URL.Scheme))
+	}
+
+	if config.Timeout <= 0 {
+		config.Timeout = schema.DefaultACLExternal.Timeout
+	}
+
+	if config.CacheDuration <= 0 {
+		config.CacheDuration = schema.DefaultACLExternal.CacheDuration
+	}
+
+	if config.FailurePolicy == "" {
+		config.FailurePolicy = schema.DefaultACLExternal.FailurePolicy
+	} else if !IsPolicyValid(config.FailurePolicy) {
+		validator.Push(fmt.Errorf(errFmtAccessControlExternalFailurePolicy, strings.Join(validACLRulePolicies, "', '"), config.FailurePolicy))
+	}
 }
 
 // ValidateRules validates an ACL Rule configuration.
@@ -86,6 +121,8 @@ func ValidateRules(config *schema.Configuration, validator *schema.StructValidat
 		return
 	}
 
+	errs := len(validator.Errors())
+
 	for i, rule := range config.AccessControl.Rules {
 		rulePosition := i + 1
 
@@ -93,20 +130,310 @@ func ValidateRules(config *schema.Configuration, validator *schema.StructValidat
 			validator.Push(fmt.Errorf(errFmtAccessControlRuleNoDomains, ruleDescriptor(rulePosition, rule)))
 		}
 
-		if !IsPolicyValid(rule.Policy) {
+		switch {
+		case rule.Policy == policyExternal:
+			if config.AccessControl.External.URL == nil {
+				validator.Push(fmt.Errorf(errFmtAccessControlRuleExternalNotConfigured, ruleDescriptor(rulePosition, rule)))
+			}
+		case !IsPolicyValid(rule.Policy):
 			validator.Push(fmt.Errorf(errFmtAccessControlRuleInvalidPolicy, ruleDescriptor(rulePosition, rule), rule.Policy))
 		}
 
 		validateNetworks(rulePosition, rule, config.AccessControl, validator)
 
-		validateSubjects(rulePosition, rule, validator)
+		validateLocation(rulePosition, rule, config.GeoIP, validator)
+
+		validateSubjects(rulePosition, rule, config.AuthenticationBackend, validator)
 
 		validateMethods(rulePosition, rule, validator)
 
+		validateQuery(rulePosition, rule, validator)
+
+		validateHeaders(rulePosition, rule, validator)
+
+		validateSchedule(rulePosition, rule, validator)
+
+		validateSubexpNames(rulePosition, rule, validator)
+
+		validateAuthenticationMethods(rulePosition, rule, validator)
+
+		validateMaxAuthenticationAge(rulePosition, rule, validator)
+
 		if rule.Policy == policyBypass {
 			validateBypass(rulePosition, rule, validator)
 		}
 	}
+
+	// The static analysis of the rules is only meaningful when the rules themselves are valid.
+	if len(validator.Errors()) != errs {
+		return
+	}
+
+	validateRulesShadowed(config.AccessControl, validator)
+
+	validateRulesDefaultPolicy(config.AccessControl, validator)
+}
+
+// validateRulesShadowed warns about rules which can never match because an earlier rule matches every request they
+// match. The analysis is conservative, a rule is only considered shadowed when it's certain.
+func validateRulesShadowed(config schema.AccessControlConfiguration, validator *schema.StructValidator) {
+	for i, rule := range config.Rules {
+		for j := 0; j < i; j++ {
+			if isRuleShadowedBy(rule, config.Rules[j]) {
+				validator.PushWarning(fmt.Errorf(errFmtAccessControlRuleShadowed, ruleDescriptor(i+1, rule), ruleDescriptor(j+1, config.Rules[j])))
+
+				break
+			}
+		}
+	}
+}
+
+// validateRulesDefaultPolicy warns about rules at the end of the list which apply the same policy as the default
+// policy, as removing them would not change the policy applied to any request.
+func validateRulesDefaultPolicy(config schema.AccessControlConfiguration, validator *schema.StructValidator) {
+	i := len(config.Rules)
+
+	// A rule with subjects isn't redundant as anonymous users who match it are asked to log in rather than having the
+	// default policy applied, and neither is a rule with authentication methods or a maximum authentication age as the
+	// default policy doesn't require them.
+	for i > 0 && isRuleDefaultPolicy(config.Rules[i-1], config.DefaultPolicy) {
+		i--
+	}
+
+	for ; i < len(config.Rules); i++ {
+		validator.PushWarning(fmt.Errorf(errFmtAccessControlRuleDefaultPolicy, ruleDescriptor(i+1, config.Rules[i]), config.DefaultPolicy))
+	}
+}
+
+// isRuleDefaultPolicy returns true if the rule has exactly the same effect as the default policy.
+func isRuleDefaultPolicy(rule schema.ACLRule, defaultPolicy string) bool {
+	return rule.Policy == defaultPolicy &&
+		len(rule.Subjects) == 0 &&
+		len(rule.AuthenticationMethods) == 0 &&
+		rule.MaxAuthenticationAge == 0
+}
+
+// isRuleShadowedBy returns true if the earlier rule matches every request the rule matches.
+func isRuleShadowedBy(rule, earlier schema.ACLRule) bool {
+	if len(earlier.Query) != 0 || len(earlier.Headers) != 0 {
+		return false
+	}
+
+	if len(earlier.Schedule) != 0 && !reflect.DeepEqual(earlier.Schedule, rule.Schedule) {
+		return false
+	}
+
+	return isDomainsShadowedBy(rule, earlier) &&
+		isResourcesShadowedBy(rule, earlier) &&
+		isStringsShadowedBy(rule.Methods, earlier.Methods) &&
+		isNetworksShadowedBy(rule.Networks, earlier.Networks) &&
+		isStringsShadowedBy(rule.Countries, earlier.Countries) &&
+		isASNsShadowedBy(rule.ASNs, earlier.ASNs) &&
+		isSubjectsShadowedBy(rule.Subjects, earlier.Subjects)
+}
+
+func isDomainsShadowedBy(rule, earlier schema.ACLRule) bool {
+	if len(earlier.Domains)+len(earlier.DomainsRegex) == 0 {
+		return true
+	}
+
+	for _, domain := range rule.Domains {
+		if !isDomainShadowedBy(strings.ToLower(domain), earlier) {
+			return false
+		}
+	}
+
+	for _, pattern := range rule.DomainsRegex {
+		if !isPatternInSlice(pattern, earlier.DomainsRegex) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isDomainShadowedBy(domain string, earlier schema.ACLRule) bool {
+	for _, d := range earlier.Domains {
+		d = strings.ToLower(d)
+
+		if d == domain || (strings.HasPrefix(d, "*.") && strings.HasSuffix(domain, d[1:])) {
+			return true
+		}
+	}
+
+	// Only plain domains can be compared to a regex, and only if the regex doesn't depend on the user identity.
+	if strings.HasPrefix(domain, "*.") || strings.HasPrefix(domain, "{") {
+		return false
+	}
+
+	for _, pattern := range earlier.DomainsRegex {
+		if !utils.IsStringSliceContainsAny(authorization.IdentitySubexpNames, pattern.SubexpNames()) && pattern.MatchString(domain) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isResourcesShadowedBy(rule, earlier schema.ACLRule) bool {
+	if len(earlier.Resources) == 0 {
+		return true
+	}
+
+	if len(rule.Resources) == 0 {
+		return false
+	}
+
+	for _, pattern := range rule.Resources {
+		if !isPatternInSlice(pattern, earlier.Resources) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isStringsShadowedBy(values, earlier []string) bool {
+	if len(earlier) == 0 {
+		return true
+	}
+
+	if len(values) == 0 {
+		return false
+	}
+
+	for _, value := range values {
+		if !utils.IsStringInSliceFold(value, earlier) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isASNsShadowedBy(asns, earlier []uint) bool {
+	if len(earlier) == 0 {
+		return true
+	}
+
+	if len(asns) == 0 {
+		return false
+	}
+
+	for _, asn := range asns {
+		found := false
+
+		for _, e := range earlier {
+			if asn == e {
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isNetworksShadowedBy(networks, earlier []string) bool {
+	if len(earlier) == 0 {
+		return true
+	}
+
+	if len(networks) == 0 {
+		return false
+	}
+
+	for _, network := range networks {
+		if !isNetworkShadowedBy(network, earlier) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isNetworkShadowedBy(network string, earlier []string) bool {
+	if utils.IsStringInSlice(network, earlier) {
+		return true
+	}
+
+	ipnet := parseNetwork(network)
+	if ipnet == nil {
+		return false
+	}
+
+	ones, _ := ipnet.Mask.Size()
+
+	for _, e := range earlier {
+		enet := parseNetwork(e)
+		if enet == nil {
+			continue
+		}
+
+		eones, _ := enet.Mask.Size()
+
+		if eones <= ones && enet.Contains(ipnet.IP) && len(enet.IP) == len(ipnet.IP) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isSubjectsShadowedBy returns true if every alternative of the subjects requires at least every subject of one of
+// the earlier alternatives.
+func isSubjectsShadowedBy(subjects, earlier [][]string) bool {
+	if len(earlier) == 0 {
+		return true
+	}
+
+	if len(subjects) == 0 {
+		return false
+	}
+
+outer:
+	for _, alternative := range subjects {
+		for _, e := range earlier {
+			if utils.IsStringSliceContainsAll(e, alternative) {
+				continue outer
+			}
+		}
+
+		return false
+	}
+
+	return true
+}
+
+func isPatternInSlice(pattern regexp.Regexp, patterns []regexp.Regexp) bool {
+	for _, p := range patterns {
+		if p.String() == pattern.String() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func parseNetwork(network string) (ipnet *net.IPNet) {
+	if ip := net.ParseIP(network); ip != nil {
+		if ip.To4() != nil {
+			return &net.IPNet{IP: ip.To4(), Mask: net.CIDRMask(32, 32)}
+		}
+
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}
+	}
+
+	if _, ipnet, err := net.ParseCIDR(network); err == nil {
+		return ipnet
+	}
+
+	return nil
 }
 
 func validateBypass(rulePosition int, rule schema.ACLRule, validator *schema.StructValidator) {
@@ -117,7 +444,36 @@ func validateBypass(rulePosition int, rule schema.ACLRule, validator *schema.Str
 	for _, pattern := range rule.DomainsRegex {
 		if utils.IsStringSliceContainsAny(authorization.IdentitySubexpNames, pattern.SubexpNames()) {
 			validator.Push(fmt.Errorf(errAccessControlRuleBypassPolicyInvalidWithSubjectsWithGroupDomainRegex, ruleDescriptor(rulePosition, rule)))
-			return
+			break
+		}
+	}
+
+	for _, pattern := range rule.Resources {
+		if utils.IsStringSliceContainsAny(authorization.IdentitySubexpNames, pattern.SubexpNames()) {
+			validator.Push(fmt.Errorf(errAccessControlRuleBypassPolicyInvalidWithSubjectsWithGroupResources, ruleDescriptor(rulePosition, rule)))
+			break
+		}
+	}
+}
+
+func validateSubexpNames(rulePosition int, rule schema.ACLRule, validator *schema.StructValidator) {
+	for _, pattern := range rule.DomainsRegex {
+		validateSubexpNamesPattern(rulePosition, rule, "domain_regex", pattern, validator)
+	}
+
+	for _, pattern := range rule.Resources {
+		validateSubexpNamesPattern(rulePosition, rule, "resources", pattern, validator)
+	}
+}
+
+func validateSubexpNamesPattern(rulePosition int, rule schema.ACLRule, option string, pattern regexp.Regexp, validator *schema.StructValidator) {
+	for _, name := range pattern.SubexpNames() {
+		if name == "" || utils.IsStringInSlice(name, authorization.IdentitySubexpNames) {
+			continue
+		}
+
+		if utils.IsStringInSliceFold(name, authorization.IdentitySubexpNames) {
+			validator.PushWarning(fmt.Errorf(errFmtAccessControlRuleSubexpNameCase, ruleDescriptor(rulePosition, rule), option, pattern.String(), name))
 		}
 	}
 }
@@ -132,11 +488,59 @@ func validateNetworks(rulePosition int, rule schema.ACLRule, config schema.Acces
 	}
 }
 
-func validateSubjects(rulePosition int, rule schema.ACLRule, validator *schema.StructValidator) {
+func validateLocation(rulePosition int, rule schema.ACLRule, config schema.GeoIPConfiguration, validator *schema.StructValidator) {
+	for _, country := range rule.Countries {
+		if !reCountryCode.MatchString(country) {
+			validator.Push(fmt.Errorf(errFmtAccessControlRuleCountryInvalid, ruleDescriptor(rulePosition, rule), country))
+		}
+	}
+
+	for _, asn := range rule.ASNs {
+		if asn == 0 {
+			validator.Push(fmt.Errorf(errFmtAccessControlRuleASNInvalid, ruleDescriptor(rulePosition, rule), asn))
+		}
+	}
+
+	if config.Path != "" || config.ASNPath != "" {
+		return
+	}
+
+	if len(rule.Countries) != 0 {
+		validator.Push(fmt.Errorf(errFmtAccessControlRuleLocationNoGeoIP, ruleDescriptor(rulePosition, rule), "countries"))
+	}
+
+	if len(rule.ASNs) != 0 {
+		validator.Push(fmt.Errorf(errFmtAccessControlRuleLocationNoGeoIP, ruleDescriptor(rulePosition, rule), "asns"))
+	}
+}
+
+func validateSubjects(rulePosition int, rule schema.ACLRule, backend schema.AuthenticationBackendConfiguration, validator *schema.StructValidator) {
+	var attributes []string
+
+	if backend.LDAP != nil {
+		attributes = append(attributes, backend.LDAP.ExtraAttributes...)
+	}
+
+	if backend.File != nil {
+		attributes = append(attributes, backend.File.ExtraAttributes...)
+	}
+
 	for _, subjectRule := range rule.Subjects {
 		for _, subject := range subjectRule {
 			if !IsSubjectValid(subject) {
 				validator.Push(fmt.Errorf(errFmtAccessControlRuleSubjectInvalid, ruleDescriptor(rulePosition, rule), subject))
+
+				continue
+			}
+
+			if !strings.HasPrefix(subject, prefixSubjectAttribute) {
+				continue
+			}
+
+			name, _, _ := strings.Cut(subject[len(prefixSubjectAttribute):], "=")
+
+			if name = strings.TrimSpace(name); !utils.IsStringInSliceFold(name, attributes) {
+				validator.PushWarning(fmt.Errorf(errFmtAccessControlRuleSubjectAttributeNotConfigured, ruleDescriptor(rulePosition, rule), subject, name))
 			}
 		}
 	}
@@ -149,3 +553,84 @@ func validateMethods(rulePosition int, rule schema.ACLRule, validator *schema.St
 		}
 	}
 }
+
+func validateAuthenticationMethods(rulePosition int, rule schema.ACLRule, validator *schema.StructValidator) {
+	if len(rule.AuthenticationMethods) == 0 {
+		return
+	}
+
+	for _, method := range rule.AuthenticationMethods {
+		if !utils.IsStringInSliceFold(method, authorization.AuthenticationMethods) {
+			validator.Push(fmt.Errorf(errFmtAccessControlRuleAuthenticationMethodInvalid, ruleDescriptor(rulePosition, rule), method, strings.Join(authorization.AuthenticationMethods, "', '")))
+		}
+	}
+
+	if rule.Policy != policyTwoFactor {
+		validator.Push(fmt.Errorf(errFmtAccessControlRuleAuthenticationMethodsPolicy, ruleDescriptor(rulePosition, rule), rule.Policy))
+	}
+}
+
+func validateMaxAuthenticationAge(rulePosition int, rule schema.ACLRule, validator *schema.StructValidator) {
+	switch {
+	case rule.MaxAuthenticationAge == 0:
+		return
+	case rule.MaxAuthenticationAge < 0:
+		validator.Push(fmt.Errorf(errFmtAccessControlRuleMaxAuthenticationAgeNegative, ruleDescriptor(rulePosition, rule), rule.MaxAuthenticationAge))
+	case rule.Policy != policyOneFactor && rule.Policy != policyTwoFactor:
+		validator.Push(fmt.Errorf(errFmtAccessControlRuleMaxAuthenticationAgePolicy, ruleDescriptor(rulePosition, rule), rule.Policy))
+	}
+}
+
+func validateQuery(rulePosition int, rule schema.ACLRule, validator *schema.StructValidator) {
+	for _, queryRule := range rule.Query {
+		for _, query := range queryRule {
+			if query.Key == "" {
+				validator.Push(fmt.Errorf(errFmtAccessControlRuleQueryKeyMissing, ruleDescriptor(rulePosition, rule)))
+
+				return
+			}
+		}
+	}
+}
+
+func validateHeaders(rulePosition int, rule schema.ACLRule, validator *schema.StructValidator) {
+	for _, headerRule := range rule.Headers {
+		for _, header := range headerRule {
+			if header.Name == "" {
+				validator.Push(fmt.Errorf(errFmtAccessControlRuleHeadersNameMissing, ruleDescriptor(rulePosition, rule)))
+
+				return
+			}
+		}
+	}
+}
+
+func validateSchedule(rulePosition int, rule schema.ACLRule, validator *schema.StructValidator) {
+	var err error
+
+	for _, schedule := range rule.Schedule {
+		for _, weekday := range schedule.Weekdays {
+			if _, err = authorization.ParseScheduleWeekday(weekday); err != nil {
+				validator.Push(fmt.Errorf(errFmtAccessControlRuleScheduleWeekdayInvalid, ruleDescriptor(rulePosition, rule), weekday))
+			}
+		}
+
+		if schedule.Start != "" {
+			if _, err = authorization.ParseScheduleTimeOfDay(schedule.Start); err != nil {
+				validator.Push(fmt.Errorf(errFmtAccessControlRuleScheduleTimeInvalid, ruleDescriptor(rulePosition, rule), "start", schedule.Start))
+			}
+		}
+
+		if schedule.End != "" {
+			if _, err = authorization.ParseScheduleTimeOfDay(schedule.End); err != nil {
+				validator.Push(fmt.Errorf(errFmtAccessControlRuleScheduleTimeInvalid, ruleDescriptor(rulePosition, rule), "end", schedule.End))
+			}
+		}
+
+		if schedule.TimeZone != "" {
+			if _, err = time.LoadLocation(schedule.TimeZone); err != nil {
+				validator.Push(fmt.Errorf(errFmtAccessControlRuleScheduleTimeZoneInvalid, ruleDescriptor(rulePosition, rule), schedule.TimeZone, err))
+			}
+		}
+	}
+}
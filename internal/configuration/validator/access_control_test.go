@@ -2,8 +2,10 @@ package validator
 
 import (
 	"fmt"
+	"net/url"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
@@ -126,9 +128,9 @@ func (suite *AccessControl) TestShouldRaiseErrorsWithEmptyRules() {
 	suite.Require().Len(suite.validator.Errors(), 4)
 
 	suite.Assert().EqualError(suite.validator.Errors()[0], "access control: rule #1: rule is invalid: must have the option 'domain' or 'domain_regex' configured")
-	suite.Assert().EqualError(suite.validator.Errors()[1], "access control: rule #1: rule 'policy' option '' is invalid: must be one of 'deny', 'two_factor', 'one_factor' or 'bypass'")
+	suite.Assert().EqualError(suite.validator.Errors()[1], "access control: rule #1: rule 'policy' option '' is invalid: must be one of 'deny', 'two_factor', 'one_factor', 'bypass' or 'external'")
 	suite.Assert().EqualError(suite.validator.Errors()[2], "access control: rule #2: rule is invalid: must have the option 'domain' or 'domain_regex' configured")
-	suite.Assert().EqualError(suite.validator.Errors()[3], "access control: rule #2: rule 'policy' option 'wrong' is invalid: must be one of 'deny', 'two_factor', 'one_factor' or 'bypass'")
+	suite.Assert().EqualError(suite.validator.Errors()[3], "access control: rule #2: rule 'policy' option 'wrong' is invalid: must be one of 'deny', 'two_factor', 'one_factor', 'bypass' or 'external'")
 }
 
 func (suite *AccessControl) TestShouldRaiseErrorInvalidPolicy() {
@@ -144,7 +146,7 @@ func (suite *AccessControl) TestShouldRaiseErrorInvalidPolicy() {
 	suite.Assert().Len(suite.validator.Warnings(), 0)
 	suite.Require().Len(suite.validator.Errors(), 1)
 
-	suite.Assert().EqualError(suite.validator.Errors()[0], "access control: rule #1 (domain 'public.example.com'): rule 'policy' option 'invalid' is invalid: must be one of 'deny', 'two_factor', 'one_factor' or 'bypass'")
+	suite.Assert().EqualError(suite.validator.Errors()[0], "access control: rule #1 (domain 'public.example.com'): rule 'policy' option 'invalid' is invalid: must be one of 'deny', 'two_factor', 'one_factor', 'bypass' or 'external'")
 }
 
 func (suite *AccessControl) TestShouldRaiseErrorInvalidNetwork() {
@@ -197,10 +199,466 @@ func (suite *AccessControl) TestShouldRaiseErrorInvalidSubject() {
 	suite.Require().Len(suite.validator.Warnings(), 0)
 	suite.Require().Len(suite.validator.Errors(), 2)
 
-	suite.Assert().EqualError(suite.validator.Errors()[0], "access control: rule #1 (domain 'public.example.com'): 'subject' option 'invalid' is invalid: must start with 'user:' or 'group:'")
+	suite.Assert().EqualError(suite.validator.Errors()[0], "access control: rule #1 (domain 'public.example.com'): 'subject' option 'invalid' is invalid: must start with 'user:', 'group:', or 'attr:' where 'attr:' is followed by the attribute in the format 'name=value'")
 	suite.Assert().EqualError(suite.validator.Errors()[1], fmt.Sprintf(errAccessControlRuleBypassPolicyInvalidWithSubjects, ruleDescriptor(1, suite.config.AccessControl.Rules[0])))
 }
 
+func (suite *AccessControl) TestShouldValidateAttributeSubjects() {
+	suite.config.AuthenticationBackend.LDAP = &schema.LDAPAuthenticationBackendConfiguration{
+		ExtraAttributes: []string{"departmentNumber"},
+	}
+
+	suite.config.AccessControl.Rules = []schema.ACLRule{
+		{
+			Domains:  []string{"public.example.com"},
+			Policy:   "one_factor",
+			Subjects: [][]string{{"attr:departmentnumber=engineering"}, {"attr:employeeType=contractor"}},
+		},
+		{
+			Domains:  []string{"private.example.com"},
+			Policy:   "one_factor",
+			Subjects: [][]string{{"attr:departmentNumber"}, {"attr:=engineering"}},
+		},
+	}
+
+	ValidateRules(suite.config, suite.validator)
+
+	suite.Require().Len(suite.validator.Warnings(), 1)
+	suite.Require().Len(suite.validator.Errors(), 2)
+
+	suite.Assert().EqualError(suite.validator.Warnings()[0], "access control: rule #1 (domain 'public.example.com'): 'subject' option 'attr:employeeType=contractor' will never match as the attribute 'employeeType' is not configured in the 'extra_attributes' option of the authentication backend")
+	suite.Assert().EqualError(suite.validator.Errors()[0], "access control: rule #2 (domain 'private.example.com'): 'subject' option 'attr:departmentNumber' is invalid: must start with 'user:', 'group:', or 'attr:' where 'attr:' is followed by the attribute in the format 'name=value'")
+	suite.Assert().EqualError(suite.validator.Errors()[1], "access control: rule #2 (domain 'private.example.com'): 'subject' option 'attr:=engineering' is invalid: must start with 'user:', 'group:', or 'attr:' where 'attr:' is followed by the attribute in the format 'name=value'")
+}
+
+func (suite *AccessControl) TestShouldRaiseErrorMissingQueryKey() {
+	suite.config.AccessControl.Rules = []schema.ACLRule{
+		{
+			Domains: []string{"public.example.com"},
+			Policy:  "bypass",
+			Query: [][]schema.ACLQueryRule{
+				{
+					{Key: "token"},
+					{Value: regexp.MustCompile(`^abc$`)},
+				},
+			},
+		},
+	}
+
+	ValidateRules(suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 1)
+
+	suite.Assert().EqualError(suite.validator.Errors()[0], "access control: rule #1 (domain 'public.example.com'): 'query' option 'key' is required for every condition but one or more conditions are missing it")
+}
+
+func (suite *AccessControl) TestShouldRaiseErrorMissingHeadersName() {
+	suite.config.AccessControl.Rules = []schema.ACLRule{
+		{
+			Domains: []string{"public.example.com"},
+			Policy:  "bypass",
+			Headers: [][]schema.ACLHeaderRule{
+				{
+					{Value: regexp.MustCompile(`^abc$`)},
+				},
+			},
+		},
+	}
+
+	ValidateRules(suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 1)
+
+	suite.Assert().EqualError(suite.validator.Errors()[0], "access control: rule #1 (domain 'public.example.com'): 'headers' option 'name' is required for every condition but one or more conditions are missing it")
+}
+
+func (suite *AccessControl) TestShouldRaiseErrorInvalidSchedule() {
+	suite.config.AccessControl.Rules = []schema.ACLRule{
+		{
+			Domains: []string{"public.example.com"},
+			Policy:  "bypass",
+			Schedule: []schema.ACLSchedule{
+				{
+					Weekdays: []string{"mon", "funday"},
+					Start:    "8:00",
+					End:      "24:30",
+					TimeZone: "Mars/Olympus_Mons",
+				},
+				{
+					Weekdays: []string{"Saturday", "sun"},
+					Start:    "22:00",
+					End:      "24:00",
+					TimeZone: "UTC",
+				},
+			},
+		},
+	}
+
+	ValidateRules(suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 4)
+
+	suite.Assert().EqualError(suite.validator.Errors()[0], "access control: rule #1 (domain 'public.example.com'): 'schedule' option 'weekdays' value 'funday' is invalid: must be a weekday name such as 'monday' or 'mon'")
+	suite.Assert().EqualError(suite.validator.Errors()[1], "access control: rule #1 (domain 'public.example.com'): 'schedule' option 'start' value '8:00' is invalid: must be a time of day in the 24-hour 'HH:MM' format")
+	suite.Assert().EqualError(suite.validator.Errors()[2], "access control: rule #1 (domain 'public.example.com'): 'schedule' option 'end' value '24:30' is invalid: must be a time of day in the 24-hour 'HH:MM' format")
+	suite.Assert().EqualError(suite.validator.Errors()[3], "access control: rule #1 (domain 'public.example.com'): 'schedule' option 'time_zone' value 'Mars/Olympus_Mons' is invalid: unknown time zone Mars/Olympus_Mons")
+}
+
+func (suite *AccessControl) TestShouldRaiseErrorInvalidAuthenticationMethods() {
+	suite.config.AccessControl.Rules = []schema.ACLRule{
+		{
+			Domains:               []string{"public.example.com"},
+			Policy:                "two_factor",
+			AuthenticationMethods: []string{"webauthn", "sms"},
+		},
+		{
+			Domains:               []string{"private.example.com"},
+			Policy:                "one_factor",
+			AuthenticationMethods: []string{"Duo"},
+		},
+	}
+
+	ValidateRules(suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 2)
+
+	suite.Assert().EqualError(suite.validator.Errors()[0], "access control: rule #1 (domain 'public.example.com'): 'authentication_methods' option 'sms' is invalid: must be one of 'webauthn', 'webauthn_user_verified', 'totp', 'duo'")
+	suite.Assert().EqualError(suite.validator.Errors()[1], "access control: rule #2 (domain 'private.example.com'): 'authentication_methods' option is only supported with the 'policy' option 'two_factor' but it's configured as 'one_factor'")
+}
+
+func (suite *AccessControl) TestShouldRaiseErrorInvalidMaxAuthenticationAge() {
+	suite.config.AccessControl.Rules = []schema.ACLRule{
+		{
+			Domains:              []string{"public.example.com"},
+			Policy:               "two_factor",
+			MaxAuthenticationAge: -time.Minute,
+		},
+		{
+			Domains:              []string{"private.example.com"},
+			Policy:               "deny",
+			MaxAuthenticationAge: time.Minute,
+		},
+		{
+			Domains:              []string{"admin.example.com"},
+			Policy:               "one_factor",
+			MaxAuthenticationAge: time.Minute,
+		},
+	}
+
+	ValidateRules(suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 2)
+
+	suite.Assert().EqualError(suite.validator.Errors()[0], "access control: rule #1 (domain 'public.example.com'): 'max_authentication_age' option '-1m0s' is invalid: must be a positive duration")
+	suite.Assert().EqualError(suite.validator.Errors()[1], "access control: rule #2 (domain 'private.example.com'): 'max_authentication_age' option is only supported with the 'policy' option 'one_factor' or 'two_factor' but it's configured as 'deny'")
+}
+
+func (suite *AccessControl) TestShouldRaiseErrorInvalidLocation() {
+	suite.config.AccessControl.Rules = []schema.ACLRule{
+		{
+			Domains:   []string{"public.example.com"},
+			Policy:    "one_factor",
+			Countries: []string{"DE", "DEU"},
+		},
+		{
+			Domains: []string{"private.example.com"},
+			Policy:  "two_factor",
+			ASNs:    []uint{0, 3320},
+		},
+	}
+
+	ValidateRules(suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 4)
+
+	suite.Assert().EqualError(suite.validator.Errors()[0], "access control: rule #1 (domain 'public.example.com'): 'countries' option 'DEU' is invalid: must be a two letter ISO 3166-1 alpha-2 country code such as 'DE'")
+	suite.Assert().EqualError(suite.validator.Errors()[1], "access control: rule #1 (domain 'public.example.com'): 'countries' option is configured but the 'geoip' option 'path' or 'asn_path' is not configured")
+	suite.Assert().EqualError(suite.validator.Errors()[2], "access control: rule #2 (domain 'private.example.com'): 'asns' option '0' is invalid: must be a positive autonomous system number")
+	suite.Assert().EqualError(suite.validator.Errors()[3], "access control: rule #2 (domain 'private.example.com'): 'asns' option is configured but the 'geoip' option 'path' or 'asn_path' is not configured")
+}
+
+func (suite *AccessControl) TestShouldValidateLocationWithGeoIP() {
+	suite.config.GeoIP = schema.GeoIPConfiguration{Path: "/var/lib/GeoIP/GeoLite2-Country.mmdb"}
+	suite.config.AccessControl.Rules = []schema.ACLRule{
+		{
+			Domains:   []string{"public.example.com"},
+			Policy:    "one_factor",
+			Countries: []string{"de", "FR"},
+			ASNs:      []uint{3320},
+		},
+	}
+
+	ValidateRules(suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Assert().Len(suite.validator.Errors(), 0)
+}
+
+func (suite *AccessControl) TestShouldRaiseErrorExternalPolicyNotConfigured() {
+	suite.config.AccessControl.Rules = []schema.ACLRule{
+		{
+			Domains: []string{"public.example.com"},
+			Policy:  "external",
+		},
+	}
+
+	ValidateRules(suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 1)
+
+	suite.Assert().EqualError(suite.validator.Errors()[0], "access control: rule #1 (domain 'public.example.com'): 'policy' option 'external' requires the 'external' option 'url' to be configured")
+}
+
+func (suite *AccessControl) TestShouldSetDefaultExternal() {
+	suite.config.AccessControl.External = schema.ACLExternal{
+		URL: &url.URL{Scheme: "https", Host: "pdp.example.com", Path: "/decide"},
+	}
+	suite.config.AccessControl.Rules = []schema.ACLRule{
+		{
+			Domains: []string{"public.example.com"},
+			Policy:  "external",
+		},
+	}
+
+	ValidateAccessControl(suite.config, suite.validator)
+	ValidateRules(suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Assert().Len(suite.validator.Errors(), 0)
+
+	suite.Assert().Equal(schema.DefaultACLExternal.Timeout, suite.config.AccessControl.External.Timeout)
+	suite.Assert().Equal(schema.DefaultACLExternal.CacheDuration, suite.config.AccessControl.External.CacheDuration)
+	suite.Assert().Equal(policyDeny, suite.config.AccessControl.External.FailurePolicy)
+}
+
+func (suite *AccessControl) TestShouldRaiseErrorInvalidExternal() {
+	suite.config.AccessControl.External = schema.ACLExternal{
+		URL:           &url.URL{Scheme: "tcp", Host: "pdp.example.com"},
+		FailurePolicy: "allow",
+	}
+
+	ValidateAccessControl(suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 2)
+
+	suite.Assert().EqualError(suite.validator.Errors()[0], "access control: external: option 'url' must have the scheme 'http' or 'https' but it is configured as 'tcp'")
+	suite.Assert().EqualError(suite.validator.Errors()[1], "access control: external: option 'failure_policy' must be one of 'bypass', 'one_factor', 'two_factor', 'deny' but it is configured as 'allow'")
+}
+
+func (suite *AccessControl) TestShouldRaiseErrorBypassWithSubexpNamedResources() {
+	suite.config.AccessControl.Rules = []schema.ACLRule{
+		{
+			Domains:   []string{"public.example.com"},
+			Policy:    "bypass",
+			Resources: []regexp.Regexp{*regexp.MustCompile(`^/(?P<User>\w+)/personal$`)},
+		},
+	}
+
+	ValidateRules(suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 1)
+
+	suite.Assert().EqualError(suite.validator.Errors()[0], "access control: rule #1 (domain 'public.example.com'): 'policy' option 'bypass' is not supported when 'resources' option contains the user or group named matches. For more information see: https://www.authelia.com/c/acl#bypass-and-user-identity")
+}
+
+func (suite *AccessControl) TestShouldRaiseWarningSubexpNameCase() {
+	suite.config.AccessControl.Rules = []schema.ACLRule{
+		{
+			DomainsRegex: []regexp.Regexp{*regexp.MustCompile(`^(?P<user>\w+)\.example\.com$`)},
+			Policy:       "one_factor",
+			Resources:    []regexp.Regexp{*regexp.MustCompile(`^/(?P<GROUP>\w+)/group$`), *regexp.MustCompile(`^/(?P<Group>\w+)/(?P<page>\w+)$`)},
+		},
+	}
+
+	ValidateRules(suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Errors(), 0)
+	suite.Require().Len(suite.validator.Warnings(), 2)
+
+	suite.Assert().EqualError(suite.validator.Warnings()[0], "access control: rule #1: 'domain_regex' option pattern '^(?P<user>\\w+)\\.example\\.com$' has the named match 'user' which is not bound to the user identity as named matches are case-sensitive: did you mean 'User' or 'Group'")
+	suite.Assert().EqualError(suite.validator.Warnings()[1], "access control: rule #1: 'resources' option pattern '^/(?P<GROUP>\\w+)/group$' has the named match 'GROUP' which is not bound to the user identity as named matches are case-sensitive: did you mean 'User' or 'Group'")
+}
+
+func (suite *AccessControl) TestShouldRaiseWarningShadowedRules() {
+	suite.config.AccessControl.Rules = []schema.ACLRule{
+		{
+			Domains: []string{"*.example.com"},
+			Policy:  "one_factor",
+		},
+		{
+			Domains:   []string{"app.example.com"},
+			Policy:    "two_factor",
+			Resources: []regexp.Regexp{*regexp.MustCompile(`^/api`)},
+		},
+		{
+			Domains:  []string{"example.com"},
+			Policy:   "one_factor",
+			Networks: []string{"10.0.0.0/8"},
+			Methods:  []string{"GET", "POST"},
+			Subjects: [][]string{{"group:admins"}, {"user:john"}},
+		},
+		{
+			Domains:  []string{"example.com"},
+			Policy:   "two_factor",
+			Networks: []string{"10.10.0.0/16", "10.0.0.1"},
+			Methods:  []string{"post"},
+			Subjects: [][]string{{"group:admins", "group:dev"}},
+		},
+		{
+			Domains:  []string{"example.com"},
+			Policy:   "two_factor",
+			Networks: []string{"192.168.0.0/16"},
+		},
+		{
+			DomainsRegex: []regexp.Regexp{*regexp.MustCompile(`^.*\.example\.org$`)},
+			Policy:       "one_factor",
+			Query:        [][]schema.ACLQueryRule{{{Key: "token"}}},
+		},
+		{
+			Domains: []string{"app.example.org"},
+			Policy:  "two_factor",
+		},
+		{
+			DomainsRegex: []regexp.Regexp{*regexp.MustCompile(`^.*\.example\.net$`)},
+			Policy:       "one_factor",
+		},
+		{
+			Domains:      []string{"app.example.net"},
+			DomainsRegex: []regexp.Regexp{*regexp.MustCompile(`^.*\.example\.net$`)},
+			Policy:       "two_factor",
+		},
+	}
+
+	ValidateRules(suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Errors(), 0)
+	suite.Require().Len(suite.validator.Warnings(), 3)
+
+	suite.Assert().EqualError(suite.validator.Warnings()[0], "access control: rule #2 (domain 'app.example.com'): rule will never be matched as rule #1 (domain '*.example.com') is evaluated first and matches every request this rule matches")
+	suite.Assert().EqualError(suite.validator.Warnings()[1], "access control: rule #4 (domain 'example.com'): rule will never be matched as rule #3 (domain 'example.com') is evaluated first and matches every request this rule matches")
+	suite.Assert().EqualError(suite.validator.Warnings()[2], "access control: rule #9 (domain 'app.example.net'): rule will never be matched as rule #8 is evaluated first and matches every request this rule matches")
+}
+
+func (suite *AccessControl) TestShouldRaiseWarningDefaultPolicyRules() {
+	suite.config.AccessControl.Rules = []schema.ACLRule{
+		{
+			Domains: []string{"public.example.com"},
+			Policy:  "deny",
+		},
+		{
+			Domains: []string{"*.example.com"},
+			Policy:  "bypass",
+		},
+		{
+			Domains:  []string{"*.example.org"},
+			Policy:   "deny",
+			Subjects: [][]string{{"group:admins"}},
+		},
+		{
+			Domains: []string{"secure.example.org"},
+			Policy:  "deny",
+		},
+		{
+			Domains: []string{"secure.example.net"},
+			Policy:  "deny",
+		},
+	}
+
+	ValidateRules(suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Errors(), 0)
+	suite.Require().Len(suite.validator.Warnings(), 2)
+
+	suite.Assert().EqualError(suite.validator.Warnings()[0], "access control: rule #4 (domain 'secure.example.org'): rule has no effect as it applies the same policy as the 'default_policy' option 'deny' and no later rule applies a different policy")
+	suite.Assert().EqualError(suite.validator.Warnings()[1], "access control: rule #5 (domain 'secure.example.net'): rule has no effect as it applies the same policy as the 'default_policy' option 'deny' and no later rule applies a different policy")
+}
+
+func (suite *AccessControl) TestShouldNotRaiseWarningDefaultPolicyRulesWithSubjects() {
+	suite.config.AccessControl.Rules = []schema.ACLRule{
+		{
+			Domains: []string{"secure.example.org"},
+			Policy:  "deny",
+		},
+		{
+			Domains:  []string{"*.example.org"},
+			Policy:   "deny",
+			Subjects: [][]string{{"group:admins"}},
+		},
+	}
+
+	ValidateRules(suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Errors(), 0)
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+}
+
+func (suite *AccessControl) TestShouldNotRaiseWarningDefaultPolicyRulesWithAuthenticationMethods() {
+	suite.config.AccessControl.DefaultPolicy = policyTwoFactor
+	suite.config.AccessControl.Rules = []schema.ACLRule{
+		{
+			Domains: []string{"secure.example.org"},
+			Policy:  "two_factor",
+		},
+		{
+			Domains:               []string{"*.example.org"},
+			Policy:                "two_factor",
+			AuthenticationMethods: []string{"webauthn"},
+		},
+	}
+
+	ValidateRules(suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Errors(), 0)
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+}
+
+func (suite *AccessControl) TestShouldNotRaiseWarningDefaultPolicyRulesWithMaxAuthenticationAge() {
+	suite.config.AccessControl.DefaultPolicy = policyOneFactor
+	suite.config.AccessControl.Rules = []schema.ACLRule{
+		{
+			Domains: []string{"secure.example.org"},
+			Policy:  "one_factor",
+		},
+		{
+			Domains:              []string{"*.example.org"},
+			Policy:               "one_factor",
+			MaxAuthenticationAge: time.Hour,
+		},
+	}
+
+	ValidateRules(suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Errors(), 0)
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+}
+
+func (suite *AccessControl) TestShouldNotAnalyseRulesWithErrors() {
+	suite.config.AccessControl.Rules = []schema.ACLRule{
+		{
+			Domains: []string{"*.example.com"},
+			Policy:  "one_factor",
+		},
+		{
+			Domains: []string{"app.example.com"},
+			Policy:  "invalid",
+		},
+	}
+
+	ValidateRules(suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 1)
+}
+
 func TestAccessControl(t *testing.T) {
 	suite.Run(t, new(AccessControl))
 }
@@ -11,18 +11,32 @@ import (
 
 // ValidateAuthenticationBackend validates and updates the authentication backend configuration.
 func ValidateAuthenticationBackend(config *schema.AuthenticationBackendConfiguration, validator *schema.StructValidator) {
-	if config.LDAP == nil && config.File == nil {
-		validator.Push(fmt.Errorf(errFmtAuthBackendNotConfigured))
+	backends := 0
+
+	for _, configured := range []bool{config.File != nil, config.LDAP != nil, config.SQL != nil} {
+		if configured {
+			backends++
+		}
 	}
 
-	if config.LDAP != nil && config.File != nil {
+	switch {
+	case backends == 0:
+		validator.Push(fmt.Errorf(errFmtAuthBackendNotConfigured))
+	case backends > 1 && len(config.Chain) == 0:
 		validator.Push(fmt.Errorf(errFmtAuthBackendMultipleConfigured))
 	}
 
-	if config.File != nil {
-		validateFileAuthenticationBackend(config.File, validator)
-	} else if config.LDAP != nil {
-		validateLDAPAuthenticationBackend(config, validator)
+	if len(config.Chain) == 0 {
+		switch {
+		case config.File != nil:
+			validateFileAuthenticationBackend(config.File, validator)
+		case config.LDAP != nil:
+			validateLDAPAuthenticationBackend(config, validator)
+		case config.SQL != nil:
+			validateSQLAuthenticationBackend(config.SQL, validator)
+		}
+	} else {
+		validateAuthenticationBackendChain(config, validator)
 	}
 
 	if config.RefreshInterval == "" {
@@ -42,6 +56,121 @@ func ValidateAuthenticationBackend(config *schema.AuthenticationBackendConfigura
 			validator.Push(fmt.Errorf(errFmtAuthBackendPasswordResetCustomURLScheme, config.PasswordReset.CustomURL.String(), config.PasswordReset.CustomURL.Scheme))
 		}
 	}
+
+	if config.Registration.Enable {
+		validateRegistration(config, validator)
+	}
+
+	if config.Cache.Enable {
+		validateAuthenticationBackendCache(&config.Cache, validator)
+	}
+}
+
+// validateAuthenticationBackendCache validates and updates the cache of the details of users configuration.
+func validateAuthenticationBackendCache(config *schema.CacheAuthenticationBackendConfiguration, validator *schema.StructValidator) {
+	switch {
+	case config.Size < 0:
+		validator.Push(fmt.Errorf(errFmtAuthBackendCacheNegative, "size", config.Size))
+	case config.Size == 0:
+		config.Size = schema.DefaultCacheAuthenticationBackendConfiguration.Size
+	}
+
+	switch {
+	case config.TTL < 0:
+		validator.Push(fmt.Errorf(errFmtAuthBackendCacheNegative, "ttl", config.TTL))
+	case config.TTL == 0:
+		config.TTL = schema.DefaultCacheAuthenticationBackendConfiguration.TTL
+	}
+}
+
+// validateRegistration validates and updates the self-service registration configuration. Users are registered in the
+// file or SQL authentication backend, or the first of them in the chain which doesn't have a prefix or realm.
+func validateRegistration(config *schema.AuthenticationBackendConfiguration, validator *schema.StructValidator) {
+	registrable := false
+
+	if len(config.Chain) == 0 {
+		registrable = config.File != nil || config.SQL != nil
+	} else {
+		for _, backend := range config.Chain {
+			if (backend.Backend == "file" || backend.Backend == "sql") && backend.Prefix == "" && backend.Realm == "" {
+				registrable = true
+
+				break
+			}
+		}
+	}
+
+	if !registrable {
+		validator.Push(fmt.Errorf(errFmtAuthBackendRegistrationBackend))
+	}
+
+	for i, domain := range config.Registration.AllowedDomains {
+		if domain == "" || strings.ContainsAny(domain, "@ ") {
+			validator.Push(fmt.Errorf(errFmtAuthBackendRegistrationAllowedDomainInvalid, domain))
+
+			continue
+		}
+
+		config.Registration.AllowedDomains[i] = strings.ToLower(domain)
+	}
+}
+
+// validateAuthenticationBackendChain validates the chain of authentication backends.
+func validateAuthenticationBackendChain(config *schema.AuthenticationBackendConfiguration, validator *schema.StructValidator) {
+	var backends, prefixes, realms []string
+
+	configured := map[string]bool{
+		"file": config.File != nil,
+		"ldap": config.LDAP != nil,
+		"sql":  config.SQL != nil,
+	}
+
+	for i, backend := range config.Chain {
+		switch {
+		case !utils.IsStringInSlice(backend.Backend, validAuthBackendChainBackends):
+			validator.Push(fmt.Errorf(errFmtAuthBackendChainInvalidBackend, i+1, backend.Backend))
+		case !configured[backend.Backend]:
+			validator.Push(fmt.Errorf(errFmtAuthBackendChainBackendNotConfigured, i+1, backend.Backend, backend.Backend))
+		case utils.IsStringInSlice(backend.Backend, backends):
+			validator.Push(fmt.Errorf(errFmtAuthBackendChainDuplicateBackend, i+1, backend.Backend))
+		default:
+			backends = append(backends, backend.Backend)
+		}
+
+		if backend.Prefix != "" {
+			if utils.IsStringInSlice(backend.Prefix, prefixes) {
+				validator.Push(fmt.Errorf(errFmtAuthBackendChainDuplicateQualifier, i+1, "prefix", backend.Prefix))
+			}
+
+			prefixes = append(prefixes, backend.Prefix)
+		}
+
+		if backend.Realm != "" {
+			if utils.IsStringInSlice(backend.Realm, realms) {
+				validator.Push(fmt.Errorf(errFmtAuthBackendChainDuplicateQualifier, i+1, "realm", backend.Realm))
+			}
+
+			realms = append(realms, backend.Realm)
+		}
+	}
+
+	for _, backend := range validAuthBackendChainBackends {
+		if configured[backend] && !utils.IsStringInSlice(backend, backends) {
+			validator.Push(fmt.Errorf(errFmtAuthBackendChainMissingBackend, backend))
+		}
+	}
+
+	if config.File != nil {
+		validateFileAuthenticationBackend(config.File, validator)
+	}
+
+	if config.LDAP != nil {
+		validateLDAPAuthenticationBackend(config, validator)
+	}
+
+	if config.SQL != nil {
+		validateSQLAuthenticationBackend(config.SQL, validator)
+	}
 }
 
 // validateFileAuthenticationBackend validates and updates the file authentication backend configuration.
@@ -57,6 +186,15 @@ func validateFileAuthenticationBackend(config *schema.FileAuthenticationBackendC
 	}
 }
 
+// validateSQLAuthenticationBackend validates and updates the SQL authentication backend configuration.
+func validateSQLAuthenticationBackend(config *schema.SQLAuthenticationBackendConfiguration, validator *schema.StructValidator) {
+	if config.Password == nil {
+		config.Password = &schema.DefaultPasswordConfiguration
+	} else {
+		ValidatePasswordConfiguration(config.Password, validator)
+	}
+}
+
 // ValidatePasswordConfiguration validates the file auth backend password configuration.
 func ValidatePasswordConfiguration(config *schema.PasswordConfiguration, validator *schema.StructValidator) {
 	// Salt Length.
@@ -165,6 +303,72 @@ func validateLDAPAuthenticationBackend(config *schema.AuthenticationBackendConfi
 	}
 
 	validateLDAPRequiredParameters(config, validator)
+	validateLDAPAuthenticationBackendGroupSearch(config.LDAP, validator)
+	validateLDAPAuthenticationBackendPooling(&config.LDAP.Pooling, validator)
+}
+
+// validateLDAPAuthenticationBackendGroupSearch validates and updates the LDAP nested group search configuration.
+func validateLDAPAuthenticationBackendGroupSearch(config *schema.LDAPAuthenticationBackendConfiguration, validator *schema.StructValidator) {
+	switch config.GroupSearchMode {
+	case "":
+		config.GroupSearchMode = schema.DefaultLDAPAuthenticationBackendConfiguration.GroupSearchMode
+	case schema.LDAPGroupSearchModeFilter, schema.LDAPGroupSearchModeInChain:
+		break
+	case schema.LDAPGroupSearchModeRecursive, schema.LDAPGroupSearchModeAuto:
+		// The parent groups of a group are searched by replacing the {dn} placeholder with the group DN.
+		if config.GroupsFilter != "" && !strings.Contains(config.GroupsFilter, "{dn}") {
+			validator.Push(fmt.Errorf(errFmtLDAPAuthBackendGroupSearchModeMissingPlaceholder, config.GroupSearchMode))
+		}
+	default:
+		validator.Push(fmt.Errorf(errFmtLDAPAuthBackendGroupSearchMode, config.GroupSearchMode, strings.Join(validLDAPGroupSearchModes, "', '")))
+	}
+
+	switch {
+	case config.GroupSearchMaxDepth < 0:
+		validator.Push(fmt.Errorf(errFmtLDAPAuthBackendGroupSearchMaxDepth, config.GroupSearchMaxDepth))
+	case config.GroupSearchMaxDepth == 0:
+		config.GroupSearchMaxDepth = schema.DefaultLDAPAuthenticationBackendConfiguration.GroupSearchMaxDepth
+	}
+}
+
+// validateLDAPAuthenticationBackendPooling validates and updates the LDAP connection pool configuration.
+func validateLDAPAuthenticationBackendPooling(config *schema.LDAPAuthenticationBackendPoolingConfiguration, validator *schema.StructValidator) {
+	defaults := schema.DefaultLDAPAuthenticationBackendConfiguration.Pooling
+
+	switch {
+	case config.Count < 0:
+		validator.Push(fmt.Errorf(errFmtLDAPAuthBackendPoolingNegative, "count", config.Count))
+	case config.Count == 0:
+		config.Count = defaults.Count
+	}
+
+	switch {
+	case config.Retries < 0 && config.Retries != schema.LDAPPoolingRetriesDisabled:
+		validator.Push(fmt.Errorf(errFmtLDAPAuthBackendPoolingRetries, config.Retries))
+	case config.Retries == 0:
+		config.Retries = defaults.Retries
+	}
+
+	switch {
+	case config.Timeout < 0:
+		validator.Push(fmt.Errorf(errFmtLDAPAuthBackendPoolingNegative, "timeout", config.Timeout))
+	case config.Timeout == 0:
+		config.Timeout = defaults.Timeout
+	}
+
+	switch {
+	case config.IdleTimeout < 0:
+		validator.Push(fmt.Errorf(errFmtLDAPAuthBackendPoolingNegative, "idle_timeout", config.IdleTimeout))
+	case config.IdleTimeout == 0:
+		config.IdleTimeout = defaults.IdleTimeout
+	}
+
+	switch {
+	case config.HealthCheckInterval < 0:
+		validator.Push(fmt.Errorf(errFmtLDAPAuthBackendPoolingNegative, "health_check_interval", config.HealthCheckInterval))
+	case config.HealthCheckInterval == 0:
+		config.HealthCheckInterval = defaults.HealthCheckInterval
+	}
 }
 
 func validateLDAPAuthenticationBackendURL(config *schema.LDAPAuthenticationBackendConfiguration, validator *schema.StructValidator) {
@@ -24,7 +24,7 @@ func TestShouldRaiseErrorWhenBothBackendsProvided(t *testing.T) {
 	ValidateAuthenticationBackend(&backendConfig, validator)
 
 	require.Len(t, validator.Errors(), 1)
-	assert.EqualError(t, validator.Errors()[0], "authentication_backend: please ensure only one of the 'file' or 'ldap' backend is configured")
+	assert.EqualError(t, validator.Errors()[0], "authentication_backend: please ensure only one of the 'file', 'ldap', or 'sql' backend is configured")
 }
 
 func TestShouldRaiseErrorWhenNoBackendProvided(t *testing.T) {
@@ -34,7 +34,208 @@ func TestShouldRaiseErrorWhenNoBackendProvided(t *testing.T) {
 	ValidateAuthenticationBackend(&backendConfig, validator)
 
 	require.Len(t, validator.Errors(), 1)
-	assert.EqualError(t, validator.Errors()[0], "authentication_backend: you must ensure either the 'file' or 'ldap' authentication backend is configured")
+	assert.EqualError(t, validator.Errors()[0], "authentication_backend: you must ensure either the 'file', 'ldap', or 'sql' authentication backend is configured")
+}
+
+func TestShouldRaiseErrorWhenSQLAndFileBackendsProvided(t *testing.T) {
+	validator := schema.NewStructValidator()
+	backendConfig := schema.AuthenticationBackendConfiguration{
+		File: &schema.FileAuthenticationBackendConfiguration{Path: "/tmp"},
+		SQL:  &schema.SQLAuthenticationBackendConfiguration{},
+	}
+
+	ValidateAuthenticationBackend(&backendConfig, validator)
+
+	require.Len(t, validator.Errors(), 1)
+	assert.EqualError(t, validator.Errors()[0], "authentication_backend: please ensure only one of the 'file', 'ldap', or 'sql' backend is configured")
+}
+
+func TestShouldValidateAuthenticationBackendChain(t *testing.T) {
+	validator := schema.NewStructValidator()
+	backendConfig := schema.AuthenticationBackendConfiguration{
+		File: &schema.FileAuthenticationBackendConfiguration{Path: "/tmp"},
+		SQL:  &schema.SQLAuthenticationBackendConfiguration{},
+		Chain: []schema.AuthenticationBackendChainConfiguration{
+			{Backend: "file", Prefix: "local\\"},
+			{Backend: "sql"},
+		},
+	}
+
+	ValidateAuthenticationBackend(&backendConfig, validator)
+
+	assert.Len(t, validator.Warnings(), 0)
+	assert.Len(t, validator.Errors(), 0)
+
+	assert.Equal(t, schema.DefaultPasswordConfiguration, *backendConfig.File.Password)
+	assert.Equal(t, schema.DefaultPasswordConfiguration, *backendConfig.SQL.Password)
+}
+
+func TestShouldRaiseErrorsInvalidAuthenticationBackendChain(t *testing.T) {
+	validator := schema.NewStructValidator()
+	backendConfig := schema.AuthenticationBackendConfiguration{
+		File: &schema.FileAuthenticationBackendConfiguration{Path: "/tmp"},
+		SQL:  &schema.SQLAuthenticationBackendConfiguration{},
+		Chain: []schema.AuthenticationBackendChainConfiguration{
+			{Backend: "file", Realm: "local"},
+			{Backend: "ldap"},
+			{Backend: "radius"},
+			{Backend: "file", Realm: "local"},
+		},
+	}
+
+	ValidateAuthenticationBackend(&backendConfig, validator)
+
+	assert.Len(t, validator.Warnings(), 0)
+	require.Len(t, validator.Errors(), 5)
+
+	assert.EqualError(t, validator.Errors()[0], "authentication_backend: chain: backend #2: option 'backend' is configured as 'ldap' but the 'ldap' authentication backend is not configured")
+	assert.EqualError(t, validator.Errors()[1], "authentication_backend: chain: backend #3: option 'backend' must be one of 'file', 'ldap', or 'sql' but it's configured as 'radius'")
+	assert.EqualError(t, validator.Errors()[2], "authentication_backend: chain: backend #4: option 'backend' is configured as 'file' which is already in the chain")
+	assert.EqualError(t, validator.Errors()[3], "authentication_backend: chain: backend #4: option 'realm' is configured as 'local' which is already used by another backend in the chain")
+	assert.EqualError(t, validator.Errors()[4], "authentication_backend: chain: the 'sql' authentication backend is configured but it's not in the chain")
+}
+
+func TestShouldSetDefaultSQLBackendPasswordConfiguration(t *testing.T) {
+	validator := schema.NewStructValidator()
+	backendConfig := schema.AuthenticationBackendConfiguration{
+		SQL: &schema.SQLAuthenticationBackendConfiguration{},
+	}
+
+	ValidateAuthenticationBackend(&backendConfig, validator)
+
+	assert.Len(t, validator.Warnings(), 0)
+	assert.Len(t, validator.Errors(), 0)
+
+	require.NotNil(t, backendConfig.SQL.Password)
+	assert.Equal(t, schema.DefaultPasswordConfiguration, *backendConfig.SQL.Password)
+
+	backendConfig.SQL.Password = &schema.PasswordConfiguration{Algorithm: "sha512"}
+
+	ValidateAuthenticationBackend(&backendConfig, validator)
+
+	assert.Len(t, validator.Errors(), 0)
+	assert.Equal(t, schema.DefaultPasswordSHA512Configuration.Iterations, backendConfig.SQL.Password.Iterations)
+	assert.Equal(t, schema.DefaultPasswordConfiguration.SaltLength, backendConfig.SQL.Password.SaltLength)
+}
+
+func TestShouldValidateRegistration(t *testing.T) {
+	validator := schema.NewStructValidator()
+	backendConfig := schema.AuthenticationBackendConfiguration{
+		SQL: &schema.SQLAuthenticationBackendConfiguration{},
+		Registration: schema.RegistrationAuthenticationBackendConfiguration{
+			Enable:         true,
+			AllowedDomains: []string{"Example.com", "example.org"},
+			DefaultGroups:  []string{"users"},
+		},
+	}
+
+	ValidateAuthenticationBackend(&backendConfig, validator)
+
+	assert.Len(t, validator.Warnings(), 0)
+	assert.Len(t, validator.Errors(), 0)
+
+	assert.Equal(t, []string{"example.com", "example.org"}, backendConfig.Registration.AllowedDomains)
+}
+
+func TestShouldRaiseErrorsInvalidRegistration(t *testing.T) {
+	testCases := []struct {
+		name     string
+		config   schema.AuthenticationBackendConfiguration
+		expected []string
+	}{
+		{
+			"ShouldRaiseErrorLDAP",
+			schema.AuthenticationBackendConfiguration{
+				LDAP: &schema.LDAPAuthenticationBackendConfiguration{
+					Implementation:    schema.LDAPImplementationCustom,
+					URL:               testLDAPURL,
+					User:              testLDAPUser,
+					Password:          testLDAPPassword,
+					BaseDN:            testLDAPBaseDN,
+					UsernameAttribute: "uid",
+					UsersFilter:       "({username_attribute}={input})",
+					GroupsFilter:      "(cn={input})",
+				},
+			},
+			[]string{
+				"authentication_backend: registration: option 'enable' requires the 'file' or 'sql' authentication backend without a prefix or realm",
+			},
+		},
+		{
+			"ShouldRaiseErrorChainQualified",
+			schema.AuthenticationBackendConfiguration{
+				File: &schema.FileAuthenticationBackendConfiguration{Path: "/tmp"},
+				SQL:  &schema.SQLAuthenticationBackendConfiguration{},
+				Chain: []schema.AuthenticationBackendChainConfiguration{
+					{Backend: "file", Prefix: "local\\"},
+					{Backend: "sql", Realm: "example.com"},
+				},
+			},
+			[]string{
+				"authentication_backend: registration: option 'enable' requires the 'file' or 'sql' authentication backend without a prefix or realm",
+			},
+		},
+		{
+			"ShouldRaiseErrorAllowedDomains",
+			schema.AuthenticationBackendConfiguration{
+				File: &schema.FileAuthenticationBackendConfiguration{Path: "/tmp"},
+				Registration: schema.RegistrationAuthenticationBackendConfiguration{
+					AllowedDomains: []string{"example.com", "", "@example.org"},
+				},
+			},
+			[]string{
+				"authentication_backend: registration: option 'allowed_domains' has the value '' which is not a valid domain",
+				"authentication_backend: registration: option 'allowed_domains' has the value '@example.org' which is not a valid domain",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			validator := schema.NewStructValidator()
+
+			tc.config.Registration.Enable = true
+
+			ValidateAuthenticationBackend(&tc.config, validator)
+
+			require.Len(t, validator.Errors(), len(tc.expected))
+
+			for i, expected := range tc.expected {
+				assert.EqualError(t, validator.Errors()[i], expected)
+			}
+		})
+	}
+}
+
+func TestShouldValidateAuthenticationBackendCache(t *testing.T) {
+	validator := schema.NewStructValidator()
+	backendConfig := schema.AuthenticationBackendConfiguration{
+		SQL: &schema.SQLAuthenticationBackendConfiguration{},
+		Cache: schema.CacheAuthenticationBackendConfiguration{
+			Enable: true,
+		},
+	}
+
+	ValidateAuthenticationBackend(&backendConfig, validator)
+
+	assert.Len(t, validator.Warnings(), 0)
+	assert.Len(t, validator.Errors(), 0)
+
+	assert.Equal(t, schema.DefaultCacheAuthenticationBackendConfiguration.Size, backendConfig.Cache.Size)
+	assert.Equal(t, schema.DefaultCacheAuthenticationBackendConfiguration.TTL, backendConfig.Cache.TTL)
+
+	backendConfig.Cache = schema.CacheAuthenticationBackendConfiguration{
+		Enable: true,
+		Size:   -1,
+		TTL:    -time.Second,
+	}
+
+	ValidateAuthenticationBackend(&backendConfig, validator)
+
+	require.Len(t, validator.Errors(), 2)
+
+	assert.EqualError(t, validator.Errors()[0], "authentication_backend: cache: option 'size' must not be negative but it's configured as '-1'")
+	assert.EqualError(t, validator.Errors()[1], "authentication_backend: cache: option 'ttl' must not be negative but it's configured as '-1s'")
 }
 
 type FileBasedAuthenticationBackend struct {
@@ -506,6 +707,97 @@ func (suite *LDAPAuthenticationBackendSuite) TestShouldNotAllowInvalidTLSValue()
 	suite.Assert().EqualError(suite.validator.Errors()[0], "authentication_backend: ldap: tls: option 'minimum_tls_version' is invalid: SSL2.0: supplied tls version isn't supported")
 }
 
+func (suite *LDAPAuthenticationBackendSuite) TestShouldSetDefaultGroupSearch() {
+	ValidateAuthenticationBackend(&suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Assert().Len(suite.validator.Errors(), 0)
+
+	suite.Assert().Equal(schema.LDAPGroupSearchModeFilter, suite.config.LDAP.GroupSearchMode)
+	suite.Assert().Equal(10, suite.config.LDAP.GroupSearchMaxDepth)
+}
+
+func (suite *LDAPAuthenticationBackendSuite) TestShouldRaiseErrorOnInvalidGroupSearch() {
+	suite.config.LDAP.GroupSearchMode = "nested"
+	suite.config.LDAP.GroupSearchMaxDepth = -1
+
+	ValidateAuthenticationBackend(&suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 2)
+
+	suite.Assert().EqualError(suite.validator.Errors()[0], "authentication_backend: ldap: option 'group_search_mode' is configured as 'nested' but must be one of the following values: 'filter', 'recursive', 'in_chain', 'auto'")
+	suite.Assert().EqualError(suite.validator.Errors()[1], "authentication_backend: ldap: option 'group_search_max_depth' must not be negative but it's configured as '-1'")
+}
+
+func (suite *LDAPAuthenticationBackendSuite) TestShouldRaiseErrorOnRecursiveGroupSearchWithoutDNPlaceholder() {
+	suite.config.LDAP.GroupSearchMode = schema.LDAPGroupSearchModeRecursive
+
+	ValidateAuthenticationBackend(&suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 1)
+
+	suite.Assert().EqualError(suite.validator.Errors()[0], "authentication_backend: ldap: option 'groups_filter' must contain the placeholder '{dn}' when option 'group_search_mode' is configured as 'recursive'")
+
+	suite.validator.Clear()
+
+	suite.config.LDAP.GroupsFilter = "(&(member={dn})(objectClass=groupOfNames))"
+
+	ValidateAuthenticationBackend(&suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Errors(), 0)
+}
+
+func (suite *LDAPAuthenticationBackendSuite) TestShouldSetDefaultPooling() {
+	suite.config.LDAP.Pooling = schema.LDAPAuthenticationBackendPoolingConfiguration{Enable: true, Count: 20}
+
+	ValidateAuthenticationBackend(&suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Assert().Len(suite.validator.Errors(), 0)
+
+	suite.Assert().True(suite.config.LDAP.Pooling.Enable)
+	suite.Assert().Equal(20, suite.config.LDAP.Pooling.Count)
+	suite.Assert().Equal(schema.DefaultLDAPAuthenticationBackendConfiguration.Pooling.Retries, suite.config.LDAP.Pooling.Retries)
+	suite.Assert().Equal(schema.DefaultLDAPAuthenticationBackendConfiguration.Pooling.Timeout, suite.config.LDAP.Pooling.Timeout)
+	suite.Assert().Equal(schema.DefaultLDAPAuthenticationBackendConfiguration.Pooling.IdleTimeout, suite.config.LDAP.Pooling.IdleTimeout)
+	suite.Assert().Equal(schema.DefaultLDAPAuthenticationBackendConfiguration.Pooling.HealthCheckInterval, suite.config.LDAP.Pooling.HealthCheckInterval)
+}
+
+func (suite *LDAPAuthenticationBackendSuite) TestShouldAllowDisablingPoolingRetries() {
+	suite.config.LDAP.Pooling = schema.LDAPAuthenticationBackendPoolingConfiguration{Enable: true, Retries: schema.LDAPPoolingRetriesDisabled}
+
+	ValidateAuthenticationBackend(&suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Assert().Len(suite.validator.Errors(), 0)
+
+	suite.Assert().Equal(-1, suite.config.LDAP.Pooling.Retries)
+}
+
+func (suite *LDAPAuthenticationBackendSuite) TestShouldRaiseErrorOnNegativePooling() {
+	suite.config.LDAP.Pooling = schema.LDAPAuthenticationBackendPoolingConfiguration{
+		Enable:              true,
+		Count:               -1,
+		Retries:             -2,
+		Timeout:             -time.Second,
+		IdleTimeout:         -time.Second,
+		HealthCheckInterval: -time.Second,
+	}
+
+	ValidateAuthenticationBackend(&suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 5)
+
+	suite.Assert().EqualError(suite.validator.Errors()[0], "authentication_backend: ldap: pooling: option 'count' must not be negative but it's configured as '-1'")
+	suite.Assert().EqualError(suite.validator.Errors()[1], "authentication_backend: ldap: pooling: option 'retries' must be -1 to disable retries or must not be negative but it's configured as '-2'")
+	suite.Assert().EqualError(suite.validator.Errors()[2], "authentication_backend: ldap: pooling: option 'timeout' must not be negative but it's configured as '-1s'")
+	suite.Assert().EqualError(suite.validator.Errors()[3], "authentication_backend: ldap: pooling: option 'idle_timeout' must not be negative but it's configured as '-1s'")
+	suite.Assert().EqualError(suite.validator.Errors()[4], "authentication_backend: ldap: pooling: option 'health_check_interval' must not be negative but it's configured as '-1s'")
+}
+
 func TestLdapAuthenticationBackend(t *testing.T) {
 	suite.Run(t, new(LDAPAuthenticationBackendSuite))
 }
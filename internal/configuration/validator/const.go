@@ -19,6 +19,11 @@ const (
 	policyOneFactor = "one_factor"
 	policyTwoFactor = "two_factor"
 	policyDeny      = "deny"
+	policyExternal  = "external"
+)
+
+const (
+	prefixSubjectAttribute = "attr:"
 )
 
 // Hashing constants.
@@ -60,14 +65,30 @@ const (
 
 // Authentication Backend Error constants.
 const (
-	errFmtAuthBackendNotConfigured = "authentication_backend: you must ensure either the 'file' or 'ldap' " +
+	errFmtAuthBackendNotConfigured = "authentication_backend: you must ensure either the 'file', 'ldap', or 'sql' " +
 		"authentication backend is configured"
-	errFmtAuthBackendMultipleConfigured = "authentication_backend: please ensure only one of the 'file' or 'ldap' " +
-		"backend is configured"
+	errFmtAuthBackendMultipleConfigured = "authentication_backend: please ensure only one of the 'file', 'ldap', or " +
+		"'sql' backend is configured"
+	errFmtAuthBackendChainInvalidBackend = "authentication_backend: chain: backend #%d: option 'backend' must be one of " +
+		"'file', 'ldap', or 'sql' but it's configured as '%s'"
+	errFmtAuthBackendChainBackendNotConfigured = "authentication_backend: chain: backend #%d: option 'backend' is " +
+		"configured as '%s' but the '%s' authentication backend is not configured"
+	errFmtAuthBackendChainDuplicateBackend = "authentication_backend: chain: backend #%d: option 'backend' is " +
+		"configured as '%s' which is already in the chain"
+	errFmtAuthBackendChainDuplicateQualifier = "authentication_backend: chain: backend #%d: option '%s' is " +
+		"configured as '%s' which is already used by another backend in the chain"
+	errFmtAuthBackendChainMissingBackend = "authentication_backend: chain: the '%s' authentication backend is " +
+		"configured but it's not in the chain"
 	errFmtAuthBackendRefreshInterval = "authentication_backend: option 'refresh_interval' is configured to '%s' but " +
 		"it must be either a duration notation or one of 'disable', or 'always': %w"
 	errFmtAuthBackendPasswordResetCustomURLScheme = "authentication_backend: password_reset: option 'custom_url' is" +
 		" configured to '%s' which has the scheme '%s' but the scheme must be either 'http' or 'https'"
+	errFmtAuthBackendRegistrationBackend = "authentication_backend: registration: option 'enable' requires the " +
+		"'file' or 'sql' authentication backend without a prefix or realm"
+	errFmtAuthBackendRegistrationAllowedDomainInvalid = "authentication_backend: registration: option " +
+		"'allowed_domains' has the value '%s' which is not a valid domain"
+	errFmtAuthBackendCacheNegative = "authentication_backend: cache: option '%s' must not be negative " +
+		"but it's configured as '%v'"
 
 	errFmtFileAuthBackendPathNotConfigured  = "authentication_backend: file: option 'path' is required"
 	errFmtFileAuthBackendPasswordSaltLength = "authentication_backend: file: password: option 'salt_length' " +
@@ -87,6 +108,11 @@ const (
 	errFmtLDAPAuthBackendUnauthenticatedBindWithPassword     = "authentication_backend: ldap: option 'permit_unauthenticated_bind' can't be enabled when a password is specified"
 	errFmtLDAPAuthBackendUnauthenticatedBindWithResetEnabled = "authentication_backend: ldap: option 'permit_unauthenticated_bind' can't be enabled when password reset is enabled"
 
+	errFmtLDAPAuthBackendPoolingNegative = "authentication_backend: ldap: pooling: option '%s' must not be negative " +
+		"but it's configured as '%v'"
+	errFmtLDAPAuthBackendPoolingRetries = "authentication_backend: ldap: pooling: option 'retries' must be -1 to " +
+		"disable retries or must not be negative but it's configured as '%d'"
+
 	errFmtLDAPAuthBackendMissingOption = "authentication_backend: ldap: option '%s' is required"
 	errFmtLDAPAuthBackendTLSMinVersion = "authentication_backend: ldap: tls: option " +
 		"'minimum_tls_version' is invalid: %s: %w"
@@ -102,6 +128,12 @@ const (
 		"'%s' must contain enclosing parenthesis: '%s' should probably be '(%s)'"
 	errFmtLDAPAuthBackendFilterMissingPlaceholder = "authentication_backend: ldap: option " +
 		"'%s' must contain the placeholder '{%s}' but it is required"
+	errFmtLDAPAuthBackendGroupSearchMode = "authentication_backend: ldap: option 'group_search_mode' " +
+		"is configured as '%s' but must be one of the following values: '%s'"
+	errFmtLDAPAuthBackendGroupSearchModeMissingPlaceholder = "authentication_backend: ldap: option " +
+		"'groups_filter' must contain the placeholder '{dn}' when option 'group_search_mode' is configured as '%s'"
+	errFmtLDAPAuthBackendGroupSearchMaxDepth = "authentication_backend: ldap: option 'group_search_max_depth' " +
+		"must not be negative but it's configured as '%d'"
 )
 
 // TOTP Error constants.
@@ -192,19 +224,60 @@ const (
 	errFmtAccessControlRuleNoDomains = "access control: rule %s: rule is invalid: must have the option " +
 		"'domain' or 'domain_regex' configured"
 	errFmtAccessControlRuleInvalidPolicy = "access control: rule %s: rule 'policy' option '%s' " +
-		"is invalid: must be one of 'deny', 'two_factor', 'one_factor' or 'bypass'"
+		"is invalid: must be one of 'deny', 'two_factor', 'one_factor', 'bypass' or 'external'"
 	errAccessControlRuleBypassPolicyInvalidWithSubjects = "access control: rule %s: 'policy' option 'bypass' is " +
 		"not supported when 'subject' option is configured: see " +
 		"https://www.authelia.com/c/acl#bypass"
 	errAccessControlRuleBypassPolicyInvalidWithSubjectsWithGroupDomainRegex = "access control: rule %s: 'policy' option 'bypass' is " +
 		"not supported when 'domain_regex' option contains the user or group named matches. For more information see: " +
 		"https://www.authelia.com/c/acl#bypass-and-user-identity"
+	errAccessControlRuleBypassPolicyInvalidWithSubjectsWithGroupResources = "access control: rule %s: 'policy' option 'bypass' is " +
+		"not supported when 'resources' option contains the user or group named matches. For more information see: " +
+		"https://www.authelia.com/c/acl#bypass-and-user-identity"
+	errFmtAccessControlRuleExternalNotConfigured = "access control: rule %s: 'policy' option 'external' requires " +
+		"the 'external' option 'url' to be configured"
+	errFmtAccessControlExternalURLScheme = "access control: external: option 'url' must have the scheme 'http' or " +
+		"'https' but it is configured as '%s'"
+	errFmtAccessControlExternalFailurePolicy = "access control: external: option 'failure_policy' must be one of '%s' " +
+		"but it is configured as '%s'"
+	errFmtAccessControlRuleShadowed = "access control: rule %s: rule will never be matched as rule %s is " +
+		"evaluated first and matches every request this rule matches"
+	errFmtAccessControlRuleDefaultPolicy = "access control: rule %s: rule has no effect as it applies the same " +
+		"policy as the 'default_policy' option '%s' and no later rule applies a different policy"
+	errFmtAccessControlRuleSubexpNameCase = "access control: rule %s: '%s' option pattern '%s' has the named match " +
+		"'%s' which is not bound to the user identity as named matches are case-sensitive: did you mean 'User' or 'Group'"
 	errFmtAccessControlRuleNetworksInvalid = "access control: rule %s: the network '%s' is not a " +
 		"valid Group Name, IP, or CIDR notation"
+	errFmtAccessControlRuleCountryInvalid = "access control: rule %s: 'countries' option '%s' is " +
+		"invalid: must be a two letter ISO 3166-1 alpha-2 country code such as 'DE'"
+	errFmtAccessControlRuleASNInvalid = "access control: rule %s: 'asns' option '%d' is " +
+		"invalid: must be a positive autonomous system number"
+	errFmtAccessControlRuleLocationNoGeoIP = "access control: rule %s: '%s' option is configured but " +
+		"the 'geoip' option 'path' or 'asn_path' is not configured"
 	errFmtAccessControlRuleSubjectInvalid = "access control: rule %s: 'subject' option '%s' is " +
-		"invalid: must start with 'user:' or 'group:'"
+		"invalid: must start with 'user:', 'group:', or 'attr:' where 'attr:' is followed by the attribute in the format 'name=value'"
+	errFmtAccessControlRuleSubjectAttributeNotConfigured = "access control: rule %s: 'subject' option '%s' " +
+		"will never match as the attribute '%s' is not configured in the 'extra_attributes' option of the authentication backend"
 	errFmtAccessControlRuleMethodInvalid = "access control: rule %s: 'methods' option '%s' is " +
 		"invalid: must be one of '%s'"
+	errFmtAccessControlRuleAuthenticationMethodInvalid = "access control: rule %s: 'authentication_methods' " +
+		"option '%s' is invalid: must be one of '%s'"
+	errFmtAccessControlRuleAuthenticationMethodsPolicy = "access control: rule %s: 'authentication_methods' " +
+		"option is only supported with the 'policy' option 'two_factor' but it's configured as '%s'"
+	errFmtAccessControlRuleMaxAuthenticationAgeNegative = "access control: rule %s: 'max_authentication_age' " +
+		"option '%s' is invalid: must be a positive duration"
+	errFmtAccessControlRuleMaxAuthenticationAgePolicy = "access control: rule %s: 'max_authentication_age' " +
+		"option is only supported with the 'policy' option 'one_factor' or 'two_factor' but it's configured as '%s'"
+	errFmtAccessControlRuleQueryKeyMissing = "access control: rule %s: 'query' option 'key' is " +
+		"required for every condition but one or more conditions are missing it"
+	errFmtAccessControlRuleHeadersNameMissing = "access control: rule %s: 'headers' option 'name' is " +
+		"required for every condition but one or more conditions are missing it"
+	errFmtAccessControlRuleScheduleWeekdayInvalid = "access control: rule %s: 'schedule' option 'weekdays' " +
+		"value '%s' is invalid: must be a weekday name such as 'monday' or 'mon'"
+	errFmtAccessControlRuleScheduleTimeInvalid = "access control: rule %s: 'schedule' option '%s' value '%s' " +
+		"is invalid: must be a time of day in the 24-hour 'HH:MM' format"
+	errFmtAccessControlRuleScheduleTimeZoneInvalid = "access control: rule %s: 'schedule' option 'time_zone' " +
+		"value '%s' is invalid: %w"
 )
 
 // Theme Error constants.
@@ -253,6 +326,8 @@ const (
 	errPasswordPolicyMultipleDefined                        = "password_policy: only a single password policy mechanism can be specified"
 	errFmtPasswordPolicyStandardMinLengthNotGreaterThanZero = "password_policy: standard: option 'min_length' must be greater than 0 but is configured as %d"
 	errFmtPasswordPolicyZXCVBNMinScoreInvalid               = "password_policy: zxcvbn: option 'min_score' is invalid: must be between 1 and 4 but it's configured as %d"
+	errPasswordPolicyBreachedPathNotConfigured              = "password_policy: breached: option 'path' is required"
+	errFmtPasswordPolicyHistoryNegative                     = "password_policy: option 'history' must be 0 or greater but it's configured as %d"
 )
 
 const (
@@ -301,6 +376,10 @@ var validRFC4918HTTPMethodVerbs = []string{"COPY", "LOCK", "MKCOL", "MOVE", "PRO
 
 var validACLHTTPMethodVerbs = append(validRFC7231HTTPMethodVerbs, validRFC4918HTTPMethodVerbs...)
 
+var validLDAPGroupSearchModes = []string{"filter", "recursive", "in_chain", "auto"}
+
+var validAuthBackendChainBackends = []string{"file", "ldap", "sql"}
+
 var validACLRulePolicies = []string{policyBypass, policyOneFactor, policyTwoFactor, policyDeny}
 
 var validDefault2FAMethods = []string{"totp", "webauthn", "mobile_push"}
@@ -313,6 +392,8 @@ var validOIDCCORSEndpoints = []string{oidc.AuthorizationEndpoint, oidc.TokenEndp
 
 var reKeyReplacer = regexp.MustCompile(`\[\d+]`)
 
+var reCountryCode = regexp.MustCompile(`^[a-zA-Z]{2}$`)
+
 var replacedKeys = map[string]string{
 	"authentication_backend.ldap.skip_verify":         "authentication_backend.ldap.tls.skip_verify",
 	"authentication_backend.ldap.minimum_tls_version": "authentication_backend.ldap.tls.minimum_version",
@@ -33,4 +33,12 @@ func ValidatePasswordPolicy(config *schema.PasswordPolicyConfiguration, validato
 			validator.Push(fmt.Errorf(errFmtPasswordPolicyZXCVBNMinScoreInvalid, config.ZXCVBN.MinScore))
 		}
 	}
+
+	if config.Breached.Enabled && config.Breached.Path == "" {
+		validator.Push(fmt.Errorf(errPasswordPolicyBreachedPathNotConfigured))
+	}
+
+	if config.History < 0 {
+		validator.Push(fmt.Errorf(errFmtPasswordPolicyHistoryNegative, config.History))
+	}
 }
@@ -122,6 +122,47 @@ func TestValidatePasswordPolicy(t *testing.T) {
 				"password_policy: zxcvbn: option 'min_score' is invalid: must be between 1 and 4 but it's configured as 5",
 			},
 		},
+		{
+			desc: "ShouldNotRaiseErrorsStandardWithBreached",
+			have: &schema.PasswordPolicyConfiguration{
+				Standard: schema.PasswordPolicyStandardParams{
+					Enabled:   true,
+					MinLength: 8,
+				},
+				Breached: schema.PasswordPolicyBreachedParams{
+					Enabled: true,
+					Path:    "/config/breached.bloom",
+				},
+			},
+			expected: &schema.PasswordPolicyConfiguration{
+				Standard: schema.PasswordPolicyStandardParams{
+					Enabled:   true,
+					MinLength: 8,
+				},
+			},
+		},
+		{
+			desc: "ShouldRaiseErrorsBreachedWithoutPath",
+			have: &schema.PasswordPolicyConfiguration{
+				Breached: schema.PasswordPolicyBreachedParams{
+					Enabled: true,
+				},
+			},
+			expected: &schema.PasswordPolicyConfiguration{},
+			expectedErrs: []string{
+				"password_policy: breached: option 'path' is required",
+			},
+		},
+		{
+			desc: "ShouldRaiseErrorsHistoryNegative",
+			have: &schema.PasswordPolicyConfiguration{
+				History: -1,
+			},
+			expected: &schema.PasswordPolicyConfiguration{},
+			expectedErrs: []string{
+				"password_policy: option 'history' must be 0 or greater but it's configured as -1",
+			},
+		},
 	}
 
 	for _, tc := range testCases {
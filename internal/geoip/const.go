@@ -0,0 +1,39 @@
+package geoip
+
+import (
+	"errors"
+)
+
+// ErrDatabaseNotLoaded is returned when a lookup is attempted before the databases have been loaded.
+var ErrDatabaseNotLoaded = errors.New("the geoip database has not been loaded")
+
+// mmdbMetadataMarker is the marker which precedes the metadata at the end of a MaxMind DB database.
+var mmdbMetadataMarker = []byte("\xAB\xCD\xEFMaxMind.com")
+
+const (
+	// mmdbDataSectionSeparatorSize is the number of zero bytes between the search tree and the data section.
+	mmdbDataSectionSeparatorSize = 16
+
+	// mmdbMaxDepth is the maximum depth of nested maps, arrays, and pointers which is decoded.
+	mmdbMaxDepth = 32
+)
+
+// The data types of the MaxMind DB format.
+const (
+	mmdbTypeExtended byte = iota
+	mmdbTypePointer
+	mmdbTypeString
+	mmdbTypeDouble
+	mmdbTypeBytes
+	mmdbTypeUint16
+	mmdbTypeUint32
+	mmdbTypeMap
+	mmdbTypeInt32
+	mmdbTypeUint64
+	mmdbTypeUint128
+	mmdbTypeArray
+	mmdbTypeContainer
+	mmdbTypeEndMarker
+	mmdbTypeBoolean
+	mmdbTypeFloat
+)
@@ -0,0 +1,84 @@
+package geoip
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+// NewMaxMindProvider returns a new MaxMindProvider given a configuration. The databases are loaded by the
+// StartupCheck.
+func NewMaxMindProvider(config *schema.GeoIPConfiguration) (provider *MaxMindProvider) {
+	return &MaxMindProvider{
+		config: config,
+	}
+}
+
+// MaxMindProvider is a Provider which uses local databases in the MaxMind DB format, for example the GeoLite2 or
+// GeoIP2 Country, City, and ASN databases. The databases are read into memory.
+type MaxMindProvider struct {
+	config *schema.GeoIPConfiguration
+
+	database *mmdbReader
+	asn      *mmdbReader
+}
+
+// StartupCheck implements the startup check provider interface.
+func (p *MaxMindProvider) StartupCheck() (err error) {
+	if p.config.Path != "" {
+		if p.database, err = openMMDB(p.config.Path); err != nil {
+			return fmt.Errorf("error opening the geoip database '%s': %w", p.config.Path, err)
+		}
+	}
+
+	if p.config.ASNPath != "" {
+		if p.asn, err = openMMDB(p.config.ASNPath); err != nil {
+			return fmt.Errorf("error opening the geoip asn database '%s': %w", p.config.ASNPath, err)
+		}
+	}
+
+	return nil
+}
+
+// Lookup the location of the IP address. The values of the location which are not available in the databases are left
+// empty, and an IP address which is not in the databases results in an empty location.
+func (p *MaxMindProvider) Lookup(ip net.IP) (location Location, err error) {
+	if p.database == nil && p.asn == nil {
+		return location, ErrDatabaseNotLoaded
+	}
+
+	if ip == nil {
+		return location, nil
+	}
+
+	var (
+		r    record
+		data map[string]interface{}
+	)
+
+	if p.database != nil {
+		if data, err = p.database.Lookup(ip); err != nil {
+			return location, fmt.Errorf("error looking up '%s' in the geoip database: %w", ip, err)
+		}
+
+		r.decode(data)
+	}
+
+	if p.asn != nil {
+		if data, err = p.asn.Lookup(ip); err != nil {
+			return location, fmt.Errorf("error looking up '%s' in the geoip asn database: %w", ip, err)
+		}
+
+		r.decode(data)
+	}
+
+	return r.Location(), nil
+}
+
+// Close the databases.
+func (p *MaxMindProvider) Close() (err error) {
+	p.database, p.asn = nil, nil
+
+	return nil
+}
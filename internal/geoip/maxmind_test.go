@@ -0,0 +1,46 @@
+package geoip
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestShouldErrorOnLookupWhenNotLoaded(t *testing.T) {
+	provider := NewMaxMindProvider(&schema.GeoIPConfiguration{Path: "/tmp/GeoLite2-Country.mmdb"})
+
+	location, err := provider.Lookup(net.ParseIP("127.0.0.1"))
+
+	assert.Equal(t, Location{}, location)
+	assert.EqualError(t, err, "the geoip database has not been loaded")
+}
+
+func TestShouldErrorOnStartupCheckWhenDatabaseMissing(t *testing.T) {
+	dir := t.TempDir()
+
+	provider := NewMaxMindProvider(&schema.GeoIPConfiguration{Path: dir + "/GeoLite2-Country.mmdb"})
+
+	err := provider.StartupCheck()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "error opening the geoip database '"+dir+"/GeoLite2-Country.mmdb'")
+
+	provider = NewMaxMindProvider(&schema.GeoIPConfiguration{ASNPath: dir + "/GeoLite2-ASN.mmdb"})
+
+	err = provider.StartupCheck()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "error opening the geoip asn database '"+dir+"/GeoLite2-ASN.mmdb'")
+}
+
+func TestShouldConvertRecordToLocation(t *testing.T) {
+	r := record{Country: "DE", RegisteredCountry: "US", AutonomousSystemNumber: 3320, AutonomousSystemOrganization: "Deutsche Telekom AG"}
+
+	assert.Equal(t, Location{Country: "DE", ASN: 3320, Organization: "Deutsche Telekom AG"}, r.Location())
+
+	r.Country = ""
+
+	assert.Equal(t, Location{Country: "US", ASN: 3320, Organization: "Deutsche Telekom AG"}, r.Location())
+}
@@ -0,0 +1,403 @@
+package geoip
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"net"
+	"os"
+)
+
+// openMMDB reads the database in the MaxMind DB format at the path into memory.
+func openMMDB(path string) (reader *mmdbReader, err error) {
+	var buffer []byte
+
+	if buffer, err = os.ReadFile(path); err != nil {
+		return nil, err
+	}
+
+	return newMMDBReader(buffer)
+}
+
+// newMMDBReader returns a mmdbReader for a database in the MaxMind DB format which is documented at
+// https://maxmind.github.io/MaxMind-DB/.
+func newMMDBReader(buffer []byte) (reader *mmdbReader, err error) {
+	i := bytes.LastIndex(buffer, mmdbMetadataMarker)
+	if i == -1 {
+		return nil, errors.New("the file is not a MaxMind DB database as the metadata could not be found")
+	}
+
+	decoder := mmdbDecoder{buffer: buffer[i+len(mmdbMetadataMarker):]}
+
+	value, _, err := decoder.decode(0, 0)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding the metadata: %w", err)
+	}
+
+	metadata, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("error decoding the metadata: the metadata is not a map")
+	}
+
+	reader = &mmdbReader{
+		nodeCount:  mmdbUint(metadata["node_count"]),
+		recordSize: mmdbUint(metadata["record_size"]),
+		ipVersion:  mmdbUint(metadata["ip_version"]),
+	}
+
+	if version := mmdbUint(metadata["binary_format_major_version"]); version != 2 {
+		return nil, fmt.Errorf("the binary format major version '%d' is not supported", version)
+	}
+
+	if reader.recordSize != 24 && reader.recordSize != 28 && reader.recordSize != 32 {
+		return nil, fmt.Errorf("the record size '%d' is not supported", reader.recordSize)
+	}
+
+	if reader.ipVersion != 4 && reader.ipVersion != 6 {
+		return nil, fmt.Errorf("the ip version '%d' is not supported", reader.ipVersion)
+	}
+
+	reader.nodeSize = reader.recordSize / 4
+
+	treeSize := reader.nodeCount * reader.nodeSize
+
+	if treeSize+mmdbDataSectionSeparatorSize > uint(i) {
+		return nil, errors.New("the search tree is larger than the database")
+	}
+
+	reader.tree = buffer[:treeSize]
+	reader.data = buffer[treeSize+mmdbDataSectionSeparatorSize : i]
+
+	// IPv4 addresses are stored in IPv6 databases as IPv4-compatible addresses, i.e. after 96 zero bits.
+	if reader.ipVersion == 6 {
+		for j := 0; j < 96 && reader.ipv4Start < reader.nodeCount; j++ {
+			if reader.ipv4Start, err = reader.readNode(reader.ipv4Start, 0); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return reader, nil
+}
+
+// mmdbReader reads records from a database in the MaxMind DB format which is held in memory.
+type mmdbReader struct {
+	tree, data []byte
+
+	nodeCount, nodeSize, recordSize, ipVersion uint
+
+	ipv4Start uint
+}
+
+// Lookup returns the record of the network the IP address belongs to, or nil if the network isn't in the database.
+func (r *mmdbReader) Lookup(ip net.IP) (record map[string]interface{}, err error) {
+	var (
+		node uint
+		bits int
+	)
+
+	if ip4 := ip.To4(); ip4 != nil {
+		ip, node, bits = ip4, r.ipv4Start, 32
+	} else {
+		if r.ipVersion == 4 {
+			return nil, fmt.Errorf("the IPv6 address '%s' can't be looked up in an IPv4 only database", ip)
+		}
+
+		ip, bits = ip.To16(), 128
+	}
+
+	for i := 0; i < bits && node < r.nodeCount; i++ {
+		if node, err = r.readNode(node, uint(ip[i>>3]>>(7-uint(i%8)))&1); err != nil {
+			return nil, err
+		}
+	}
+
+	switch {
+	case node == r.nodeCount:
+		return nil, nil
+	case node < r.nodeCount:
+		return nil, errors.New("the search tree is invalid")
+	}
+
+	offset := node - r.nodeCount - mmdbDataSectionSeparatorSize
+
+	decoder := mmdbDecoder{buffer: r.data}
+
+	value, _, err := decoder.decode(offset, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var ok bool
+
+	if record, ok = value.(map[string]interface{}); !ok {
+		return nil, errors.New("the record is not a map")
+	}
+
+	return record, nil
+}
+
+// readNode returns the left (bit 0) or right (bit 1) record of the node.
+func (r *mmdbReader) readNode(node, bit uint) (record uint, err error) {
+	offset := node * r.nodeSize
+
+	if offset+r.nodeSize > uint(len(r.tree)) {
+		return 0, errors.New("the search tree is invalid")
+	}
+
+	b := r.tree[offset : offset+r.nodeSize]
+
+	switch r.recordSize {
+	case 24:
+		b = b[bit*3:]
+
+		return uint(b[0])<<16 | uint(b[1])<<8 | uint(b[2]), nil
+	case 28:
+		if bit == 0 {
+			return uint(b[3]&0xF0)<<20 | uint(b[0])<<16 | uint(b[1])<<8 | uint(b[2]), nil
+		}
+
+		return uint(b[3]&0x0F)<<24 | uint(b[4])<<16 | uint(b[5])<<8 | uint(b[6]), nil
+	default:
+		b = b[bit*4:]
+
+		return uint(b[0])<<24 | uint(b[1])<<16 | uint(b[2])<<8 | uint(b[3]), nil
+	}
+}
+
+// mmdbDecoder decodes the values of the data section or the metadata of a database in the MaxMind DB format.
+type mmdbDecoder struct {
+	buffer []byte
+}
+
+// decode the value at the offset, returning the value and the offset following the value.
+func (d *mmdbDecoder) decode(offset uint, depth int) (value interface{}, next uint, err error) {
+	if depth > mmdbMaxDepth {
+		return nil, 0, errors.New("the data is nested too deeply")
+	}
+
+	var b []byte
+
+	if b, offset, err = d.read(offset, 1); err != nil {
+		return nil, 0, err
+	}
+
+	ctrl := b[0]
+	kind := ctrl >> 5
+
+	if kind == mmdbTypePointer {
+		var pointer uint
+
+		if pointer, next, err = d.pointer(ctrl, offset); err != nil {
+			return nil, 0, err
+		}
+
+		value, _, err = d.decode(pointer, depth+1)
+
+		return value, next, err
+	}
+
+	if kind == mmdbTypeExtended {
+		if b, offset, err = d.read(offset, 1); err != nil {
+			return nil, 0, err
+		}
+
+		kind = 7 + b[0]
+	}
+
+	var size uint
+
+	if size, offset, err = d.size(ctrl, offset); err != nil {
+		return nil, 0, err
+	}
+
+	// Every entry of a map or an array is at least one byte so the size can't exceed the size of the data.
+	if (kind == mmdbTypeMap || kind == mmdbTypeArray) && size > uint(len(d.buffer)) {
+		return nil, 0, errors.New("unexpected end of the data")
+	}
+
+	switch kind {
+	case mmdbTypeString:
+		if b, next, err = d.read(offset, size); err != nil {
+			return nil, 0, err
+		}
+
+		return string(b), next, nil
+	case mmdbTypeBytes:
+		if b, next, err = d.read(offset, size); err != nil {
+			return nil, 0, err
+		}
+
+		return append([]byte(nil), b...), next, nil
+	case mmdbTypeDouble, mmdbTypeFloat:
+		if (kind == mmdbTypeDouble && size != 8) || (kind == mmdbTypeFloat && size != 4) {
+			return nil, 0, fmt.Errorf("the floating point value has the invalid size '%d'", size)
+		}
+
+		if b, next, err = d.read(offset, size); err != nil {
+			return nil, 0, err
+		}
+
+		if kind == mmdbTypeFloat {
+			return float64(math.Float32frombits(uint32(mmdbUintFromBytes(b)))), next, nil
+		}
+
+		return math.Float64frombits(mmdbUintFromBytes(b)), next, nil
+	case mmdbTypeUint16, mmdbTypeUint32, mmdbTypeInt32, mmdbTypeUint64:
+		if size > 8 {
+			return nil, 0, fmt.Errorf("the integer value has the invalid size '%d'", size)
+		}
+
+		if b, next, err = d.read(offset, size); err != nil {
+			return nil, 0, err
+		}
+
+		if kind == mmdbTypeInt32 {
+			return int32(uint32(mmdbUintFromBytes(b))), next, nil
+		}
+
+		return mmdbUintFromBytes(b), next, nil
+	case mmdbTypeUint128:
+		if b, next, err = d.read(offset, size); err != nil {
+			return nil, 0, err
+		}
+
+		return new(big.Int).SetBytes(b), next, nil
+	case mmdbTypeBoolean:
+		return size != 0, offset, nil
+	case mmdbTypeMap:
+		m := make(map[string]interface{}, size)
+
+		var key interface{}
+
+		for i := uint(0); i < size; i++ {
+			if key, offset, err = d.decode(offset, depth+1); err != nil {
+				return nil, 0, err
+			}
+
+			k, ok := key.(string)
+			if !ok {
+				return nil, 0, errors.New("the map has a key which is not a string")
+			}
+
+			if m[k], offset, err = d.decode(offset, depth+1); err != nil {
+				return nil, 0, err
+			}
+		}
+
+		return m, offset, nil
+	case mmdbTypeArray:
+		a := make([]interface{}, size)
+
+		for i := range a {
+			if a[i], offset, err = d.decode(offset, depth+1); err != nil {
+				return nil, 0, err
+			}
+		}
+
+		return a, offset, nil
+	default:
+		return nil, 0, fmt.Errorf("the data type '%d' is not supported", kind)
+	}
+}
+
+// pointer returns the offset the pointer with the control byte refers to and the offset following the pointer.
+func (d *mmdbDecoder) pointer(ctrl byte, offset uint) (pointer, next uint, err error) {
+	ss := uint(ctrl>>3) & 0x3
+
+	var b []byte
+
+	if b, next, err = d.read(offset, ss+1); err != nil {
+		return 0, 0, err
+	}
+
+	vvv := uint(ctrl & 0x7)
+
+	switch ss {
+	case 0:
+		pointer = vvv<<8 | uint(b[0])
+	case 1:
+		pointer = (vvv<<16 | uint(b[0])<<8 | uint(b[1])) + 2048
+	case 2:
+		pointer = (vvv<<24 | uint(b[0])<<16 | uint(b[1])<<8 | uint(b[2])) + 526336
+	default:
+		pointer = uint(mmdbUintFromBytes(b))
+	}
+
+	return pointer, next, nil
+}
+
+// size returns the size of the value with the control byte and the offset following the size.
+func (d *mmdbDecoder) size(ctrl byte, offset uint) (size, next uint, err error) {
+	size = uint(ctrl & 0x1f)
+
+	if size < 29 {
+		return size, offset, nil
+	}
+
+	var b []byte
+
+	if b, next, err = d.read(offset, size-28); err != nil {
+		return 0, 0, err
+	}
+
+	switch size {
+	case 29:
+		size = 29 + uint(b[0])
+	case 30:
+		size = 285 + uint(mmdbUintFromBytes(b))
+	default:
+		size = 65821 + uint(mmdbUintFromBytes(b))
+	}
+
+	return size, next, nil
+}
+
+// read returns n bytes at the offset and the offset following them.
+func (d *mmdbDecoder) read(offset, n uint) (b []byte, next uint, err error) {
+	if next = offset + n; next > uint(len(d.buffer)) || next < offset {
+		return nil, 0, errors.New("unexpected end of the data")
+	}
+
+	return d.buffer[offset:next], next, nil
+}
+
+func mmdbUintFromBytes(b []byte) (value uint64) {
+	for _, x := range b {
+		value = value<<8 | uint64(x)
+	}
+
+	return value
+}
+
+func mmdbUint(value interface{}) uint {
+	if v, ok := value.(uint64); ok {
+		return uint(v)
+	}
+
+	return 0
+}
+
+func mmdbString(record map[string]interface{}, keys ...string) string {
+	for i, key := range keys {
+		value, ok := record[key]
+
+		if !ok {
+			return ""
+		}
+
+		if i == len(keys)-1 {
+			s, _ := value.(string)
+
+			return s
+		}
+
+		if record, ok = value.(map[string]interface{}); !ok {
+			return ""
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,122 @@
+package geoip
+
+import (
+	"bytes"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func mmdbTestString(value string) []byte {
+	if len(value) < 29 {
+		return append([]byte{mmdbTypeString<<5 | byte(len(value))}, value...)
+	}
+
+	return append([]byte{mmdbTypeString<<5 | 29, byte(len(value) - 29)}, value...)
+}
+
+func mmdbTestUint(kind byte, value ...byte) []byte {
+	return append([]byte{kind<<5 | byte(len(value))}, value...)
+}
+
+func mmdbTestMap(entries ...[]byte) (b []byte) {
+	b = []byte{mmdbTypeMap<<5 | byte(len(entries)/2)}
+
+	for _, entry := range entries {
+		b = append(b, entry...)
+	}
+
+	return b
+}
+
+// mmdbTestDatabase returns an IPv4 database with a record size of 24 bits where 1.0.0.0/8 has a record.
+func mmdbTestDatabase() (database []byte) {
+	const nodeCount = 8
+
+	// The country is stored first and referred to by a pointer from the record.
+	country := mmdbTestMap(mmdbTestString("iso_code"), mmdbTestString("DE"))
+
+	data := append([]byte{}, country...)
+	recordOffset := len(data)
+
+	data = append(data, mmdbTestMap(
+		mmdbTestString("country"), []byte{mmdbTypePointer << 5, 0},
+		mmdbTestString("autonomous_system_number"), mmdbTestUint(mmdbTypeUint32, 0xFA, 0x56, 0xEA, 0x00),
+		mmdbTestString("autonomous_system_organization"), mmdbTestString("Example"),
+		mmdbTestString("is_anycast"), []byte{mmdbTypeExtended<<5 | 1, mmdbTypeBoolean - 7},
+	)...)
+
+	// The first address of 1.0.0.0/8 has the bits 00000001, each node follows the left record for a 0 bit except the
+	// last node which follows the right record for the 1 bit.
+	for node := 0; node < nodeCount; node++ {
+		left, right := node+1, nodeCount
+
+		if node == nodeCount-1 {
+			left, right = nodeCount, nodeCount+mmdbDataSectionSeparatorSize+recordOffset
+		}
+
+		database = append(database, byte(left>>16), byte(left>>8), byte(left), byte(right>>16), byte(right>>8), byte(right))
+	}
+
+	database = append(database, make([]byte, mmdbDataSectionSeparatorSize)...)
+	database = append(database, data...)
+	database = append(database, mmdbMetadataMarker...)
+
+	return append(database, mmdbTestMap(
+		mmdbTestString("binary_format_major_version"), mmdbTestUint(mmdbTypeUint16, 2),
+		mmdbTestString("node_count"), mmdbTestUint(mmdbTypeUint32, nodeCount),
+		mmdbTestString("record_size"), mmdbTestUint(mmdbTypeUint16, 24),
+		mmdbTestString("ip_version"), mmdbTestUint(mmdbTypeUint16, 4),
+	)...)
+}
+
+func TestShouldLookupMaxMindDatabase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "GeoLite2-Country.mmdb")
+
+	require.NoError(t, os.WriteFile(path, mmdbTestDatabase(), 0600))
+
+	provider := NewMaxMindProvider(&schema.GeoIPConfiguration{Path: path})
+
+	require.NoError(t, provider.StartupCheck())
+
+	location, err := provider.Lookup(net.ParseIP("1.2.3.4"))
+	require.NoError(t, err)
+	assert.Equal(t, Location{Country: "DE", ASN: 4200000000, Organization: "Example"}, location)
+
+	location, err = provider.Lookup(net.ParseIP("2.2.3.4"))
+	require.NoError(t, err)
+	assert.Equal(t, Location{}, location)
+
+	_, err = provider.Lookup(net.ParseIP("2001:db8::1"))
+	assert.EqualError(t, err, "error looking up '2001:db8::1' in the geoip database: the IPv6 address '2001:db8::1' can't be looked up in an IPv4 only database")
+
+	assert.NoError(t, provider.Close())
+}
+
+func TestShouldErrorOnInvalidMaxMindDatabase(t *testing.T) {
+	database := mmdbTestDatabase()
+
+	testCases := []struct {
+		name     string
+		have     []byte
+		expected string
+	}{
+		{"ShouldErrNoMetadata", []byte("abc"), "the file is not a MaxMind DB database as the metadata could not be found"},
+		{"ShouldErrTruncatedMetadata", database[:len(database)-3], "error decoding the metadata: unexpected end of the data"},
+		{"ShouldErrTreeTooLarge", database[bytes.LastIndex(database, mmdbMetadataMarker):], "the search tree is larger than the database"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := newMMDBReader(tc.have)
+
+			assert.EqualError(t, err, tc.expected)
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package geoip
+
+import (
+	"net"
+
+	"github.com/authelia/authelia/v4/internal/model"
+)
+
+// Provider is the interface used to lookup the location of an IP address.
+type Provider interface {
+	model.StartupCheck
+
+	Lookup(ip net.IP) (location Location, err error)
+}
+
+// Location represents the location of an IP address.
+type Location struct {
+	Country      string
+	ASN          uint
+	Organization string
+}
+
+// record represents the fields of a MaxMind format database record which are relevant to Authelia. The country fields
+// are present in the City and Country databases, and the autonomous system fields are present in the ASN databases.
+type record struct {
+	Country           string
+	RegisteredCountry string
+
+	AutonomousSystemNumber       uint
+	AutonomousSystemOrganization string
+}
+
+// decode sets the fields of the record which are present in the decoded MaxMind format database record, leaving the
+// other fields unchanged so the records of several databases can be combined.
+func (r *record) decode(data map[string]interface{}) {
+	if value := mmdbString(data, "country", "iso_code"); value != "" {
+		r.Country = value
+	}
+
+	if value := mmdbString(data, "registered_country", "iso_code"); value != "" {
+		r.RegisteredCountry = value
+	}
+
+	if value := mmdbUint(data["autonomous_system_number"]); value != 0 {
+		r.AutonomousSystemNumber = value
+	}
+
+	if value := mmdbString(data, "autonomous_system_organization"); value != "" {
+		r.AutonomousSystemOrganization = value
+	}
+}
+
+// Location returns the record as a Location, falling back to the registered country when the country is unknown
+// which is generally the case for anycast and satellite providers.
+func (r record) Location() (location Location) {
+	location = Location{
+		Country:      r.Country,
+		ASN:          r.AutonomousSystemNumber,
+		Organization: r.AutonomousSystemOrganization,
+	}
+
+	if location.Country == "" {
+		location.Country = r.RegisteredCountry
+	}
+
+	return location
+}
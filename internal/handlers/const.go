@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"regexp"
 	"time"
 
 	"github.com/valyala/fasthttp"
@@ -15,6 +16,9 @@ const (
 
 	// ActionResetPassword is the string representation of the action for which the token has been produced.
 	ActionResetPassword = "ResetPassword"
+
+	// ActionRegistration is the string representation of the action for which the token has been produced.
+	ActionRegistration = "Registration"
 )
 
 var (
@@ -28,6 +32,12 @@ var (
 	headerRemoteEmail     = []byte("Remote-Email")
 )
 
+// headerRemoteAttributePrefix is the prefix of the forwarded headers of the extra attributes of the user.
+const headerRemoteAttributePrefix = "Remote-Attribute-"
+
+// reHeaderAttributeName matches the names of extra attributes which can be used in the name of a forwarded header.
+var reHeaderAttributeName = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
 const (
 	// Forbidden means the user is forbidden the access to a resource.
 	Forbidden authorizationMatching = iota
@@ -45,12 +55,27 @@ const (
 	messageUnableToResetPassword           = "Unable to reset your password."
 	messageMFAValidationFailed             = "Authentication failed, please retry later."
 	messagePasswordWeak                    = "Your supplied password does not meet the password policy requirements"
+	messagePasswordBreached                = "Your supplied password has appeared in a data breach and can't be used"
+	messagePasswordReused                  = "Your supplied password has been used previously and can't be used again"
+	messageUnableToRegister                = "Unable to register your account."
+	messageRegistrationUsernameUnavailable = "The username is not available."
+	messageRegistrationEmailNotAllowed     = "Registration is not allowed with this email address."
+
+	messageAuthenticationPasswordExpired        = "Your password has expired. Contact your administrator."
+	messageAuthenticationPasswordChangeRequired = "Your password must be changed before you can sign in."
+	messageAuthenticationAccountLocked          = "Your account is locked. Contact your administrator."
+	messageAuthenticationAccountDisabled        = "Your account is disabled. Contact your administrator."
 )
 
 const (
 	workflowOpenIDConnect = "openid_connect"
 )
 
+// reRegistrationUsername matches the usernames which can be chosen during self-service registration. The usernames
+// can't contain the characters used by the prefixes and realms of the chain of authentication backends and are limited
+// to the length of the username column of the SQL authentication backend.
+var reRegistrationUsername = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]{0,99}$`)
+
 const (
 	logFmtErrParseRequestBody     = "Failed to parse %s request body: %+v"
 	logFmtErrWriteResponseBody    = "Failed to write %s response body for user '%s': %+v"
@@ -2,8 +2,10 @@ package handlers
 
 import (
 	"errors"
+	"strings"
 	"time"
 
+	"github.com/authelia/authelia/v4/internal/authentication"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/middlewares"
 	"github.com/authelia/authelia/v4/internal/regulation"
@@ -53,7 +55,7 @@ func FirstFactorPOST(delayFunc middlewares.TimingAttackDelayFunc) middlewares.Re
 		if err != nil {
 			_ = markAuthenticationAttempt(ctx, false, nil, bodyJSON.Username, regulation.AuthType1FA, err)
 
-			respondUnauthorized(ctx, messageAuthenticationFailed)
+			respondFirstFactorPasswordError(ctx, bodyJSON.Username, err)
 
 			return
 		}
@@ -119,7 +121,13 @@ func FirstFactorPOST(delayFunc middlewares.TimingAttackDelayFunc) middlewares.Re
 
 		ctx.Logger.Tracef(logFmtTraceProfileDetails, bodyJSON.Username, userDetails.Groups, userDetails.Emails)
 
-		userSession.SetOneFactor(ctx.Clock.Now(), userDetails, keepMeLoggedIn)
+		// The user of the session completing the first factor again, for example because a rule requires a recent
+		// authentication, only proves the first factor again and retains the second factor of the session.
+		if userSession.AuthenticationLevel >= authentication.OneFactor && strings.EqualFold(userSession.Username, userDetails.Username) {
+			userSession.SetOneFactorReauthenticated(ctx.Clock.Now(), userDetails, keepMeLoggedIn)
+		} else {
+			userSession.SetOneFactor(ctx.Clock.Now(), userDetails, keepMeLoggedIn)
+		}
 
 		if refresh, refreshInterval := getProfileRefreshSettings(ctx.Configuration.AuthenticationBackend); refresh {
 			userSession.RefreshTTL = ctx.Clock.Now().Add(refreshInterval)
@@ -138,7 +146,42 @@ func FirstFactorPOST(delayFunc middlewares.TimingAttackDelayFunc) middlewares.Re
 		if bodyJSON.Workflow == workflowOpenIDConnect {
 			handleOIDCWorkflowResponse(ctx, bodyJSON.TargetURL)
 		} else {
-			Handle1FAResponse(ctx, bodyJSON.TargetURL, bodyJSON.RequestMethod, userSession.Username, userSession.Groups)
+			Handle1FAResponse(ctx, bodyJSON.TargetURL, bodyJSON.RequestMethod, userSession.Username, userSession.Groups, userSession.Attributes)
+		}
+	}
+}
+
+// respondFirstFactorPasswordError responds to a failed password check with a distinct message for the password policy
+// states reported by the authentication backend. When the password is correct but has expired or must be changed the
+// session is flagged the same way as a completed identity verification so the user can set a new password using the
+// reset password endpoint.
+func respondFirstFactorPasswordError(ctx *middlewares.AutheliaCtx, username string, err error) {
+	switch {
+	case errors.Is(err, authentication.ErrPasswordExpired), errors.Is(err, authentication.ErrPasswordMustChange):
+		if ctx.Configuration.AuthenticationBackend.PasswordReset.Disable {
+			respondUnauthorized(ctx, messageAuthenticationPasswordExpired)
+
+			return
+		}
+
+		userSession := ctx.GetSession()
+
+		userSession.PasswordResetUsername = &username
+
+		if err = ctx.SaveSession(userSession); err != nil {
+			ctx.Logger.Errorf(logFmtErrSessionSave, "password change requirement", regulation.AuthType1FA, username, err)
+
+			respondUnauthorized(ctx, messageAuthenticationFailed)
+
+			return
 		}
+
+		respondUnauthorized(ctx, messageAuthenticationPasswordChangeRequired)
+	case errors.Is(err, authentication.ErrAccountLocked):
+		respondUnauthorized(ctx, messageAuthenticationAccountLocked)
+	case errors.Is(err, authentication.ErrAccountDisabled):
+		respondUnauthorized(ctx, messageAuthenticationAccountDisabled)
+	default:
+		respondUnauthorized(ctx, messageAuthenticationFailed)
 	}
 }
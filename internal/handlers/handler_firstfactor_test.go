@@ -3,9 +3,11 @@ package handlers
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/authelia/authelia/v4/internal/authentication"
@@ -129,6 +131,90 @@ func (s *FirstFactorSuite) TestShouldCheckAuthenticationIsMarkedWhenInvalidCrede
 	FirstFactorPOST(nil)(s.mock.Ctx)
 }
 
+func (s *FirstFactorSuite) TestShouldRespondWithPasswordPolicyErrors() {
+	testCases := []struct {
+		err      error
+		expected string
+	}{
+		{authentication.ErrAccountLocked, "Your account is locked. Contact your administrator."},
+		{authentication.ErrAccountDisabled, "Your account is disabled. Contact your administrator."},
+		{fmt.Errorf("authentication failed. Cause: %w", authentication.ErrAccountLocked), "Your account is locked. Contact your administrator."},
+	}
+
+	for _, tc := range testCases {
+		s.mock.Close()
+		s.mock = mocks.NewMockAutheliaCtx(s.T())
+
+		s.mock.UserProviderMock.
+			EXPECT().
+			CheckUserPassword(gomock.Eq("test"), gomock.Eq("hello")).
+			Return(false, tc.err)
+
+		s.mock.StorageMock.
+			EXPECT().
+			AppendAuthenticationLog(s.mock.Ctx, gomock.Any())
+
+		s.mock.Ctx.Request.SetBodyString(`{
+			"username": "test",
+			"password": "hello"
+		}`)
+
+		FirstFactorPOST(nil)(s.mock.Ctx)
+
+		s.mock.Assert401KO(s.T(), tc.expected)
+		assert.Nil(s.T(), s.mock.Ctx.GetSession().PasswordResetUsername)
+	}
+}
+
+func (s *FirstFactorSuite) TestShouldRequirePasswordChangeWhenPasswordExpired() {
+	s.mock.UserProviderMock.
+		EXPECT().
+		CheckUserPassword(gomock.Eq("test"), gomock.Eq("hello")).
+		Return(false, authentication.ErrPasswordExpired)
+
+	s.mock.StorageMock.
+		EXPECT().
+		AppendAuthenticationLog(s.mock.Ctx, gomock.Any())
+
+	s.mock.Ctx.Request.SetBodyString(`{
+		"username": "test",
+		"password": "hello"
+	}`)
+
+	FirstFactorPOST(nil)(s.mock.Ctx)
+
+	s.mock.Assert401KO(s.T(), "Your password must be changed before you can sign in.")
+
+	userSession := s.mock.Ctx.GetSession()
+
+	require.NotNil(s.T(), userSession.PasswordResetUsername)
+	assert.Equal(s.T(), "test", *userSession.PasswordResetUsername)
+	assert.Equal(s.T(), "", userSession.Username)
+}
+
+func (s *FirstFactorSuite) TestShouldNotRequirePasswordChangeWhenPasswordResetDisabled() {
+	s.mock.Ctx.Configuration.AuthenticationBackend.PasswordReset.Disable = true
+
+	s.mock.UserProviderMock.
+		EXPECT().
+		CheckUserPassword(gomock.Eq("test"), gomock.Eq("hello")).
+		Return(false, authentication.ErrPasswordMustChange)
+
+	s.mock.StorageMock.
+		EXPECT().
+		AppendAuthenticationLog(s.mock.Ctx, gomock.Any())
+
+	s.mock.Ctx.Request.SetBodyString(`{
+		"username": "test",
+		"password": "hello"
+	}`)
+
+	FirstFactorPOST(nil)(s.mock.Ctx)
+
+	s.mock.Assert401KO(s.T(), "Your password has expired. Contact your administrator.")
+	assert.Nil(s.T(), s.mock.Ctx.GetSession().PasswordResetUsername)
+}
+
 func (s *FirstFactorSuite) TestShouldFailIfUserProviderGetDetailsFail() {
 	s.mock.UserProviderMock.
 		EXPECT().
@@ -303,6 +389,133 @@ func (s *FirstFactorSuite) TestShouldSaveUsernameFromAuthenticationBackendInSess
 	assert.Equal(s.T(), []string{"dev", "admins"}, session.Groups)
 }
 
+func (s *FirstFactorSuite) TestShouldRetainSecondFactorWhenReauthenticatingFirstFactor() {
+	userSession := s.mock.Ctx.GetSession()
+	userSession.Username = "test"
+	userSession.AuthenticationLevel = authentication.TwoFactor
+	userSession.FirstFactorAuthnTimestamp = s.mock.Clock.Now().Add(-time.Hour).Unix()
+	userSession.SecondFactorAuthnTimestamp = s.mock.Clock.Now().Add(-time.Minute * 59).Unix()
+	userSession.AuthenticationMethodRefs.UsernameAndPassword = true
+	userSession.AuthenticationMethodRefs.TOTP = true
+
+	require.NoError(s.T(), s.mock.Ctx.SaveSession(userSession))
+
+	s.mock.UserProviderMock.
+		EXPECT().
+		CheckUserPassword(gomock.Eq("test"), gomock.Eq("hello")).
+		Return(true, nil)
+
+	s.mock.UserProviderMock.
+		EXPECT().
+		GetDetails(gomock.Eq("test")).
+		Return(&authentication.UserDetails{
+			Username: "test",
+			Emails:   []string{"test@example.com"},
+			Groups:   []string{"dev", "admins"},
+		}, nil)
+
+	s.mock.StorageMock.
+		EXPECT().
+		AppendAuthenticationLog(s.mock.Ctx, gomock.Any()).
+		Return(nil)
+
+	s.mock.Ctx.Request.SetBodyString(`{
+		"username": "test",
+		"password": "hello",
+		"requestMethod": "GET"
+	}`)
+	FirstFactorPOST(nil)(s.mock.Ctx)
+
+	assert.Equal(s.T(), 200, s.mock.Ctx.Response.StatusCode())
+
+	session := s.mock.Ctx.GetSession()
+	assert.Equal(s.T(), "test", session.Username)
+	assert.Equal(s.T(), authentication.TwoFactor, session.AuthenticationLevel)
+	assert.Equal(s.T(), s.mock.Clock.Now().Unix(), session.FirstFactorAuthnTimestamp)
+	assert.Equal(s.T(), userSession.SecondFactorAuthnTimestamp, session.SecondFactorAuthnTimestamp)
+	assert.True(s.T(), session.AuthenticationMethodRefs.UsernameAndPassword)
+	assert.True(s.T(), session.AuthenticationMethodRefs.TOTP)
+	assert.Equal(s.T(), []string{"dev", "admins"}, session.Groups)
+}
+
+func (s *FirstFactorSuite) TestShouldRetainSecondFactorWhenReauthenticatingFirstFactorWithDifferentCase() {
+	userSession := s.mock.Ctx.GetSession()
+	userSession.Username = "Test"
+	userSession.AuthenticationLevel = authentication.TwoFactor
+	userSession.SecondFactorAuthnTimestamp = s.mock.Clock.Now().Add(-time.Minute * 59).Unix()
+
+	require.NoError(s.T(), s.mock.Ctx.SaveSession(userSession))
+
+	s.mock.UserProviderMock.
+		EXPECT().
+		CheckUserPassword(gomock.Eq("test"), gomock.Eq("hello")).
+		Return(true, nil)
+
+	s.mock.UserProviderMock.
+		EXPECT().
+		GetDetails(gomock.Eq("test")).
+		Return(&authentication.UserDetails{
+			Username: "test",
+			Emails:   []string{"test@example.com"},
+			Groups:   []string{"dev", "admins"},
+		}, nil)
+
+	s.mock.StorageMock.
+		EXPECT().
+		AppendAuthenticationLog(s.mock.Ctx, gomock.Any()).
+		Return(nil)
+
+	s.mock.Ctx.Request.SetBodyString(`{
+		"username": "test",
+		"password": "hello",
+		"requestMethod": "GET"
+	}`)
+	FirstFactorPOST(nil)(s.mock.Ctx)
+
+	assert.Equal(s.T(), 200, s.mock.Ctx.Response.StatusCode())
+
+	session := s.mock.Ctx.GetSession()
+	assert.Equal(s.T(), authentication.TwoFactor, session.AuthenticationLevel)
+	assert.Equal(s.T(), userSession.SecondFactorAuthnTimestamp, session.SecondFactorAuthnTimestamp)
+}
+
+func (s *FirstFactorSuite) TestShouldNotRetainSecondFactorWhenAnotherUserAuthenticates() {
+	userSession := s.mock.Ctx.GetSession()
+	userSession.Username = "john"
+	userSession.AuthenticationLevel = authentication.TwoFactor
+	userSession.SecondFactorAuthnTimestamp = s.mock.Clock.Now().Add(-time.Minute).Unix()
+
+	require.NoError(s.T(), s.mock.Ctx.SaveSession(userSession))
+
+	s.mock.UserProviderMock.
+		EXPECT().
+		CheckUserPassword(gomock.Eq("test"), gomock.Eq("hello")).
+		Return(true, nil)
+
+	s.mock.UserProviderMock.
+		EXPECT().
+		GetDetails(gomock.Eq("test")).
+		Return(&authentication.UserDetails{Username: "test"}, nil)
+
+	s.mock.StorageMock.
+		EXPECT().
+		AppendAuthenticationLog(s.mock.Ctx, gomock.Any()).
+		Return(nil)
+
+	s.mock.Ctx.Request.SetBodyString(`{
+		"username": "test",
+		"password": "hello",
+		"requestMethod": "GET"
+	}`)
+	FirstFactorPOST(nil)(s.mock.Ctx)
+
+	assert.Equal(s.T(), 200, s.mock.Ctx.Response.StatusCode())
+
+	session := s.mock.Ctx.GetSession()
+	assert.Equal(s.T(), "test", session.Username)
+	assert.Equal(s.T(), authentication.OneFactor, session.AuthenticationLevel)
+}
+
 type FirstFactorRedirectionSuite struct {
 	suite.Suite
 
@@ -319,7 +532,7 @@ func (s *FirstFactorRedirectionSuite) SetupTest() {
 			Policy:  "one_factor",
 		},
 	}
-	s.mock.Ctx.Providers.Authorizer = authorization.NewAuthorizer(&s.mock.Ctx.Configuration)
+	s.mock.Ctx.Providers.Authorizer = authorization.NewAuthorizer(&s.mock.Ctx.Configuration, &s.mock.Clock, nil)
 
 	s.mock.UserProviderMock.
 		EXPECT().
@@ -403,7 +616,7 @@ func (s *FirstFactorRedirectionSuite) TestShouldReply200WhenNoTargetURLProvidedA
 		AccessControl: schema.AccessControlConfiguration{
 			DefaultPolicy: "two_factor",
 		},
-	})
+	}, &s.mock.Clock, nil)
 	s.mock.Ctx.Request.SetBodyString(`{
 		"username": "test",
 		"password": "hello",
@@ -438,7 +651,7 @@ func (s *FirstFactorRedirectionSuite) TestShouldReply200WhenUnsafeTargetURLProvi
 					Policy:  "two_factor",
 				},
 			},
-		}})
+		}}, &s.mock.Clock, nil)
 	s.mock.Ctx.Request.SetBodyString(`{
 		"username": "test",
 		"password": "hello",
@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/middlewares"
+	"github.com/authelia/authelia/v4/internal/session"
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+// identityRetrieverFromRegistration retriever computing the identity from the pending registration in the cookie session.
+func identityRetrieverFromRegistration(ctx *middlewares.AutheliaCtx) (*session.Identity, error) {
+	userSession := ctx.GetSession()
+
+	if userSession.Registration == nil {
+		return nil, fmt.Errorf("no registration has been initiated")
+	}
+
+	return &session.Identity{
+		Username:    userSession.Registration.Username,
+		Email:       userSession.Registration.Email,
+		DisplayName: userSession.Registration.DisplayName,
+	}, nil
+}
+
+func isTokenUserValidForRegistration(ctx *middlewares.AutheliaCtx, username string) bool {
+	userSession := ctx.GetSession()
+
+	return userSession.Registration != nil && userSession.Registration.Username == username
+}
+
+var registerIdentityStart = middlewares.IdentityVerificationStart(middlewares.IdentityVerificationStartArgs{
+	MailTitle:             "Confirm your email address",
+	MailButtonContent:     "Confirm",
+	TargetEndpoint:        "/register/finish",
+	ActionClaim:           ActionRegistration,
+	IdentityRetrieverFunc: identityRetrieverFromRegistration,
+}, nil)
+
+// RegisterIdentityStart the handler for initiating the identity validation of the email address of a self-service
+// registration. The details of the registration are checked and kept in the session until the registration completes.
+func RegisterIdentityStart(ctx *middlewares.AutheliaCtx) {
+	var requestBody registerRequestBody
+
+	if err := ctx.ParseBody(&requestBody); err != nil {
+		ctx.Error(err, messageUnableToRegister)
+		return
+	}
+
+	registration, message, err := newRegistration(ctx, requestBody)
+	if err != nil {
+		ctx.Error(err, message)
+		return
+	}
+
+	userSession := ctx.GetSession()
+	userSession.Registration = registration
+
+	if err = ctx.SaveSession(userSession); err != nil {
+		ctx.Error(fmt.Errorf("unable to save the registration in the session: %w", err), messageOperationFailed)
+		return
+	}
+
+	registerIdentityStart(ctx)
+}
+
+// newRegistration checks the details of a self-service registration, returning the message to reply with if they're not
+// acceptable.
+func newRegistration(ctx *middlewares.AutheliaCtx, requestBody registerRequestBody) (registration *session.Registration, message string, err error) {
+	username := strings.TrimSpace(requestBody.Username)
+
+	if !reRegistrationUsername.MatchString(username) {
+		return nil, messageUnableToRegister, fmt.Errorf("the username '%s' is not valid", username)
+	}
+
+	address, err := mail.ParseAddress(strings.TrimSpace(requestBody.Email))
+	if err != nil {
+		return nil, messageUnableToRegister, fmt.Errorf("the email address '%s' is not valid: %w", requestBody.Email, err)
+	}
+
+	domain := strings.ToLower(address.Address[strings.LastIndex(address.Address, "@")+1:])
+
+	if allowed := ctx.Configuration.AuthenticationBackend.Registration.AllowedDomains; len(allowed) != 0 && !utils.IsStringInSlice(domain, allowed) {
+		return nil, messageRegistrationEmailNotAllowed, fmt.Errorf("the email address '%s' is not in one of the allowed domains", address.Address)
+	}
+
+	if _, err = ctx.Providers.UserProvider.GetDetails(username); err == nil {
+		return nil, messageRegistrationUsernameUnavailable, fmt.Errorf("the username '%s' is already registered", username)
+	} else if !errors.Is(err, authentication.ErrUserNotFound) {
+		return nil, messageUnableToRegister, err
+	}
+
+	displayName := strings.TrimSpace(requestBody.DisplayName)
+	if displayName == "" {
+		displayName = username
+	}
+
+	return &session.Registration{
+		Username:    username,
+		DisplayName: displayName,
+		Email:       address.Address,
+	}, "", nil
+}
+
+func registerIdentityFinish(ctx *middlewares.AutheliaCtx, _ string) {
+	userSession := ctx.GetSession()
+	userSession.Registration.Verified = true
+
+	if err := ctx.SaveSession(userSession); err != nil {
+		ctx.Error(fmt.Errorf("unable to save the registration in the session: %w", err), messageOperationFailed)
+		return
+	}
+
+	ctx.ReplyOK()
+}
+
+// RegisterIdentityFinish the handler for finishing the identity validation of the email address of a self-service
+// registration.
+var RegisterIdentityFinish = middlewares.IdentityVerificationFinish(middlewares.IdentityVerificationFinishArgs{
+	ActionClaim:          ActionRegistration,
+	IsTokenUserValidFunc: isTokenUserValidForRegistration,
+}, registerIdentityFinish)
+
+// RegisterPOST handler for completing a self-service registration by creating the user with the chosen password.
+func RegisterPOST(ctx *middlewares.AutheliaCtx) {
+	userSession := ctx.GetSession()
+
+	if userSession.Registration == nil || !userSession.Registration.Verified {
+		ctx.Error(fmt.Errorf("no identity verification process has been completed for the registration"), messageUnableToRegister)
+		return
+	}
+
+	registration := *userSession.Registration
+
+	var requestBody registerCompleteRequestBody
+
+	err := ctx.ParseBody(&requestBody)
+	if err != nil {
+		ctx.Error(err, messageUnableToRegister)
+		return
+	}
+
+	if err = ctx.Providers.PasswordPolicy.Check(requestBody.Password); err != nil {
+		switch {
+		case errors.Is(err, middlewares.ErrPasswordPolicyBreached):
+			ctx.Error(err, messagePasswordBreached)
+		default:
+			ctx.Error(err, messagePasswordWeak)
+		}
+
+		return
+	}
+
+	creator, ok := ctx.Providers.UserProvider.(authentication.UserCreator)
+	if !ok {
+		ctx.Error(authentication.ErrUserCreationNotSupported, messageUnableToRegister)
+		return
+	}
+
+	if err = creator.CreateUser(registration.Username, registration.DisplayName, registration.Email, requestBody.Password,
+		ctx.Configuration.AuthenticationBackend.Registration.DefaultGroups); err != nil {
+		switch {
+		case errors.Is(err, authentication.ErrUserAlreadyExists):
+			ctx.Error(err, messageRegistrationUsernameUnavailable)
+		default:
+			ctx.Error(err, messageUnableToRegister)
+		}
+
+		return
+	}
+
+	ctx.Logger.Infof("User '%s' has been registered with the email address '%s'", registration.Username, registration.Email)
+
+	// The user has already been created so failing to save the password to the password history only results in an
+	// error being logged.
+	if err = ctx.Providers.PasswordHistory.Save(ctx, registration.Username, requestBody.Password); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred saving the password history of user '%s'", registration.Username)
+	}
+
+	userSession.Registration = nil
+
+	if err = ctx.SaveSession(userSession); err != nil {
+		ctx.Error(fmt.Errorf("unable to update registration state: %w", err), messageOperationFailed)
+		return
+	}
+
+	ctx.ReplyOK()
+}
@@ -0,0 +1,269 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/mail"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/middlewares"
+	"github.com/authelia/authelia/v4/internal/mocks"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/session"
+)
+
+// registerUserProviderMock is a user provider which is able to create users.
+type registerUserProviderMock struct {
+	*mocks.MockUserProvider
+	*mocks.MockUserCreator
+}
+
+type RegisterSuite struct {
+	suite.Suite
+
+	mock    *mocks.MockAutheliaCtx
+	creator *mocks.MockUserCreator
+}
+
+func (s *RegisterSuite) SetupTest() {
+	s.mock = mocks.NewMockAutheliaCtx(s.T())
+	s.creator = mocks.NewMockUserCreator(s.mock.Ctrl)
+
+	s.mock.Ctx.Configuration.JWTSecret = "abc"
+	s.mock.Ctx.Configuration.AuthenticationBackend.Registration = schema.RegistrationAuthenticationBackendConfiguration{
+		Enable:         true,
+		AllowedDomains: []string{"example.com"},
+		DefaultGroups:  []string{"users"},
+	}
+	s.mock.Ctx.Configuration.PasswordPolicy.History = 3
+
+	s.mock.Ctx.Providers.UserProvider = &registerUserProviderMock{MockUserProvider: s.mock.UserProviderMock, MockUserCreator: s.creator}
+	s.mock.Ctx.Providers.PasswordPolicy = middlewares.NewPasswordPolicyProvider(schema.PasswordPolicyConfiguration{})
+	s.mock.Ctx.Providers.PasswordHistory = authentication.NewPasswordHistory(s.mock.Ctx.Configuration.PasswordPolicy, schema.AuthenticationBackendConfiguration{}, s.mock.StorageMock)
+
+	s.mock.Ctx.Request.Header.Add("X-Forwarded-Proto", "https")
+	s.mock.Ctx.Request.Header.Add("X-Forwarded-Host", "login.example.com")
+}
+
+func (s *RegisterSuite) TearDownTest() {
+	s.mock.Close()
+}
+
+func (s *RegisterSuite) TestShouldStartRegistration() {
+	gomock.InOrder(
+		s.mock.UserProviderMock.EXPECT().
+			GetDetails("fred").
+			Return(nil, authentication.ErrUserNotFound),
+		s.mock.StorageMock.EXPECT().
+			SaveIdentityVerification(s.mock.Ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, verification model.IdentityVerification) error {
+				assert.Equal(s.T(), "fred", verification.Username)
+				assert.Equal(s.T(), ActionRegistration, verification.Action)
+
+				return nil
+			}),
+		s.mock.NotifierMock.EXPECT().
+			Send(mail.Address{Name: "Fred", Address: "fred@example.com"}, "Confirm your email address", gomock.Any(), gomock.Any()).
+			Return(nil),
+	)
+
+	s.mock.SetRequestBody(s.T(), registerRequestBody{Username: " fred ", DisplayName: "Fred", Email: "fred@EXAMPLE.com"})
+
+	RegisterIdentityStart(s.mock.Ctx)
+
+	s.mock.Assert200OK(s.T(), nil)
+
+	assert.Equal(s.T(), &session.Registration{Username: "fred", DisplayName: "Fred", Email: "fred@EXAMPLE.com"}, s.mock.Ctx.GetSession().Registration)
+}
+
+func (s *RegisterSuite) TestShouldDefaultDisplayNameToUsername() {
+	s.mock.Ctx.Configuration.AuthenticationBackend.Registration.AllowedDomains = nil
+
+	gomock.InOrder(
+		s.mock.UserProviderMock.EXPECT().
+			GetDetails("fred").
+			Return(nil, authentication.ErrUserNotFound),
+		s.mock.StorageMock.EXPECT().
+			SaveIdentityVerification(s.mock.Ctx, gomock.Any()).
+			Return(nil),
+		s.mock.NotifierMock.EXPECT().
+			Send(mail.Address{Name: "fred", Address: "fred@example.org"}, "Confirm your email address", gomock.Any(), gomock.Any()).
+			Return(nil),
+	)
+
+	s.mock.SetRequestBody(s.T(), registerRequestBody{Username: "fred", Email: "Fred <fred@example.org>"})
+
+	RegisterIdentityStart(s.mock.Ctx)
+
+	s.mock.Assert200OK(s.T(), nil)
+}
+
+func (s *RegisterSuite) TestShouldRejectInvalidRegistrations() {
+	testCases := []struct {
+		name     string
+		body     registerRequestBody
+		setup    func()
+		message  string
+		expected string
+	}{
+		{
+			"ShouldRejectInvalidUsername",
+			registerRequestBody{Username: "local\\fred", Email: "fred@example.com"},
+			nil,
+			messageUnableToRegister,
+			"the username 'local\\fred' is not valid",
+		},
+		{
+			"ShouldRejectInvalidEmail",
+			registerRequestBody{Username: "fred", Email: "fred"},
+			nil,
+			messageUnableToRegister,
+			"the email address 'fred' is not valid: mail: missing '@' or angle-addr",
+		},
+		{
+			"ShouldRejectEmailNotInAllowedDomains",
+			registerRequestBody{Username: "fred", Email: "fred@example.org"},
+			nil,
+			messageRegistrationEmailNotAllowed,
+			"the email address 'fred@example.org' is not in one of the allowed domains",
+		},
+		{
+			"ShouldRejectEmailInSubdomainOfAllowedDomain",
+			registerRequestBody{Username: "fred", Email: "fred@mail.example.com"},
+			nil,
+			messageRegistrationEmailNotAllowed,
+			"the email address 'fred@mail.example.com' is not in one of the allowed domains",
+		},
+		{
+			"ShouldRejectRegisteredUsername",
+			registerRequestBody{Username: "john", Email: "john@example.com"},
+			func() {
+				s.mock.UserProviderMock.EXPECT().GetDetails("john").Return(&authentication.UserDetails{Username: "john"}, nil)
+			},
+			messageRegistrationUsernameUnavailable,
+			"the username 'john' is already registered",
+		},
+		{
+			"ShouldRejectWhenBackendFails",
+			registerRequestBody{Username: "john", Email: "john@example.com"},
+			func() {
+				s.mock.UserProviderMock.EXPECT().GetDetails("john").Return(nil, errors.New("database is locked"))
+			},
+			messageUnableToRegister,
+			"database is locked",
+		},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			s.mock.Ctx.Response.Reset()
+
+			if tc.setup != nil {
+				tc.setup()
+			}
+
+			s.mock.SetRequestBody(s.T(), tc.body)
+
+			RegisterIdentityStart(s.mock.Ctx)
+
+			s.mock.Assert200KO(s.T(), tc.message)
+			assert.Equal(s.T(), tc.expected, s.mock.Hook.LastEntry().Message)
+
+			assert.Nil(s.T(), s.mock.Ctx.GetSession().Registration)
+		})
+	}
+}
+
+func (s *RegisterSuite) TestShouldValidateTokenUser() {
+	assert.False(s.T(), isTokenUserValidForRegistration(s.mock.Ctx, "fred"))
+
+	userSession := s.mock.Ctx.GetSession()
+	userSession.Registration = &session.Registration{Username: "fred"}
+	require.NoError(s.T(), s.mock.Ctx.SaveSession(userSession))
+
+	assert.True(s.T(), isTokenUserValidForRegistration(s.mock.Ctx, "fred"))
+	assert.False(s.T(), isTokenUserValidForRegistration(s.mock.Ctx, "john"))
+
+	registerIdentityFinish(s.mock.Ctx, "fred")
+
+	s.mock.Assert200OK(s.T(), nil)
+	assert.True(s.T(), s.mock.Ctx.GetSession().Registration.Verified)
+}
+
+func (s *RegisterSuite) TestShouldCompleteRegistration() {
+	userSession := s.mock.Ctx.GetSession()
+	userSession.Registration = &session.Registration{Username: "fred", DisplayName: "Fred", Email: "fred@example.com", Verified: true}
+	require.NoError(s.T(), s.mock.Ctx.SaveSession(userSession))
+
+	gomock.InOrder(
+		s.creator.EXPECT().
+			CreateUser("fred", "Fred", "fred@example.com", "correct horse battery staple", []string{"users"}).
+			Return(nil),
+		s.mock.StorageMock.EXPECT().
+			SaveUserPasswordHistory(s.mock.Ctx, gomock.Any(), 3).
+			Return(nil),
+	)
+
+	s.mock.SetRequestBody(s.T(), registerCompleteRequestBody{Password: "correct horse battery staple"})
+
+	RegisterPOST(s.mock.Ctx)
+
+	s.mock.Assert200OK(s.T(), nil)
+	assert.Nil(s.T(), s.mock.Ctx.GetSession().Registration)
+}
+
+func (s *RegisterSuite) TestShouldNotCompleteUnverifiedRegistration() {
+	userSession := s.mock.Ctx.GetSession()
+	userSession.Registration = &session.Registration{Username: "fred", DisplayName: "Fred", Email: "fred@example.com"}
+	require.NoError(s.T(), s.mock.Ctx.SaveSession(userSession))
+
+	s.mock.SetRequestBody(s.T(), registerCompleteRequestBody{Password: "correct horse battery staple"})
+
+	RegisterPOST(s.mock.Ctx)
+
+	s.mock.Assert200KO(s.T(), messageUnableToRegister)
+	assert.Equal(s.T(), "no identity verification process has been completed for the registration", s.mock.Hook.LastEntry().Message)
+}
+
+func (s *RegisterSuite) TestShouldNotCompleteRegistrationOfExistingUser() {
+	userSession := s.mock.Ctx.GetSession()
+	userSession.Registration = &session.Registration{Username: "fred", DisplayName: "Fred", Email: "fred@example.com", Verified: true}
+	require.NoError(s.T(), s.mock.Ctx.SaveSession(userSession))
+
+	s.creator.EXPECT().
+		CreateUser("fred", "Fred", "fred@example.com", "correct horse battery staple", []string{"users"}).
+		Return(authentication.ErrUserAlreadyExists)
+
+	s.mock.SetRequestBody(s.T(), registerCompleteRequestBody{Password: "correct horse battery staple"})
+
+	RegisterPOST(s.mock.Ctx)
+
+	s.mock.Assert200KO(s.T(), messageRegistrationUsernameUnavailable)
+	assert.NotNil(s.T(), s.mock.Ctx.GetSession().Registration)
+}
+
+func (s *RegisterSuite) TestShouldNotCompleteRegistrationWithoutUserCreator() {
+	s.mock.Ctx.Providers.UserProvider = s.mock.UserProviderMock
+
+	userSession := s.mock.Ctx.GetSession()
+	userSession.Registration = &session.Registration{Username: "fred", DisplayName: "Fred", Email: "fred@example.com", Verified: true}
+	require.NoError(s.T(), s.mock.Ctx.SaveSession(userSession))
+
+	s.mock.SetRequestBody(s.T(), registerCompleteRequestBody{Password: "correct horse battery staple"})
+
+	RegisterPOST(s.mock.Ctx)
+
+	s.mock.Assert200KO(s.T(), messageUnableToRegister)
+	assert.Equal(s.T(), "the authentication backend doesn't support creating users", s.mock.Hook.LastEntry().Message)
+}
+
+func TestRunRegisterSuite(t *testing.T) {
+	suite.Run(t, new(RegisterSuite))
+}
@@ -2,8 +2,10 @@ package handlers
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 
+	"github.com/authelia/authelia/v4/internal/authentication"
 	"github.com/authelia/authelia/v4/internal/middlewares"
 	"github.com/authelia/authelia/v4/internal/templates"
 	"github.com/authelia/authelia/v4/internal/utils"
@@ -32,10 +34,48 @@ func ResetPasswordPOST(ctx *middlewares.AutheliaCtx) {
 	}
 
 	if err = ctx.Providers.PasswordPolicy.Check(requestBody.Password); err != nil {
-		ctx.Error(err, messagePasswordWeak)
+		switch {
+		case errors.Is(err, middlewares.ErrPasswordPolicyBreached):
+			ctx.Error(err, messagePasswordBreached)
+		default:
+			ctx.Error(err, messagePasswordWeak)
+		}
+
+		return
+	}
+
+	if err = ctx.Providers.PasswordHistory.Check(ctx, username, requestBody.Password); err != nil {
+		switch {
+		case errors.Is(err, authentication.ErrPasswordReused):
+			ctx.Error(err, messagePasswordReused)
+		default:
+			ctx.Error(err, messageUnableToResetPassword)
+		}
+
 		return
 	}
 
+	// The current password of the user only becomes part of the history once it's replaced, so it's checked against the
+	// authentication backend which also covers passwords which weren't set through Authelia. The backends only return
+	// the expired, must change, and disabled errors when the password matches so these are also the current password.
+	if ctx.Providers.PasswordHistory.Enabled() {
+		var valid bool
+
+		valid, err = ctx.Providers.UserProvider.CheckUserPassword(username, requestBody.Password)
+
+		switch {
+		case valid,
+			errors.Is(err, authentication.ErrPasswordExpired),
+			errors.Is(err, authentication.ErrPasswordMustChange),
+			errors.Is(err, authentication.ErrAccountDisabled):
+			ctx.Error(authentication.ErrPasswordReused, messagePasswordReused)
+
+			return
+		case err != nil:
+			ctx.Logger.WithError(err).Warnf("Error occurred checking the new password of user '%s' against their current password", username)
+		}
+	}
+
 	if err = ctx.Providers.UserProvider.UpdatePassword(username, requestBody.Password); err != nil {
 		switch {
 		case utils.IsStringInSliceContains(err.Error(), ldapPasswordComplexityCodes),
@@ -50,6 +90,12 @@ func ResetPasswordPOST(ctx *middlewares.AutheliaCtx) {
 
 	ctx.Logger.Debugf("Password of user %s has been reset", username)
 
+	// The password has already been changed so failing to save it to the password history only results in an error
+	// being logged.
+	if err = ctx.Providers.PasswordHistory.Save(ctx, username, requestBody.Password); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred saving the password history of user '%s'", username)
+	}
+
 	// Reset the request.
 	userSession.PasswordResetUsername = nil
 
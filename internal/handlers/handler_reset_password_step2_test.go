@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/middlewares"
+	"github.com/authelia/authelia/v4/internal/mocks"
+	"github.com/authelia/authelia/v4/internal/model"
+)
+
+type ResetPasswordStep2Suite struct {
+	suite.Suite
+
+	mock *mocks.MockAutheliaCtx
+}
+
+func (s *ResetPasswordStep2Suite) SetupTest() {
+	s.mock = mocks.NewMockAutheliaCtx(s.T())
+
+	s.mock.Ctx.Configuration.PasswordPolicy.History = 3
+	s.mock.Ctx.Providers.PasswordPolicy = middlewares.NewPasswordPolicyProvider(schema.PasswordPolicyConfiguration{})
+	s.mock.Ctx.Providers.PasswordHistory = authentication.NewPasswordHistory(s.mock.Ctx.Configuration.PasswordPolicy, schema.AuthenticationBackendConfiguration{
+		File: &schema.FileAuthenticationBackendConfiguration{
+			Password: &schema.PasswordConfiguration{
+				Algorithm:  "sha512",
+				Iterations: 1000,
+				SaltLength: 16,
+			},
+		},
+	}, s.mock.StorageMock)
+
+	username := "john"
+
+	userSession := s.mock.Ctx.GetSession()
+	userSession.PasswordResetUsername = &username
+	require.NoError(s.T(), s.mock.Ctx.SaveSession(userSession))
+}
+
+func (s *ResetPasswordStep2Suite) TearDownTest() {
+	s.mock.Close()
+}
+
+func (s *ResetPasswordStep2Suite) TestShouldRejectPasswordInHistory() {
+	s.mock.StorageMock.EXPECT().
+		LoadUserPasswordHistory(s.mock.Ctx, "john", 3).
+		Return([]model.UserPasswordHistory{{Username: "john", Password: "$2y$04$abcdefghijklmnopqrstuughE8Ev8uGFaUgY2cNEySvxngrb/Jzdm"}}, nil)
+
+	s.mock.SetRequestBody(s.T(), resetPasswordStep2RequestBody{Password: "password"})
+
+	ResetPasswordPOST(s.mock.Ctx)
+
+	s.mock.Assert200KO(s.T(), messagePasswordReused)
+	assert.Equal(s.T(), "password was used previously", s.mock.Hook.LastEntry().Message)
+
+	assert.NotNil(s.T(), s.mock.Ctx.GetSession().PasswordResetUsername)
+}
+
+func (s *ResetPasswordStep2Suite) TestShouldRejectCurrentPassword() {
+	gomock.InOrder(
+		s.mock.StorageMock.EXPECT().
+			LoadUserPasswordHistory(s.mock.Ctx, "john", 3).
+			Return(nil, nil),
+		s.mock.UserProviderMock.EXPECT().
+			CheckUserPassword("john", "correct horse battery staple").
+			Return(true, nil),
+	)
+
+	s.mock.SetRequestBody(s.T(), resetPasswordStep2RequestBody{Password: "correct horse battery staple"})
+
+	ResetPasswordPOST(s.mock.Ctx)
+
+	s.mock.Assert200KO(s.T(), messagePasswordReused)
+	assert.Equal(s.T(), "password was used previously", s.mock.Hook.LastEntry().Message)
+
+	assert.NotNil(s.T(), s.mock.Ctx.GetSession().PasswordResetUsername)
+}
+
+func (s *ResetPasswordStep2Suite) TestShouldRejectCurrentExpiredPassword() {
+	gomock.InOrder(
+		s.mock.StorageMock.EXPECT().
+			LoadUserPasswordHistory(s.mock.Ctx, "john", 3).
+			Return(nil, nil),
+		s.mock.UserProviderMock.EXPECT().
+			CheckUserPassword("john", "correct horse battery staple").
+			Return(false, authentication.ErrPasswordExpired),
+	)
+
+	s.mock.SetRequestBody(s.T(), resetPasswordStep2RequestBody{Password: "correct horse battery staple"})
+
+	ResetPasswordPOST(s.mock.Ctx)
+
+	s.mock.Assert200KO(s.T(), messagePasswordReused)
+	assert.Equal(s.T(), "password was used previously", s.mock.Hook.LastEntry().Message)
+
+	assert.NotNil(s.T(), s.mock.Ctx.GetSession().PasswordResetUsername)
+}
+
+func (s *ResetPasswordStep2Suite) TestShouldSavePasswordToHistory() {
+	var saved model.UserPasswordHistory
+
+	gomock.InOrder(
+		s.mock.StorageMock.EXPECT().
+			LoadUserPasswordHistory(s.mock.Ctx, "john", 3).
+			Return([]model.UserPasswordHistory{{Username: "john", Password: "$2y$04$abcdefghijklmnopqrstuughE8Ev8uGFaUgY2cNEySvxngrb/Jzdm"}}, nil),
+		s.mock.UserProviderMock.EXPECT().
+			CheckUserPassword("john", "correct horse battery staple").
+			Return(false, nil),
+		s.mock.UserProviderMock.EXPECT().
+			UpdatePassword("john", "correct horse battery staple").
+			Return(nil),
+		s.mock.StorageMock.EXPECT().
+			SaveUserPasswordHistory(s.mock.Ctx, gomock.Any(), 3).
+			DoAndReturn(func(_ context.Context, entry model.UserPasswordHistory, _ int) error {
+				saved = entry
+
+				return nil
+			}),
+		s.mock.UserProviderMock.EXPECT().
+			GetDetails("john").
+			Return(&authentication.UserDetails{Username: "john"}, nil),
+	)
+
+	s.mock.SetRequestBody(s.T(), resetPasswordStep2RequestBody{Password: "correct horse battery staple"})
+
+	ResetPasswordPOST(s.mock.Ctx)
+
+	s.mock.Assert200OK(s.T(), nil)
+
+	assert.Equal(s.T(), "john", saved.Username)
+	assert.True(s.T(), strings.HasPrefix(saved.Password, "$6$rounds=1000$"))
+
+	assert.Nil(s.T(), s.mock.Ctx.GetSession().PasswordResetUsername)
+}
+
+func TestRunResetPasswordStep2Suite(t *testing.T) {
+	suite.Run(t, new(ResetPasswordStep2Suite))
+}
@@ -41,6 +41,10 @@ func WebauthnAssertionGET(ctx *middlewares.AutheliaCtx) {
 		webauthn.WithAllowedCredentials(user.WebAuthnCredentialDescriptors()),
 	}
 
+	if isWebauthnUserVerificationRequired(ctx) {
+		opts = append(opts, webauthn.WithUserVerification(protocol.VerificationRequired))
+	}
+
 	extensions := make(map[string]interface{})
 
 	if user.HasFIDOU2F() {
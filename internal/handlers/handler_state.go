@@ -10,6 +10,7 @@ func StateGET(ctx *middlewares.AutheliaCtx) {
 	stateResponse := StateResponse{
 		Username:              userSession.Username,
 		AuthenticationLevel:   userSession.AuthenticationLevel,
+		AuthenticationMethods: userSession.AuthenticationMethods(),
 		DefaultRedirectionURL: ctx.Configuration.DefaultRedirectionURL,
 	}
 
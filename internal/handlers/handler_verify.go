@@ -3,8 +3,10 @@ package handlers
 import (
 	"bytes"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"net"
+	"net/http"
 	"net/url"
 	"strings"
 	"time"
@@ -54,66 +56,117 @@ func parseBasicAuth(header []byte, auth string) (username, password string, err
 }
 
 // isTargetURLAuthorized check whether the given user is authorized to access the resource.
-func isTargetURLAuthorized(authorizer *authorization.Authorizer, targetURL url.URL,
-	username string, userGroups []string, clientIP net.IP, method []byte, authLevel authentication.Level) authorizationMatching {
-	hasSubject, level := authorizer.GetRequiredLevel(
+func isTargetURLAuthorized(authorizer *authorization.Authorizer, targetURL url.URL, header http.Header,
+	username string, userGroups []string, userAttributes map[string][]string, clientIP net.IP, method []byte,
+	authLevel authentication.Level, authn authorizationAuthentication) (matching authorizationMatching, stepUp authorizationStepUp) {
+	hasSubject, level, rule := authorizer.GetRequiredAuthorization(
 		authorization.Subject{
-			Username: username,
-			Groups:   userGroups,
-			IP:       clientIP,
+			Username:   username,
+			Groups:     userGroups,
+			Attributes: userAttributes,
+			IP:         clientIP,
 		},
-		authorization.NewObjectRaw(&targetURL, method))
+		authorization.NewObjectWithHeader(&targetURL, string(method), header))
 
 	switch {
 	case level == authorization.Bypass:
-		return Authorized
+		return Authorized, stepUp
 	case level == authorization.Denied && (username != "" || !hasSubject):
 		// If the user is not anonymous, it means that we went through
 		// all the rules related to that user and knowing who he is we can
 		// deduce the access is forbidden
 		// For anonymous users though, we check that the matched rule has no subject
 		// if matched rule has not subject then this rule applies to all users including anonymous.
-		return Forbidden
-	case level == authorization.OneFactor && authLevel >= authentication.OneFactor,
-		level == authorization.TwoFactor && authLevel >= authentication.TwoFactor:
-		return Authorized
+		return Forbidden, stepUp
+	case level == authorization.OneFactor && authLevel >= authentication.OneFactor:
+		if rule != nil && !rule.IsAuthenticationAgeSufficient(authn.FirstFactorAge) {
+			return NotAuthorized, authorizationStepUp{Reauthenticate: authentication.OneFactor}
+		}
+
+		return Authorized, stepUp
+	case level == authorization.TwoFactor && authLevel >= authentication.TwoFactor:
+		if rule == nil {
+			return Authorized, stepUp
+		}
+
+		// The session has completed two factor authentication but the rule may require a particular method, or that the
+		// second factor was completed recently, in which case the user must step up by completing the second factor again.
+		if !rule.IsAuthenticationMethodsSufficient(authn.Methods) {
+			stepUp.Methods = rule.AuthenticationMethods
+		}
+
+		if !rule.IsAuthenticationAgeSufficient(authn.SecondFactorAge) {
+			stepUp.Methods, stepUp.Reauthenticate = rule.AuthenticationMethods, authentication.TwoFactor
+		}
+
+		if len(stepUp.Methods) != 0 || stepUp.Reauthenticate != authentication.NotAuthenticated {
+			return NotAuthorized, stepUp
+		}
+
+		return Authorized, stepUp
 	}
 
-	return NotAuthorized
+	return NotAuthorized, stepUp
+}
+
+// getAuthorizationAuthentication returns the authentication methods and factor ages of the current session.
+func getAuthorizationAuthentication(ctx *middlewares.AutheliaCtx) (authn authorizationAuthentication) {
+	userSession := ctx.GetSession()
+
+	now := ctx.Clock.Now()
+
+	authn.Methods = userSession.AuthenticationMethods()
+	authn.FirstFactorAge = now.Sub(time.Unix(userSession.FirstFactorAuthnTimestamp, 0))
+	authn.SecondFactorAge = now.Sub(time.Unix(userSession.SecondFactorAuthnTimestamp, 0))
+
+	return authn
+}
+
+// requestHeaderToHTTPHeader converts the headers of the forwarded request into a http.Header.
+func requestHeaderToHTTPHeader(requestHeader *fasthttp.RequestHeader) (header http.Header) {
+	header = http.Header{}
+
+	requestHeader.VisitAll(func(key, value []byte) {
+		header.Add(string(key), string(value))
+	})
+
+	return header
 }
 
 // verifyBasicAuth verify that the provided username and password are correct and
 // that the user is authorized to target the resource.
-func verifyBasicAuth(ctx *middlewares.AutheliaCtx, header, auth []byte) (username, name string, groups, emails []string, authLevel authentication.Level, err error) {
+func verifyBasicAuth(ctx *middlewares.AutheliaCtx, header, auth []byte) (username, name string, groups, emails []string, attributes map[string][]string, authLevel authentication.Level, err error) {
 	username, password, err := parseBasicAuth(header, string(auth))
 
 	if err != nil {
-		return "", "", nil, nil, authentication.NotAuthenticated, fmt.Errorf("unable to parse content of %s header: %s", header, err)
+		return "", "", nil, nil, nil, authentication.NotAuthenticated, fmt.Errorf("unable to parse content of %s header: %s", header, err)
 	}
 
 	authenticated, err := ctx.Providers.UserProvider.CheckUserPassword(username, password)
 
 	if err != nil {
-		return "", "", nil, nil, authentication.NotAuthenticated, fmt.Errorf("unable to check credentials extracted from %s header: %w", header, err)
+		return "", "", nil, nil, nil, authentication.NotAuthenticated, fmt.Errorf("unable to check credentials extracted from %s header: %w", header, err)
 	}
 
 	// If the user is not correctly authenticated, send a 401.
 	if !authenticated {
 		// Request Basic Authentication otherwise.
-		return "", "", nil, nil, authentication.NotAuthenticated, fmt.Errorf("user %s is not authenticated", username)
+		return "", "", nil, nil, nil, authentication.NotAuthenticated, fmt.Errorf("user %s is not authenticated", username)
 	}
 
 	details, err := ctx.Providers.UserProvider.GetDetails(username)
 
 	if err != nil {
-		return "", "", nil, nil, authentication.NotAuthenticated, fmt.Errorf("unable to retrieve details of user %s: %s", username, err)
+		return "", "", nil, nil, nil, authentication.NotAuthenticated, fmt.Errorf("unable to retrieve details of user %s: %s", username, err)
 	}
 
-	return username, details.DisplayName, details.Groups, details.Emails, authentication.OneFactor, nil
+	return username, details.DisplayName, details.Groups, details.Emails, details.Attributes, authentication.OneFactor, nil
 }
 
-// setForwardedHeaders set the forwarded User, Groups, Name and Email headers.
-func setForwardedHeaders(headers *fasthttp.ResponseHeader, username, name string, groups, emails []string) {
+// setForwardedHeaders set the forwarded User, Groups, Name, Email, and Attribute headers.
+// setForwardedHeaders sets the headers forwarded to the backend. A header is set for every configured extra attribute
+// even if the user doesn't have the attribute so a header of the same name sent by the client is always replaced.
+func setForwardedHeaders(headers *fasthttp.ResponseHeader, username, name string, groups, emails []string, attributes map[string][]string, extraAttributes []string) {
 	if username != "" {
 		headers.SetBytesK(headerRemoteUser, username)
 		headers.SetBytesK(headerRemoteGroups, strings.Join(groups, ","))
@@ -124,9 +177,39 @@ func setForwardedHeaders(headers *fasthttp.ResponseHeader, username, name string
 		} else {
 			headers.SetBytesK(headerRemoteEmail, "")
 		}
+
+		for _, attribute := range extraAttributes {
+			headers.Set(headerRemoteAttributePrefix+attribute, strings.Join(attributes[attribute], ","))
+		}
 	}
 }
 
+// getForwardedExtraAttributes returns the names of the configured extra attributes which are forwarded as headers.
+// Attributes with names which aren't valid in a header name are only available to the access control rules.
+func getForwardedExtraAttributes(cfg schema.AuthenticationBackendConfiguration) (extraAttributes []string) {
+	var configured []string
+
+	if cfg.LDAP != nil {
+		configured = append(configured, cfg.LDAP.ExtraAttributes...)
+	}
+
+	if cfg.File != nil {
+		configured = append(configured, cfg.File.ExtraAttributes...)
+	}
+
+	for _, attribute := range configured {
+		attribute = strings.ToLower(attribute)
+
+		if !reHeaderAttributeName.MatchString(attribute) || utils.IsStringInSlice(attribute, extraAttributes) {
+			continue
+		}
+
+		extraAttributes = append(extraAttributes, attribute)
+	}
+
+	return extraAttributes
+}
+
 func isSessionInactiveTooLong(ctx *middlewares.AutheliaCtx, userSession *session.UserSession, isUserAnonymous bool) (isInactiveTooLong bool) {
 	if userSession.KeepMeLoggedIn || isUserAnonymous || int64(ctx.Providers.SessionProvider.Inactivity.Seconds()) == 0 {
 		return false
@@ -141,43 +224,43 @@ func isSessionInactiveTooLong(ctx *middlewares.AutheliaCtx, userSession *session
 
 // verifySessionCookie verifies if a user is identified by a cookie.
 func verifySessionCookie(ctx *middlewares.AutheliaCtx, targetURL *url.URL, userSession *session.UserSession, refreshProfile bool,
-	refreshProfileInterval time.Duration) (username, name string, groups, emails []string, authLevel authentication.Level, err error) {
+	refreshProfileInterval time.Duration) (username, name string, groups, emails []string, attributes map[string][]string, authLevel authentication.Level, err error) {
 	// No username in the session means the user is anonymous.
 	isUserAnonymous := userSession.Username == ""
 
 	if isUserAnonymous && userSession.AuthenticationLevel != authentication.NotAuthenticated {
-		return "", "", nil, nil, authentication.NotAuthenticated, fmt.Errorf("an anonymous user cannot be authenticated (this might be the sign of a security compromise)")
+		return "", "", nil, nil, nil, authentication.NotAuthenticated, fmt.Errorf("an anonymous user cannot be authenticated (this might be the sign of a security compromise)")
 	}
 
 	if isSessionInactiveTooLong(ctx, userSession, isUserAnonymous) {
 		// Destroy the session a new one will be regenerated on next request.
 		if err = ctx.Providers.SessionProvider.DestroySession(ctx.RequestCtx); err != nil {
-			return "", "", nil, nil, authentication.NotAuthenticated, fmt.Errorf("unable to destroy session for user '%s' after the session has been inactive too long: %w", userSession.Username, err)
+			return "", "", nil, nil, nil, authentication.NotAuthenticated, fmt.Errorf("unable to destroy session for user '%s' after the session has been inactive too long: %w", userSession.Username, err)
 		}
 
 		ctx.Logger.Warnf("Session destroyed for user '%s' after exceeding configured session inactivity and not being marked as remembered", userSession.Username)
 
-		return "", "", nil, nil, authentication.NotAuthenticated, nil
+		return "", "", nil, nil, nil, authentication.NotAuthenticated, nil
 	}
 
 	if err = verifySessionHasUpToDateProfile(ctx, targetURL, userSession, refreshProfile, refreshProfileInterval); err != nil {
-		if err == authentication.ErrUserNotFound {
+		if errors.Is(err, authentication.ErrUserNotFound) || errors.Is(err, authentication.ErrAccountDisabled) {
 			if err = ctx.Providers.SessionProvider.DestroySession(ctx.RequestCtx); err != nil {
-				ctx.Logger.Errorf("Unable to destroy user session after provider refresh didn't find the user: %v", err)
+				ctx.Logger.Errorf("Unable to destroy user session after provider refresh didn't find the user or found the user disabled: %v", err)
 			}
 
-			return userSession.Username, userSession.DisplayName, userSession.Groups, userSession.Emails, authentication.NotAuthenticated, err
+			return userSession.Username, userSession.DisplayName, userSession.Groups, userSession.Emails, userSession.Attributes, authentication.NotAuthenticated, err
 		}
 
 		ctx.Logger.Errorf("Error occurred while attempting to update user details from LDAP: %v", err)
 
-		return "", "", nil, nil, authentication.NotAuthenticated, err
+		return "", "", nil, nil, nil, authentication.NotAuthenticated, err
 	}
 
-	return userSession.Username, userSession.DisplayName, userSession.Groups, userSession.Emails, userSession.AuthenticationLevel, nil
+	return userSession.Username, userSession.DisplayName, userSession.Groups, userSession.Emails, userSession.Attributes, userSession.AuthenticationLevel, nil
 }
 
-func handleUnauthorized(ctx *middlewares.AutheliaCtx, targetURL fmt.Stringer, isBasicAuth bool, username string, method []byte) {
+func handleUnauthorized(ctx *middlewares.AutheliaCtx, targetURL fmt.Stringer, isBasicAuth bool, username string, method []byte, stepUp authorizationStepUp) {
 	var (
 		statusCode            int
 		redirectionURL        string
@@ -220,6 +303,14 @@ func handleUnauthorized(ctx *middlewares.AutheliaCtx, targetURL fmt.Stringer, is
 		default:
 			redirectionURL = fmt.Sprintf("%s?rd=%s&rm=%s", rd, url.QueryEscape(targetURL.String()), rm)
 		}
+
+		if len(stepUp.Methods) != 0 {
+			redirectionURL = fmt.Sprintf("%s&am=%s", redirectionURL, url.QueryEscape(strings.Join(stepUp.Methods, ",")))
+		}
+
+		if stepUp.Reauthenticate != authentication.NotAuthenticated {
+			redirectionURL = fmt.Sprintf("%s&ra=%d", redirectionURL, stepUp.Reauthenticate)
+		}
 	}
 
 	switch {
@@ -300,6 +391,13 @@ func generateVerifySessionHasUpToDateProfileTraceLogs(ctx *middlewares.AutheliaC
 		ctx.Logger.Tracef("No updated emails detected for %s", userSession.Username)
 	}
 
+	// Check Attributes.
+	if isAttributesDifferent(userSession.Attributes, details.Attributes) {
+		ctx.Logger.Tracef("Updated attributes detected for %s", userSession.Username)
+	} else {
+		ctx.Logger.Tracef("No updated attributes detected for %s", userSession.Username)
+	}
+
 	// Check Name.
 	if nameDelta {
 		ctx.Logger.Tracef("Updated display name detected for %s. Added: %s. Removed: %s.", userSession.Username, details.DisplayName, userSession.DisplayName)
@@ -308,6 +406,22 @@ func generateVerifySessionHasUpToDateProfileTraceLogs(ctx *middlewares.AutheliaC
 	}
 }
 
+// isAttributesDifferent returns true if the attribute names or any of the attribute values differ.
+func isAttributesDifferent(a, b map[string][]string) (different bool) {
+	if len(a) != len(b) {
+		return true
+	}
+
+	for name, values := range a {
+		other, ok := b[name]
+		if !ok || utils.IsStringSlicesDifferent(values, other) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func verifySessionHasUpToDateProfile(ctx *middlewares.AutheliaCtx, targetURL *url.URL, userSession *session.UserSession,
 	refreshProfile bool, refreshProfileInterval time.Duration) error {
 	// TODO: Add a check for LDAP password changes based on a time format attribute.
@@ -331,9 +445,10 @@ func verifySessionHasUpToDateProfile(ctx *middlewares.AutheliaCtx, targetURL *ur
 
 	emailsDiff := utils.IsStringSlicesDifferent(userSession.Emails, details.Emails)
 	groupsDiff := utils.IsStringSlicesDifferent(userSession.Groups, details.Groups)
+	attributesDiff := isAttributesDifferent(userSession.Attributes, details.Attributes)
 	nameDiff := userSession.DisplayName != details.DisplayName
 
-	if !groupsDiff && !emailsDiff && !nameDiff {
+	if !groupsDiff && !emailsDiff && !attributesDiff && !nameDiff {
 		ctx.Logger.Tracef("Updated profile not detected for %s.", userSession.Username)
 		// Only update TTL if the user has an interval set.
 		// We get to this check when there were no changes.
@@ -351,6 +466,7 @@ func verifySessionHasUpToDateProfile(ctx *middlewares.AutheliaCtx, targetURL *ur
 		}
 		userSession.Emails = details.Emails
 		userSession.Groups = details.Groups
+		userSession.Attributes = details.Attributes
 		userSession.DisplayName = details.DisplayName
 
 		// Only update TTL if the user has a interval set.
@@ -366,7 +482,9 @@ func verifySessionHasUpToDateProfile(ctx *middlewares.AutheliaCtx, targetURL *ur
 }
 
 func getProfileRefreshSettings(cfg schema.AuthenticationBackendConfiguration) (refresh bool, refreshInterval time.Duration) {
-	if cfg.LDAP != nil {
+	// Every backend is refreshed so the sessions of users whose groups are changed or who are removed are updated or
+	// destroyed, for the file backend this includes disabled users and changes made while Authelia wasn't running.
+	if cfg.LDAP != nil || cfg.File != nil || cfg.SQL != nil || len(cfg.Chain) != 0 {
 		if cfg.RefreshInterval == schema.ProfileRefreshDisabled {
 			refresh = false
 			refreshInterval = 0
@@ -385,7 +503,7 @@ func getProfileRefreshSettings(cfg schema.AuthenticationBackendConfiguration) (r
 	return refresh, refreshInterval
 }
 
-func verifyAuth(ctx *middlewares.AutheliaCtx, targetURL *url.URL, refreshProfile bool, refreshProfileInterval time.Duration) (isBasicAuth bool, username, name string, groups, emails []string, authLevel authentication.Level, err error) {
+func verifyAuth(ctx *middlewares.AutheliaCtx, targetURL *url.URL, refreshProfile bool, refreshProfileInterval time.Duration) (isBasicAuth bool, username, name string, groups, emails []string, attributes map[string][]string, authLevel authentication.Level, err error) {
 	authHeader := headerProxyAuthorization
 	if bytes.Equal(ctx.QueryArgs().Peek("auth"), []byte("basic")) {
 		authHeader = headerAuthorization
@@ -396,18 +514,18 @@ func verifyAuth(ctx *middlewares.AutheliaCtx, targetURL *url.URL, refreshProfile
 	if authValue != nil {
 		isBasicAuth = true
 	} else if isBasicAuth {
-		return isBasicAuth, username, name, groups, emails, authLevel, fmt.Errorf("basic auth requested via query arg, but no value provided via %s header", authHeader)
+		return isBasicAuth, username, name, groups, emails, attributes, authLevel, fmt.Errorf("basic auth requested via query arg, but no value provided via %s header", authHeader)
 	}
 
 	if isBasicAuth {
-		username, name, groups, emails, authLevel, err = verifyBasicAuth(ctx, authHeader, authValue)
+		username, name, groups, emails, attributes, authLevel, err = verifyBasicAuth(ctx, authHeader, authValue)
 
-		return isBasicAuth, username, name, groups, emails, authLevel, err
+		return isBasicAuth, username, name, groups, emails, attributes, authLevel, err
 	}
 
 	userSession := ctx.GetSession()
-	if username, name, groups, emails, authLevel, err = verifySessionCookie(ctx, targetURL, &userSession, refreshProfile, refreshProfileInterval); err != nil {
-		return isBasicAuth, username, name, groups, emails, authLevel, err
+	if username, name, groups, emails, attributes, authLevel, err = verifySessionCookie(ctx, targetURL, &userSession, refreshProfile, refreshProfileInterval); err != nil {
+		return isBasicAuth, username, name, groups, emails, attributes, authLevel, err
 	}
 
 	sessionUsername := ctx.Request.Header.PeekBytes(headerSessionUsername)
@@ -418,15 +536,16 @@ func verifyAuth(ctx *middlewares.AutheliaCtx, targetURL *url.URL, refreshProfile
 			ctx.Logger.Errorf("Unable to destroy user session after handler could not match them to their %s header: %s", headerSessionUsername, err)
 		}
 
-		return isBasicAuth, username, name, groups, emails, authLevel, fmt.Errorf("could not match user %s to their %s header with a value of %s when visiting %s", username, headerSessionUsername, sessionUsername, targetURL.String())
+		return isBasicAuth, username, name, groups, emails, attributes, authLevel, fmt.Errorf("could not match user %s to their %s header with a value of %s when visiting %s", username, headerSessionUsername, sessionUsername, targetURL.String())
 	}
 
-	return isBasicAuth, username, name, groups, emails, authLevel, err
+	return isBasicAuth, username, name, groups, emails, attributes, authLevel, err
 }
 
 // VerifyGET returns the handler verifying if a request is allowed to go through.
 func VerifyGET(cfg schema.AuthenticationBackendConfiguration) middlewares.RequestHandler {
 	refreshProfile, refreshProfileInterval := getProfileRefreshSettings(cfg)
+	extraAttributes := getForwardedExtraAttributes(cfg)
 
 	return func(ctx *middlewares.AutheliaCtx) {
 		ctx.Logger.Tracef("Headers=%s", ctx.Request.Header.String())
@@ -456,7 +575,7 @@ func VerifyGET(cfg schema.AuthenticationBackendConfiguration) middlewares.Reques
 		}
 
 		method := ctx.XForwardedMethod()
-		isBasicAuth, username, name, groups, emails, authLevel, err := verifyAuth(ctx, targetURL, refreshProfile, refreshProfileInterval)
+		isBasicAuth, username, name, groups, emails, attributes, authLevel, err := verifyAuth(ctx, targetURL, refreshProfile, refreshProfileInterval)
 
 		if err != nil {
 			ctx.Logger.Errorf("Error caught when verifying user authorization: %s", err)
@@ -466,22 +585,39 @@ func VerifyGET(cfg schema.AuthenticationBackendConfiguration) middlewares.Reques
 				return
 			}
 
-			handleUnauthorized(ctx, targetURL, isBasicAuth, username, method)
+			handleUnauthorized(ctx, targetURL, isBasicAuth, username, method, authorizationStepUp{})
 
 			return
 		}
 
-		authorized := isTargetURLAuthorized(ctx.Providers.Authorizer, *targetURL, username,
-			groups, ctx.RemoteIP(), method, authLevel)
+		var authn authorizationAuthentication
+
+		if !isBasicAuth {
+			authn = getAuthorizationAuthentication(ctx)
+		}
+
+		authorized, stepUp := isTargetURLAuthorized(ctx.Providers.Authorizer, *targetURL, requestHeaderToHTTPHeader(&ctx.Request.Header),
+			username, groups, attributes, ctx.RemoteIP(), method, authLevel, authn)
 
 		switch authorized {
 		case Forbidden:
 			ctx.Logger.Infof("Access to %s is forbidden to user %s", targetURL.String(), username)
 			ctx.ReplyForbidden()
 		case NotAuthorized:
-			handleUnauthorized(ctx, targetURL, isBasicAuth, username, method)
+			if len(stepUp.Methods) != 0 {
+				ctx.Logger.Infof("Access to %s requires user %s to complete one of the authentication methods '%s'", targetURL.String(), username, strings.Join(stepUp.Methods, "', '"))
+			}
+
+			switch stepUp.Reauthenticate {
+			case authentication.OneFactor:
+				ctx.Logger.Infof("Access to %s requires user %s to complete the first factor again as it was completed before the maximum authentication age", targetURL.String(), username)
+			case authentication.TwoFactor:
+				ctx.Logger.Infof("Access to %s requires user %s to complete the second factor again as it was completed before the maximum authentication age", targetURL.String(), username)
+			}
+
+			handleUnauthorized(ctx, targetURL, isBasicAuth, username, method, stepUp)
 		case Authorized:
-			setForwardedHeaders(&ctx.Response.Header, username, name, groups, emails)
+			setForwardedHeaders(&ctx.Response.Header, username, name, groups, emails, attributes, extraAttributes)
 		}
 
 		if err = updateActivityTimestamp(ctx, isBasicAuth); err != nil {
@@ -155,19 +155,58 @@ func TestShouldCheckAuthorizationMatching(t *testing.T) {
 					Domains: []string{"test.example.com"},
 					Policy:  rule.Policy,
 				}},
-			}})
+			}}, utils.RealClock{}, nil)
 
 		username := ""
 		if rule.AuthLevel > authentication.NotAuthenticated {
 			username = testUsername
 		}
 
-		matching := isTargetURLAuthorized(authorizer, *u, username, []string{}, net.ParseIP("127.0.0.1"), []byte("GET"), rule.AuthLevel)
+		matching, _ := isTargetURLAuthorized(authorizer, *u, nil, username, []string{}, nil, net.ParseIP("127.0.0.1"), []byte("GET"), rule.AuthLevel, authorizationAuthentication{})
 		assert.Equal(t, rule.ExpectedMatching, matching, "policy=%s, authLevel=%v, expected=%v, actual=%v",
 			rule.Policy, rule.AuthLevel, rule.ExpectedMatching, matching)
 	}
 }
 
+func TestShouldCheckAuthorizationMatchingAuthenticationMethods(t *testing.T) {
+	testCases := []struct {
+		name             string
+		authLevel        authentication.Level
+		methods          []string
+		expectedMatching authorizationMatching
+		expectedStepUp   []string
+	}{
+		{"ShouldNotAuthorizeOneFactor", authentication.OneFactor, nil, NotAuthorized, nil},
+		{"ShouldStepUpTwoFactorWithoutMethods", authentication.TwoFactor, nil, NotAuthorized, []string{"webauthn_user_verified", "duo"}},
+		{"ShouldStepUpTwoFactorWithOtherMethods", authentication.TwoFactor, []string{"webauthn", "totp"}, NotAuthorized, []string{"webauthn_user_verified", "duo"}},
+		{"ShouldAuthorizeTwoFactorWithDuo", authentication.TwoFactor, []string{"duo"}, Authorized, nil},
+		{"ShouldAuthorizeTwoFactorWithWebauthnUserVerified", authentication.TwoFactor, []string{"webauthn", "webauthn_user_verified"}, Authorized, nil},
+	}
+
+	authorizer := authorization.NewAuthorizer(&schema.Configuration{
+		AccessControl: schema.AccessControlConfiguration{
+			DefaultPolicy: "deny",
+			Rules: []schema.ACLRule{
+				{
+					Domains:               []string{"secure.example.com"},
+					Policy:                "two_factor",
+					AuthenticationMethods: []string{"webauthn_user_verified", "duo"},
+				},
+			},
+		}}, utils.RealClock{}, nil)
+
+	u, _ := url.ParseRequestURI("https://secure.example.com")
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			matching, stepUp := isTargetURLAuthorized(authorizer, *u, nil, testUsername, []string{}, nil, net.ParseIP("127.0.0.1"), []byte("GET"), tc.authLevel, authorizationAuthentication{Methods: tc.methods})
+
+			assert.Equal(t, tc.expectedMatching, matching)
+			assert.Equal(t, tc.expectedStepUp, stepUp.Methods)
+		})
+	}
+}
+
 // Test verifyBasicAuth.
 func TestShouldVerifyWrongCredentials(t *testing.T) {
 	mock := mocks.NewMockAutheliaCtx(t)
@@ -177,7 +216,7 @@ func TestShouldVerifyWrongCredentials(t *testing.T) {
 		CheckUserPassword(gomock.Eq("john"), gomock.Eq("password")).
 		Return(false, nil)
 
-	_, _, _, _, _, err := verifyBasicAuth(mock.Ctx, headerProxyAuthorization, []byte("Basic am9objpwYXNzd29yZA=="))
+	_, _, _, _, _, _, err := verifyBasicAuth(mock.Ctx, headerProxyAuthorization, []byte("Basic am9objpwYXNzd29yZA=="))
 
 	assert.Error(t, err)
 }
@@ -731,6 +770,178 @@ func TestShouldRedirectWhenSessionInactiveForTooLongAndRDParamProvided(t *testin
 	assert.Equal(t, clock.Now().Unix(), newUserSession.LastActivity)
 }
 
+func TestShouldCheckAuthorizationMatchingMaxAuthenticationAge(t *testing.T) {
+	testCases := []struct {
+		name             string
+		domain           string
+		authLevel        authentication.Level
+		authn            authorizationAuthentication
+		expectedMatching authorizationMatching
+		expectedStepUp   authorizationStepUp
+	}{
+		{
+			"ShouldAuthorizeRecentFirstFactor", "one-factor.example.com", authentication.OneFactor,
+			authorizationAuthentication{FirstFactorAge: time.Minute},
+			Authorized, authorizationStepUp{},
+		},
+		{
+			"ShouldReauthenticateExpiredFirstFactor", "one-factor.example.com", authentication.TwoFactor,
+			authorizationAuthentication{FirstFactorAge: time.Hour, SecondFactorAge: time.Minute},
+			NotAuthorized, authorizationStepUp{Reauthenticate: authentication.OneFactor},
+		},
+		{
+			"ShouldAuthorizeRecentSecondFactor", "two-factor.example.com", authentication.TwoFactor,
+			authorizationAuthentication{Methods: []string{"totp"}, FirstFactorAge: time.Hour, SecondFactorAge: time.Minute},
+			Authorized, authorizationStepUp{},
+		},
+		{
+			"ShouldReauthenticateExpiredSecondFactor", "two-factor.example.com", authentication.TwoFactor,
+			authorizationAuthentication{Methods: []string{"totp"}, FirstFactorAge: time.Minute, SecondFactorAge: time.Hour},
+			NotAuthorized, authorizationStepUp{Reauthenticate: authentication.TwoFactor},
+		},
+		{
+			"ShouldReauthenticateExpiredSecondFactorWithMethods", "secure.example.com", authentication.TwoFactor,
+			authorizationAuthentication{Methods: []string{"duo"}, SecondFactorAge: time.Hour},
+			NotAuthorized, authorizationStepUp{Methods: []string{"duo"}, Reauthenticate: authentication.TwoFactor},
+		},
+		{
+			"ShouldNotReauthenticateWithoutMaxAge", "other.example.com", authentication.TwoFactor,
+			authorizationAuthentication{FirstFactorAge: time.Hour * 24, SecondFactorAge: time.Hour * 24},
+			Authorized, authorizationStepUp{},
+		},
+	}
+
+	authorizer := authorization.NewAuthorizer(&schema.Configuration{
+		AccessControl: schema.AccessControlConfiguration{
+			DefaultPolicy: "two_factor",
+			Rules: []schema.ACLRule{
+				{
+					Domains:              []string{"one-factor.example.com"},
+					Policy:               "one_factor",
+					MaxAuthenticationAge: time.Minute * 10,
+				},
+				{
+					Domains:              []string{"two-factor.example.com"},
+					Policy:               "two_factor",
+					MaxAuthenticationAge: time.Minute * 10,
+				},
+				{
+					Domains:               []string{"secure.example.com"},
+					Policy:                "two_factor",
+					AuthenticationMethods: []string{"duo"},
+					MaxAuthenticationAge:  time.Minute * 10,
+				},
+			},
+		}}, utils.RealClock{}, nil)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			u, _ := url.ParseRequestURI(fmt.Sprintf("https://%s", tc.domain))
+
+			matching, stepUp := isTargetURLAuthorized(authorizer, *u, nil, testUsername, []string{}, nil, net.ParseIP("127.0.0.1"), []byte("GET"), tc.authLevel, tc.authn)
+
+			assert.Equal(t, tc.expectedMatching, matching)
+			assert.Equal(t, tc.expectedStepUp, stepUp)
+		})
+	}
+}
+
+func TestShouldRedirectWithReauthenticationWhenSecondFactorExpired(t *testing.T) {
+	mock := mocks.NewMockAutheliaCtx(t)
+	defer mock.Close()
+
+	mock.Clock.Set(time.Now())
+
+	mock.Ctx.Providers.Authorizer = authorization.NewAuthorizer(&schema.Configuration{
+		AccessControl: schema.AccessControlConfiguration{
+			DefaultPolicy: "deny",
+			Rules: []schema.ACLRule{
+				{
+					Domains:              []string{"two-factor.example.com"},
+					Policy:               "two_factor",
+					MaxAuthenticationAge: time.Minute * 10,
+				},
+			},
+		}}, &mock.Clock, nil)
+
+	userSession := mock.Ctx.GetSession()
+	userSession.Username = testUsername
+	userSession.AuthenticationLevel = authentication.TwoFactor
+	userSession.AuthenticationMethodRefs.UsernameAndPassword = true
+	userSession.AuthenticationMethodRefs.TOTP = true
+	userSession.RefreshTTL = mock.Clock.Now().Add(5 * time.Minute)
+	userSession.FirstFactorAuthnTimestamp = mock.Clock.Now().Add(-time.Hour).Unix()
+	userSession.SecondFactorAuthnTimestamp = mock.Clock.Now().Add(-time.Minute * 20).Unix()
+	userSession.LastActivity = mock.Clock.Now().Unix()
+
+	err := mock.Ctx.SaveSession(userSession)
+	require.NoError(t, err)
+
+	mock.Ctx.QueryArgs().Add("rd", "https://login.example.com")
+	mock.Ctx.Request.Header.Set("X-Original-URL", "https://two-factor.example.com")
+	mock.Ctx.Request.Header.Set("X-Forwarded-Method", "GET")
+	mock.Ctx.Request.Header.Set("Accept", "text/html; charset=utf-8")
+
+	VerifyGET(verifyGetCfg)(mock.Ctx)
+
+	assert.Equal(t, "<a href=\"https://login.example.com/?rd=https%3A%2F%2Ftwo-factor.example.com&amp;rm=GET&amp;ra=2\">302 Found</a>",
+		string(mock.Ctx.Response.Body()))
+	assert.Equal(t, 302, mock.Ctx.Response.StatusCode())
+
+	mock.Ctx.Response.Reset()
+
+	userSession = mock.Ctx.GetSession()
+	userSession.SecondFactorAuthnTimestamp = mock.Clock.Now().Add(-time.Minute).Unix()
+
+	err = mock.Ctx.SaveSession(userSession)
+	require.NoError(t, err)
+
+	VerifyGET(verifyGetCfg)(mock.Ctx)
+
+	assert.Equal(t, 200, mock.Ctx.Response.StatusCode())
+}
+
+func TestShouldRedirectWithAuthenticationMethodsWhenStepUpRequired(t *testing.T) {
+	mock := mocks.NewMockAutheliaCtx(t)
+	defer mock.Close()
+
+	mock.Clock.Set(time.Now())
+
+	mock.Ctx.Providers.Authorizer = authorization.NewAuthorizer(&schema.Configuration{
+		AccessControl: schema.AccessControlConfiguration{
+			DefaultPolicy: "deny",
+			Rules: []schema.ACLRule{
+				{
+					Domains:               []string{"two-factor.example.com"},
+					Policy:                "two_factor",
+					AuthenticationMethods: []string{"webauthn", "duo"},
+				},
+			},
+		}}, &mock.Clock, nil)
+
+	userSession := mock.Ctx.GetSession()
+	userSession.Username = testUsername
+	userSession.AuthenticationLevel = authentication.TwoFactor
+	userSession.AuthenticationMethodRefs.UsernameAndPassword = true
+	userSession.AuthenticationMethodRefs.TOTP = true
+	userSession.RefreshTTL = mock.Clock.Now().Add(5 * time.Minute)
+	userSession.LastActivity = mock.Clock.Now().Unix()
+
+	err := mock.Ctx.SaveSession(userSession)
+	require.NoError(t, err)
+
+	mock.Ctx.QueryArgs().Add("rd", "https://login.example.com")
+	mock.Ctx.Request.Header.Set("X-Original-URL", "https://two-factor.example.com")
+	mock.Ctx.Request.Header.Set("X-Forwarded-Method", "GET")
+	mock.Ctx.Request.Header.Set("Accept", "text/html; charset=utf-8")
+
+	VerifyGET(verifyGetCfg)(mock.Ctx)
+
+	assert.Equal(t, "<a href=\"https://login.example.com/?rd=https%3A%2F%2Ftwo-factor.example.com&amp;rm=GET&amp;am=webauthn%2Cduo\">302 Found</a>",
+		string(mock.Ctx.Response.Body()))
+	assert.Equal(t, 302, mock.Ctx.Response.StatusCode())
+}
+
 func TestShouldRedirectWithCorrectStatusCodeBasedOnRequestMethod(t *testing.T) {
 	mock := mocks.NewMockAutheliaCtx(t)
 	defer mock.Close()
@@ -1042,6 +1253,61 @@ func TestShouldDestroySessionWhenUserNotExist(t *testing.T) {
 	assert.Equal(t, authentication.NotAuthenticated, userSession.AuthenticationLevel)
 }
 
+func TestShouldDestroySessionWhenUserDisabled(t *testing.T) {
+	mock := mocks.NewMockAutheliaCtx(t)
+	defer mock.Close()
+
+	clock := mocks.TestingClock{}
+	clock.Set(time.Now())
+
+	userSession := mock.Ctx.GetSession()
+	userSession.Username = "john"
+	userSession.AuthenticationLevel = authentication.TwoFactor
+	userSession.LastActivity = clock.Now().Unix()
+	userSession.RefreshTTL = clock.Now().Add(-1 * time.Minute)
+	userSession.Groups = []string{"admin", "users"}
+	userSession.Emails = []string{"john@example.com"}
+	userSession.KeepMeLoggedIn = true
+
+	require.NoError(t, mock.Ctx.SaveSession(userSession))
+
+	mock.Ctx.Request.Header.Set("X-Original-URL", "https://two-factor.example.com")
+
+	mock.UserProviderMock.EXPECT().GetDetails("john").Return(nil, authentication.ErrAccountDisabled).Times(1)
+
+	VerifyGET(verifyGetCfg)(mock.Ctx)
+
+	assert.Equal(t, 401, mock.Ctx.Response.StatusCode())
+
+	userSession = mock.Ctx.GetSession()
+	assert.Equal(t, "", userSession.Username)
+	assert.Equal(t, authentication.NotAuthenticated, userSession.AuthenticationLevel)
+}
+
+func TestShouldSetForwardedAttributeHeaders(t *testing.T) {
+	mock := mocks.NewMockAutheliaCtx(t)
+	defer mock.Close()
+
+	extraAttributes := getForwardedExtraAttributes(schema.AuthenticationBackendConfiguration{
+		File: &schema.FileAuthenticationBackendConfiguration{ExtraAttributes: []string{"department", "employeeType", "cost center", "location"}},
+		LDAP: &schema.LDAPAuthenticationBackendConfiguration{ExtraAttributes: []string{"Department"}},
+	})
+
+	assert.Equal(t, []string{"department", "employeetype", "location"}, extraAttributes)
+
+	setForwardedHeaders(&mock.Ctx.Response.Header, "john", "John Smith", []string{"admin", "dev"}, []string{"john@example.com"},
+		map[string][]string{"department": {"engineering"}, "employeetype": {"staff", "oncall"}, "cost center": {"1234"}}, extraAttributes)
+
+	assert.Equal(t, []byte("john"), mock.Ctx.Response.Header.Peek("Remote-User"))
+	assert.Equal(t, []byte("admin,dev"), mock.Ctx.Response.Header.Peek("Remote-Groups"))
+	assert.Equal(t, []byte("engineering"), mock.Ctx.Response.Header.Peek("Remote-Attribute-Department"))
+	assert.Equal(t, []byte("staff,oncall"), mock.Ctx.Response.Header.Peek("Remote-Attribute-Employeetype"))
+	assert.Equal(t, []byte(nil), mock.Ctx.Response.Header.Peek("Remote-Attribute-Cost center"))
+
+	// The header of an attribute the user doesn't have is set to an empty value to replace a header sent by the client.
+	assert.Contains(t, mock.Ctx.Response.Header.String(), "Remote-Attribute-Location: \r\n")
+}
+
 func TestShouldGetRemovedUserGroupsFromBackend(t *testing.T) {
 	mock := mocks.NewMockAutheliaCtx(t)
 	defer mock.Close()
@@ -1244,6 +1510,59 @@ func TestShouldCheckInvalidSessionUsernameHeaderAndReturn401(t *testing.T) {
 	assert.Equal(t, "401 Unauthorized", string(mock.Ctx.Response.Body()))
 }
 
+func TestShouldRefreshAttributesAndAuthorizeAttributeSubject(t *testing.T) {
+	mock := mocks.NewMockAutheliaCtx(t)
+	defer mock.Close()
+
+	mock.Clock.Set(time.Now())
+
+	mock.Ctx.Providers.Authorizer = authorization.NewAuthorizer(&schema.Configuration{
+		AccessControl: schema.AccessControlConfiguration{
+			DefaultPolicy: "deny",
+			Rules: []schema.ACLRule{
+				{
+					Domains:  []string{"engineering.example.com"},
+					Policy:   "one_factor",
+					Subjects: [][]string{{"attr:department=engineering"}},
+				},
+			},
+		}}, &mock.Clock, nil)
+
+	mock.UserProviderMock.EXPECT().
+		GetDetails(gomock.Eq(testUsername)).
+		Return(&authentication.UserDetails{
+			Username:   testUsername,
+			Attributes: map[string][]string{"department": {"engineering"}},
+		}, nil).
+		Times(1)
+
+	userSession := mock.Ctx.GetSession()
+	userSession.Username = testUsername
+	userSession.AuthenticationLevel = authentication.OneFactor
+	userSession.Attributes = map[string][]string{"department": {"sales"}}
+	userSession.RefreshTTL = mock.Clock.Now().Add(-1 * time.Minute)
+
+	err := mock.Ctx.SaveSession(userSession)
+	require.NoError(t, err)
+
+	mock.Ctx.Request.Header.Set("X-Original-URL", "https://engineering.example.com")
+
+	VerifyGET(verifyGetCfg)(mock.Ctx)
+
+	assert.Equal(t, 200, mock.Ctx.Response.StatusCode())
+
+	userSession = mock.Ctx.GetSession()
+	assert.Equal(t, map[string][]string{"department": {"engineering"}}, userSession.Attributes)
+}
+
+func TestIsAttributesDifferent(t *testing.T) {
+	assert.False(t, isAttributesDifferent(nil, nil))
+	assert.False(t, isAttributesDifferent(map[string][]string{"a": {"1", "2"}}, map[string][]string{"a": {"2", "1"}}))
+	assert.True(t, isAttributesDifferent(map[string][]string{"a": {"1"}}, nil))
+	assert.True(t, isAttributesDifferent(map[string][]string{"a": {"1"}}, map[string][]string{"b": {"1"}}))
+	assert.True(t, isAttributesDifferent(map[string][]string{"a": {"1"}}, map[string][]string{"a": {"2"}}))
+}
+
 func TestGetProfileRefreshSettings(t *testing.T) {
 	cfg := verifyGetCfg
 
@@ -1265,6 +1584,45 @@ func TestGetProfileRefreshSettings(t *testing.T) {
 
 	assert.Equal(t, true, refresh)
 	assert.Equal(t, time.Duration(0), interval)
+
+	cfg.LDAP = nil
+	cfg.File = &schema.FileAuthenticationBackendConfiguration{Path: "/config/users_database.yml"}
+	cfg.RefreshInterval = schema.RefreshIntervalDefault
+
+	refresh, interval = getProfileRefreshSettings(cfg)
+
+	assert.Equal(t, true, refresh)
+	assert.Equal(t, 5*time.Minute, interval)
+
+	cfg.File.Watch = true
+
+	refresh, interval = getProfileRefreshSettings(cfg)
+
+	assert.Equal(t, true, refresh)
+	assert.Equal(t, 5*time.Minute, interval)
+
+	cfg.File = nil
+	cfg.SQL = &schema.SQLAuthenticationBackendConfiguration{}
+
+	refresh, interval = getProfileRefreshSettings(cfg)
+
+	assert.Equal(t, true, refresh)
+	assert.Equal(t, 5*time.Minute, interval)
+
+	cfg.SQL = nil
+	cfg.Chain = []schema.AuthenticationBackendChainConfiguration{{Backend: "ldap"}}
+
+	refresh, interval = getProfileRefreshSettings(cfg)
+
+	assert.Equal(t, true, refresh)
+	assert.Equal(t, 5*time.Minute, interval)
+
+	cfg.Chain = nil
+
+	refresh, interval = getProfileRefreshSettings(cfg)
+
+	assert.Equal(t, false, refresh)
+	assert.Equal(t, time.Duration(0), interval)
 }
 
 func TestShouldNotRedirectRequestsForBypassACLWhenInactiveForTooLong(t *testing.T) {
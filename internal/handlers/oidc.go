@@ -22,6 +22,10 @@ func oidcGrantRequests(ar fosite.AuthorizeRequester, consent *model.OAuth2Consen
 		case oidc.ScopeProfile:
 			extraClaims[oidc.ClaimPreferredUsername] = userSession.Username
 			extraClaims[oidc.ClaimDisplayName] = userSession.DisplayName
+
+			if len(userSession.Attributes) != 0 {
+				extraClaims[oidc.ClaimAttributes] = userSession.Attributes
+			}
 		case oidc.ScopeEmail:
 			if len(userSession.Emails) != 0 {
 				extraClaims[oidc.ClaimEmail] = userSession.Emails[0]
@@ -106,6 +106,22 @@ func TestShouldGrantAppropriateClaimsForScopeOpenIDAndProfile(t *testing.T) {
 	assert.Equal(t, extraClaims[oidc.ClaimDisplayName], "Fred Smith")
 }
 
+func TestShouldGrantAttributesClaimForScopeProfile(t *testing.T) {
+	consent := &model.OAuth2ConsentSession{
+		GrantedScopes: []string{oidc.ScopeProfile},
+	}
+
+	userSession := oidcUserSessionFred
+	userSession.Attributes = map[string][]string{"department": {"engineering"}}
+
+	extraClaims := oidcGrantRequests(nil, consent, &userSession)
+
+	assert.Len(t, extraClaims, 3)
+
+	require.Contains(t, extraClaims, oidc.ClaimAttributes)
+	assert.Equal(t, map[string][]string{"department": {"engineering"}}, extraClaims[oidc.ClaimAttributes])
+}
+
 var (
 	oidcUserSessionJohn = session.UserSession{
 		Username:    "john",
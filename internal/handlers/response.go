@@ -64,7 +64,7 @@ func handleOIDCWorkflowResponse(ctx *middlewares.AutheliaCtx, targetURI string)
 }
 
 // Handle1FAResponse handle the redirection upon 1FA authentication.
-func Handle1FAResponse(ctx *middlewares.AutheliaCtx, targetURI, requestMethod string, username string, groups []string) {
+func Handle1FAResponse(ctx *middlewares.AutheliaCtx, targetURI, requestMethod string, username string, groups []string, attributes map[string][]string) {
 	var err error
 
 	if len(targetURI) == 0 {
@@ -89,9 +89,10 @@ func Handle1FAResponse(ctx *middlewares.AutheliaCtx, targetURI, requestMethod st
 
 	_, requiredLevel := ctx.Providers.Authorizer.GetRequiredLevel(
 		authorization.Subject{
-			Username: username,
-			Groups:   groups,
-			IP:       ctx.RemoteIP(),
+			Username:   username,
+			Groups:     groups,
+			Attributes: attributes,
+			IP:         ctx.RemoteIP(),
 		},
 		authorization.NewObject(targetURL, requestMethod))
 
@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"time"
+
 	"github.com/authelia/authelia/v4/internal/authentication"
 )
 
@@ -9,6 +11,24 @@ type MethodList = []string
 
 type authorizationMatching int
 
+// authorizationAuthentication describes how and how long ago the user completed each factor of authentication.
+type authorizationAuthentication struct {
+	// Methods is a list of second factor authentication methods the user has completed.
+	Methods []string
+
+	FirstFactorAge  time.Duration
+	SecondFactorAge time.Duration
+}
+
+// authorizationStepUp describes the additional authentication a user has to complete before they're authorized.
+type authorizationStepUp struct {
+	// Methods is a list of authentication methods, at least one of which must be completed.
+	Methods []string
+
+	// Reauthenticate is the factor of authentication which must be completed again as it was completed too long ago.
+	Reauthenticate authentication.Level
+}
+
 // configurationBody the content returned by the configuration endpoint.
 type configurationBody struct {
 	AvailableMethods MethodList `json:"available_methods"`
@@ -104,6 +124,7 @@ type DuoSignResponse struct {
 type StateResponse struct {
 	Username              string               `json:"username"`
 	AuthenticationLevel   authentication.Level `json:"authentication_level"`
+	AuthenticationMethods []string             `json:"authentication_methods"`
 	DefaultRedirectionURL string               `json:"default_redirection_url"`
 }
 
@@ -117,6 +138,18 @@ type resetPasswordStep2RequestBody struct {
 	Password string `json:"password"`
 }
 
+// registerRequestBody model of the self-service registration (start) request body.
+type registerRequestBody struct {
+	Username    string `json:"username" valid:"required"`
+	DisplayName string `json:"displayname"`
+	Email       string `json:"email" valid:"required"`
+}
+
+// registerCompleteRequestBody model of the self-service registration (complete) request body.
+type registerCompleteRequestBody struct {
+	Password string `json:"password"`
+}
+
 // PasswordPolicyBody represents the response sent by the password reset step 2.
 type PasswordPolicyBody struct {
 	Mode             string `json:"mode"`
@@ -127,4 +160,5 @@ type PasswordPolicyBody struct {
 	RequireLowercase bool   `json:"require_lowercase"`
 	RequireNumber    bool   `json:"require_number"`
 	RequireSpecial   bool   `json:"require_special"`
+	Breached         bool   `json:"breached"`
 }
@@ -3,13 +3,16 @@ package handlers
 import (
 	"fmt"
 	"net/url"
+	"strings"
 
 	"github.com/go-webauthn/webauthn/protocol"
 	"github.com/go-webauthn/webauthn/webauthn"
 
+	"github.com/authelia/authelia/v4/internal/authorization"
 	"github.com/authelia/authelia/v4/internal/middlewares"
 	"github.com/authelia/authelia/v4/internal/model"
 	"github.com/authelia/authelia/v4/internal/session"
+	"github.com/authelia/authelia/v4/internal/utils"
 )
 
 func getWebAuthnUser(ctx *middlewares.AutheliaCtx, userSession session.UserSession) (user *model.WebauthnUser, err error) {
@@ -61,3 +64,13 @@ func newWebauthn(ctx *middlewares.AutheliaCtx) (w *webauthn.WebAuthn, err error)
 
 	return webauthn.New(config)
 }
+
+// isWebauthnUserVerificationRequired returns true if the user is stepping up to satisfy a rule which only accepts
+// Webauthn when the authenticator verified the user, in which case the assertion must require user verification
+// regardless of the configured user verification requirement.
+func isWebauthnUserVerificationRequired(ctx *middlewares.AutheliaCtx) bool {
+	methods := strings.Split(string(ctx.QueryArgs().Peek("am")), ",")
+
+	return utils.IsStringInSlice(authorization.AuthenticationMethodWebauthnUserVerified, methods) &&
+		!utils.IsStringInSlice(authorization.AuthenticationMethodWebauthn, methods)
+}
@@ -165,3 +165,30 @@ func TestWebauthnNewWebauthnShouldReturnErrWhenWebauthnNotConfigured(t *testing.
 	assert.Nil(t, w)
 	assert.EqualError(t, err, "Configuration error: Missing RPDisplayName")
 }
+
+func TestWebauthnIsUserVerificationRequired(t *testing.T) {
+	testCases := []struct {
+		name     string
+		am       string
+		expected bool
+	}{
+		{"ShouldNotRequireWithoutMethods", "", false},
+		{"ShouldNotRequireWebauthn", "webauthn", false},
+		{"ShouldRequireWebauthnUserVerified", "webauthn_user_verified", true},
+		{"ShouldRequireWebauthnUserVerifiedWithOtherMethods", "totp,webauthn_user_verified", true},
+		{"ShouldNotRequireWhenWebauthnIsSufficient", "webauthn_user_verified,webauthn", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := mocks.NewMockAutheliaCtx(t)
+			defer mock.Close()
+
+			if tc.am != "" {
+				mock.Ctx.QueryArgs().Add("am", tc.am)
+			}
+
+			assert.Equal(t, tc.expected, isWebauthnUserVerificationRequired(mock.Ctx))
+		})
+	}
+}
@@ -3,6 +3,7 @@ package metrics
 import (
 	"time"
 
+	"github.com/authelia/authelia/v4/internal/authentication"
 	"github.com/authelia/authelia/v4/internal/regulation"
 )
 
@@ -10,6 +11,8 @@ import (
 type Provider interface {
 	Recorder
 	regulation.MetricsRecorder
+	authentication.LDAPPoolMetricsRecorder
+	authentication.UserDetailsCacheMetricsRecorder
 }
 
 // Recorder of metrics.
@@ -25,6 +25,9 @@ type Prometheus struct {
 	reqVerifyCounter *prometheus.CounterVec
 	auth1FACounter   *prometheus.CounterVec
 	auth2FACounter   *prometheus.CounterVec
+	ldapPoolConns    *prometheus.GaugeVec
+	ldapPoolCounter  *prometheus.CounterVec
+	userCacheCounter *prometheus.CounterVec
 }
 
 // RecordRequest takes the statusCode string, requestMethod string, and the elapsed time.Duration to record the request and request duration metrics.
@@ -53,6 +56,22 @@ func (r *Prometheus) RecordAuthenticationDuration(success bool, elapsed time.Dur
 	r.authDuration.WithLabelValues(strconv.FormatBool(success)).Observe(elapsed.Seconds())
 }
 
+// RecordLDAPPoolConnections takes the number of active and idle connections to record the LDAP connection pool metrics.
+func (r *Prometheus) RecordLDAPPoolConnections(active, idle int) {
+	r.ldapPoolConns.WithLabelValues("active").Set(float64(active))
+	r.ldapPoolConns.WithLabelValues("idle").Set(float64(idle))
+}
+
+// RecordLDAPPoolGet takes the result string to record the LDAP connection pool request metrics.
+func (r *Prometheus) RecordLDAPPoolGet(result string) {
+	r.ldapPoolCounter.WithLabelValues(result).Inc()
+}
+
+// RecordUserDetailsCache takes the result string to record the user details cache lookup metrics.
+func (r *Prometheus) RecordUserDetailsCache(result string) {
+	r.userCacheCounter.WithLabelValues(result).Inc()
+}
+
 func (r *Prometheus) register() {
 	r.authDuration = promauto.NewHistogramVec(
 		prometheus.HistogramOpts{
@@ -109,4 +128,31 @@ func (r *Prometheus) register() {
 		},
 		[]string{"success", "banned", "type"},
 	)
+
+	r.ldapPoolConns = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: "authelia",
+			Name:      "ldap_pool_connections",
+			Help:      "The number of connections in the LDAP connection pool.",
+		},
+		[]string{"state"},
+	)
+
+	r.ldapPoolCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: "authelia",
+			Name:      "ldap_pool_get",
+			Help:      "The number of connections taken from the LDAP connection pool.",
+		},
+		[]string{"result"},
+	)
+
+	r.userCacheCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: "authelia",
+			Name:      "user_details_cache",
+			Help:      "The number of lookups of the details of users in the user details cache.",
+		},
+		[]string{"result"},
+	)
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/valyala/fasthttp"
 
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/geoip"
 	"github.com/authelia/authelia/v4/internal/logging"
 	"github.com/authelia/authelia/v4/internal/model"
 	"github.com/authelia/authelia/v4/internal/session"
@@ -33,6 +34,11 @@ func NewAutheliaCtx(requestCTX *fasthttp.RequestCtx, configuration schema.Config
 	ctx = new(AutheliaCtx)
 	ctx.RequestCtx = requestCTX
 	ctx.Providers = providers
+
+	if providers.AuthorizerStore != nil {
+		ctx.Providers.Authorizer = providers.AuthorizerStore.Load()
+	}
+
 	ctx.Configuration = configuration
 	ctx.Logger = NewRequestLogger(ctx)
 	ctx.Clock = utils.RealClock{}
@@ -275,6 +281,23 @@ func (ctx *AutheliaCtx) RemoteIP() net.IP {
 	return ctx.RequestCtx.RemoteIP()
 }
 
+// RemoteLocation returns the location of the client IP when a GeoIP provider is configured, otherwise it returns an
+// empty location.
+func (ctx *AutheliaCtx) RemoteLocation() (location geoip.Location) {
+	if ctx.Providers.GeoIP == nil {
+		return location
+	}
+
+	ip := ctx.RemoteIP()
+
+	location, err := ctx.Providers.GeoIP.Lookup(ip)
+	if err != nil {
+		ctx.Logger.WithError(err).Errorf("Failed to lookup the location of IP %s", ip)
+	}
+
+	return location
+}
+
 // GetOriginalURL extract the URL from the request headers (X-Original-URL or X-Forwarded-* headers).
 func (ctx *AutheliaCtx) GetOriginalURL() (*url.URL, error) {
 	originalURL := ctx.XOriginalURL()
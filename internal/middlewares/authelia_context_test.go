@@ -8,13 +8,36 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/valyala/fasthttp"
 
+	"github.com/authelia/authelia/v4/internal/authorization"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/middlewares"
 	"github.com/authelia/authelia/v4/internal/mocks"
 	"github.com/authelia/authelia/v4/internal/model"
 	"github.com/authelia/authelia/v4/internal/session"
+	"github.com/authelia/authelia/v4/internal/utils"
 )
 
+func TestShouldUseAuthorizerFromAuthorizerStore(t *testing.T) {
+	configuration := schema.Configuration{}
+	original := authorization.NewAuthorizer(&configuration, utils.RealClock{}, nil)
+
+	providers := middlewares.Providers{
+		Authorizer:      original,
+		AuthorizerStore: middlewares.NewAuthorizerStore(original),
+	}
+
+	ctx := middlewares.NewAutheliaCtx(&fasthttp.RequestCtx{}, configuration, providers)
+	assert.Same(t, original, ctx.Providers.Authorizer)
+
+	reloaded := authorization.NewAuthorizer(&configuration, utils.RealClock{}, nil)
+
+	providers.AuthorizerStore.Store(reloaded)
+
+	ctx = middlewares.NewAutheliaCtx(&fasthttp.RequestCtx{}, configuration, providers)
+	assert.Same(t, reloaded, ctx.Providers.Authorizer)
+	assert.Same(t, original, providers.Authorizer)
+}
+
 func TestShouldCallNextWithAutheliaCtx(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	ctx := &fasthttp.RequestCtx{}
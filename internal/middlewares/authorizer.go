@@ -0,0 +1,32 @@
+package middlewares
+
+import (
+	"sync/atomic"
+
+	"github.com/authelia/authelia/v4/internal/authorization"
+)
+
+// NewAuthorizerStore returns a new AuthorizerStore which initially holds the provided *authorization.Authorizer.
+func NewAuthorizerStore(authorizer *authorization.Authorizer) (store *AuthorizerStore) {
+	store = &AuthorizerStore{}
+
+	store.Store(authorizer)
+
+	return store
+}
+
+// AuthorizerStore holds the current *authorization.Authorizer and allows it to be atomically swapped while requests
+// are being served, for example when the access control rules are reloaded.
+type AuthorizerStore struct {
+	authorizer atomic.Pointer[authorization.Authorizer]
+}
+
+// Load returns the current *authorization.Authorizer.
+func (s *AuthorizerStore) Load() (authorizer *authorization.Authorizer) {
+	return s.authorizer.Load()
+}
+
+// Store atomically replaces the current *authorization.Authorizer.
+func (s *AuthorizerStore) Store(authorizer *authorization.Authorizer) {
+	s.authorizer.Store(authorizer)
+}
@@ -96,3 +96,22 @@ const (
 var protoHostSeparator = []byte("://")
 
 var errPasswordPolicyNoMet = errors.New("the supplied password does not met the security policy")
+
+// ErrPasswordPolicyBreached is returned by the password policy when the supplied password is present in the breached
+// password corpus.
+var ErrPasswordPolicyBreached = errors.New("the supplied password has appeared in a data breach")
+
+var errBreachedPasswordDumpLineTooLong = errors.New("the dump contains a line which exceeds the maximum length")
+
+const (
+	// breachedPasswordFilterMagic is the value the breached password bloom filter files begin with.
+	breachedPasswordFilterMagic = "ABPF"
+
+	breachedPasswordFilterVersion    = 1
+	breachedPasswordFilterHeaderSize = 24
+	breachedPasswordFilterMaxHashes  = 32
+
+	// breachedPasswordDumpMaxLineLength is the maximum length of a line in a HIBP SHA-1 dump, which is the hex encoded
+	// hash, a colon, the occurrence count, and the line ending.
+	breachedPasswordDumpMaxLineLength = 64
+)
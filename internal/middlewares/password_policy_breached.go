@@ -0,0 +1,346 @@
+package middlewares
+
+import (
+	"bytes"
+	"crypto/sha1" //nolint:gosec // SHA-1 is used to look up the password in the breached password corpus which uses it.
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+// NewBreachedPasswordPolicyProvider returns a password policy provider which checks passwords against the policy and
+// then rejects the passwords which are present in the breached password corpus at the configured path. The corpus is
+// either a bloom filter built with the 'authelia password-policy build-breached-filter' command which is loaded into
+// memory, or a HIBP SHA-1 dump ordered by hash which is searched on disk.
+func NewBreachedPasswordPolicyProvider(config schema.PasswordPolicyBreachedParams, policy PasswordPolicyProvider) (provider *BreachedPasswordPolicyProvider, err error) {
+	var corpus breachedPasswordCorpus
+
+	if corpus, err = openBreachedPasswordCorpus(config.Path); err != nil {
+		return nil, fmt.Errorf("error occurred loading the breached password corpus '%s': %w", config.Path, err)
+	}
+
+	return &BreachedPasswordPolicyProvider{policy: policy, corpus: corpus}, nil
+}
+
+// BreachedPasswordPolicyProvider handles breached password policy checking.
+type BreachedPasswordPolicyProvider struct {
+	policy PasswordPolicyProvider
+	corpus breachedPasswordCorpus
+}
+
+// Check checks the password against the policy and the breached password corpus.
+func (p BreachedPasswordPolicyProvider) Check(password string) (err error) {
+	if err = p.policy.Check(password); err != nil {
+		return err
+	}
+
+	var breached bool
+
+	//nolint:gosec // The corpus is indexed by the SHA-1 hash of the password.
+	if breached, err = p.corpus.Contains(sha1.Sum([]byte(password))); err != nil {
+		return fmt.Errorf("error occurred checking the breached password corpus: %w", err)
+	}
+
+	if breached {
+		return ErrPasswordPolicyBreached
+	}
+
+	return nil
+}
+
+type breachedPasswordCorpus interface {
+	Contains(digest [sha1.Size]byte) (breached bool, err error)
+}
+
+func openBreachedPasswordCorpus(path string) (corpus breachedPasswordCorpus, err error) {
+	var file *os.File
+
+	if file, err = os.Open(path); err != nil {
+		return nil, err
+	}
+
+	magic := make([]byte, len(breachedPasswordFilterMagic))
+
+	if _, err = io.ReadFull(file, magic); err == nil && string(magic) == breachedPasswordFilterMagic {
+		defer file.Close()
+
+		if _, err = file.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+
+		return ReadBreachedPasswordFilter(file)
+	}
+
+	// The dump is searched on disk so the file is kept open for the lifetime of the provider.
+	if corpus, err = newBreachedPasswordDump(file); err != nil {
+		file.Close()
+
+		return nil, err
+	}
+
+	return corpus, nil
+}
+
+// BreachedPasswordFilter is a bloom filter of the SHA-1 hashes of breached passwords. As the hashes are uniformly
+// distributed the bit indexes are derived from the hash itself using double hashing.
+type BreachedPasswordFilter struct {
+	bits  []byte
+	m     uint64
+	k     uint8
+	count uint64
+}
+
+// NewBreachedPasswordFilter returns a BreachedPasswordFilter sized to hold the number of hashes with the false positive
+// rate.
+func NewBreachedPasswordFilter(count uint64, rate float64) *BreachedPasswordFilter {
+	if count == 0 {
+		count = 1
+	}
+
+	m := uint64(math.Ceil(-float64(count) * math.Log(rate) / (math.Ln2 * math.Ln2)))
+	if m == 0 {
+		m = 1
+	}
+
+	k := math.Round(float64(m) / float64(count) * math.Ln2)
+
+	switch {
+	case k < 1:
+		k = 1
+	case k > breachedPasswordFilterMaxHashes:
+		k = breachedPasswordFilterMaxHashes
+	}
+
+	return &BreachedPasswordFilter{
+		bits: make([]byte, (m+7)/8),
+		m:    m,
+		k:    uint8(k),
+	}
+}
+
+// ReadBreachedPasswordFilter reads a BreachedPasswordFilter previously written with WriteTo.
+func ReadBreachedPasswordFilter(r io.Reader) (filter *BreachedPasswordFilter, err error) {
+	header := make([]byte, breachedPasswordFilterHeaderSize)
+
+	if _, err = io.ReadFull(r, header); err != nil {
+		return nil, fmt.Errorf("error reading the bloom filter header: %w", err)
+	}
+
+	if string(header[0:4]) != breachedPasswordFilterMagic {
+		return nil, errors.New("the file is not a breached password bloom filter")
+	}
+
+	if header[4] != breachedPasswordFilterVersion {
+		return nil, fmt.Errorf("the bloom filter version %d is not supported", header[4])
+	}
+
+	filter = &BreachedPasswordFilter{
+		k:     header[5],
+		m:     binary.BigEndian.Uint64(header[8:16]),
+		count: binary.BigEndian.Uint64(header[16:24]),
+	}
+
+	if filter.k == 0 || filter.k > breachedPasswordFilterMaxHashes || filter.m == 0 {
+		return nil, errors.New("the bloom filter header is invalid")
+	}
+
+	filter.bits = make([]byte, (filter.m+7)/8)
+
+	if _, err = io.ReadFull(r, filter.bits); err != nil {
+		return nil, fmt.Errorf("error reading the bloom filter: %w", err)
+	}
+
+	return filter, nil
+}
+
+// Add adds the SHA-1 hash of a breached password to the filter.
+func (f *BreachedPasswordFilter) Add(digest [sha1.Size]byte) {
+	h1, h2 := breachedPasswordFilterHashes(digest)
+
+	for i := uint64(0); i < uint64(f.k); i++ {
+		bit := (h1 + i*h2) % f.m
+
+		f.bits[bit/8] |= 1 << (bit % 8)
+	}
+
+	f.count++
+}
+
+// Contains returns true if the SHA-1 hash of a password is probably in the filter.
+func (f *BreachedPasswordFilter) Contains(digest [sha1.Size]byte) (breached bool, err error) {
+	h1, h2 := breachedPasswordFilterHashes(digest)
+
+	for i := uint64(0); i < uint64(f.k); i++ {
+		bit := (h1 + i*h2) % f.m
+
+		if f.bits[bit/8]&(1<<(bit%8)) == 0 {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// Count returns the number of hashes which have been added to the filter.
+func (f *BreachedPasswordFilter) Count() uint64 {
+	return f.count
+}
+
+// WriteTo writes the filter to the writer.
+func (f *BreachedPasswordFilter) WriteTo(w io.Writer) (n int64, err error) {
+	header := make([]byte, breachedPasswordFilterHeaderSize)
+
+	copy(header[0:4], breachedPasswordFilterMagic)
+
+	header[4], header[5] = breachedPasswordFilterVersion, f.k
+
+	binary.BigEndian.PutUint64(header[8:16], f.m)
+	binary.BigEndian.PutUint64(header[16:24], f.count)
+
+	var written int
+
+	if written, err = w.Write(header); err != nil {
+		return int64(written), err
+	}
+
+	n = int64(written)
+
+	written, err = w.Write(f.bits)
+
+	return n + int64(written), err
+}
+
+func breachedPasswordFilterHashes(digest [sha1.Size]byte) (h1, h2 uint64) {
+	return binary.BigEndian.Uint64(digest[0:8]), binary.BigEndian.Uint64(digest[8:16]) | 1
+}
+
+// breachedPasswordDump is a HIBP SHA-1 dump ordered by hash, i.e. lines of the upper case hex encoded hash, a colon, and
+// the number of occurrences, which is binary searched on disk.
+type breachedPasswordDump struct {
+	reader io.ReaderAt
+	size   int64
+}
+
+func newBreachedPasswordDump(file *os.File) (dump *breachedPasswordDump, err error) {
+	var info os.FileInfo
+
+	if info, err = file.Stat(); err != nil {
+		return nil, err
+	}
+
+	dump = &breachedPasswordDump{reader: file, size: info.Size()}
+
+	if _, _, err = dump.line(0); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("the file is empty")
+		}
+
+		return nil, err
+	}
+
+	return dump, nil
+}
+
+// Contains returns true if the SHA-1 hash of a password is in the dump.
+func (d *breachedPasswordDump) Contains(digest [sha1.Size]byte) (breached bool, err error) {
+	target := bytes.ToUpper([]byte(hex.EncodeToString(digest[:])))
+
+	var (
+		lo, hi int64 = 0, d.size
+		hash   []byte
+		next   int64
+	)
+
+	// The invariant is that the line containing the hash, if there is one, begins in the range [lo, hi).
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+
+		if hash, next, err = d.line(mid); err != nil {
+			if errors.Is(err, io.EOF) {
+				hi = mid
+
+				continue
+			}
+
+			return false, err
+		}
+
+		switch result := bytes.Compare(hash, target); {
+		case result == 0:
+			return true, nil
+		case result < 0:
+			lo = next
+		default:
+			hi = mid
+		}
+	}
+
+	return false, nil
+}
+
+// line returns the hash of the first line which begins at or after the offset along with the offset of the line which
+// follows it, or io.EOF if no line begins at or after the offset.
+func (d *breachedPasswordDump) line(offset int64) (hash []byte, next int64, err error) {
+	start := offset
+
+	// Reading from the byte before the offset ensures a line which begins exactly at the offset is not skipped.
+	if offset > 0 {
+		start = offset - 1
+	}
+
+	buf := make([]byte, breachedPasswordDumpMaxLineLength*2)
+
+	n, err := d.reader.ReadAt(buf, start)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, 0, err
+	}
+
+	buf = buf[:n]
+
+	i := 0
+
+	if offset > 0 {
+		if i = bytes.IndexByte(buf, '\n') + 1; i == 0 {
+			if start+int64(n) < d.size {
+				return nil, 0, errBreachedPasswordDumpLineTooLong
+			}
+
+			return nil, 0, io.EOF
+		}
+	}
+
+	line := buf[i:]
+
+	j := bytes.IndexByte(line, '\n')
+
+	switch {
+	case j != -1:
+		next = start + int64(i+j+1)
+	case start+int64(n) < d.size:
+		return nil, 0, errBreachedPasswordDumpLineTooLong
+	default:
+		j, next = len(line), d.size
+	}
+
+	if line = bytes.TrimRight(line[:j], "\r"); len(line) == 0 {
+		return nil, 0, io.EOF
+	}
+
+	if len(line) < sha1.Size*2 || (len(line) > sha1.Size*2 && line[sha1.Size*2] != ':') {
+		return nil, 0, fmt.Errorf("the line at offset %d is not a SHA-1 hash", start+int64(i))
+	}
+
+	hash = bytes.ToUpper(line[:sha1.Size*2])
+
+	if _, err = hex.Decode(make([]byte, sha1.Size), hash); err != nil {
+		return nil, 0, fmt.Errorf("the line at offset %d is not a SHA-1 hash: %w", start+int64(i), err)
+	}
+
+	return hash, next, nil
+}
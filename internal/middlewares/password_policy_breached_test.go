@@ -0,0 +1,153 @@
+package middlewares
+
+import (
+	"bytes"
+	"crypto/sha1" //nolint:gosec // SHA-1 is used by the breached password corpus.
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+var breachedPasswordsTest = []string{"password", "123456", "letmein", "qwerty", "monkey", "dragon", "iloveyou"}
+
+// breachedPasswordDumpTestContent returns a dump in the format of the Pwned Passwords SHA-1 ordered by hash download
+// which contains the breachedPasswordsTest passwords and 100 filler passwords.
+func breachedPasswordDumpTestContent(lineEnding string) []byte {
+	lines := make([]string, 0, len(breachedPasswordsTest)+100)
+
+	for i, password := range breachedPasswordsTest {
+		lines = append(lines, fmt.Sprintf("%X:%d", sha1.Sum([]byte(password)), 1000+i)) //nolint:gosec
+	}
+
+	for i := 0; i < 100; i++ {
+		lines = append(lines, fmt.Sprintf("%X:%d", sha1.Sum([]byte(fmt.Sprintf("filler-%d", i))), i+1)) //nolint:gosec
+	}
+
+	sort.Strings(lines)
+
+	return []byte(strings.Join(lines, lineEnding) + lineEnding)
+}
+
+func TestBreachedPasswordPolicyProviderShouldCheckDump(t *testing.T) {
+	for _, lineEnding := range []string{"\n", "\r\n"} {
+		t.Run(fmt.Sprintf("LineEnding%q", lineEnding), func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "pwned-passwords-sha1-ordered-by-hash.txt")
+
+			require.NoError(t, os.WriteFile(path, breachedPasswordDumpTestContent(lineEnding), 0600))
+
+			provider, err := NewBreachedPasswordPolicyProvider(schema.PasswordPolicyBreachedParams{Enabled: true, Path: path}, &StandardPasswordPolicyProvider{})
+			require.NoError(t, err)
+
+			for _, password := range breachedPasswordsTest {
+				assert.ErrorIs(t, provider.Check(password), ErrPasswordPolicyBreached, password)
+			}
+
+			for i := 0; i < 100; i++ {
+				assert.ErrorIs(t, provider.Check(fmt.Sprintf("filler-%d", i)), ErrPasswordPolicyBreached)
+			}
+
+			for _, password := range []string{"a really str0ng pass12nm3kjl12word@@#4", "correct horse battery staple", ""} {
+				assert.NoError(t, provider.Check(password), password)
+			}
+		})
+	}
+}
+
+func TestBreachedPasswordPolicyProviderShouldCheckFilter(t *testing.T) {
+	filter := NewBreachedPasswordFilter(uint64(len(breachedPasswordsTest)), 0.0001)
+
+	for _, password := range breachedPasswordsTest {
+		filter.Add(sha1.Sum([]byte(password))) //nolint:gosec
+	}
+
+	assert.Equal(t, uint64(len(breachedPasswordsTest)), filter.Count())
+
+	path := filepath.Join(t.TempDir(), "breached.bloom")
+
+	buf := &bytes.Buffer{}
+
+	n, err := filter.WriteTo(buf)
+	require.NoError(t, err)
+	assert.Equal(t, int64(buf.Len()), n)
+
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0600))
+
+	provider, err := NewBreachedPasswordPolicyProvider(schema.PasswordPolicyBreachedParams{Enabled: true, Path: path}, &StandardPasswordPolicyProvider{})
+	require.NoError(t, err)
+
+	assert.Equal(t, filter, provider.corpus)
+
+	for _, password := range breachedPasswordsTest {
+		assert.ErrorIs(t, provider.Check(password), ErrPasswordPolicyBreached, password)
+	}
+
+	assert.NoError(t, provider.Check("a really str0ng pass12nm3kjl12word@@#4"))
+}
+
+func TestBreachedPasswordPolicyProviderShouldCheckPolicyFirst(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pwned-passwords-sha1-ordered-by-hash.txt")
+
+	require.NoError(t, os.WriteFile(path, breachedPasswordDumpTestContent("\n"), 0600))
+
+	provider, err := NewBreachedPasswordPolicyProvider(schema.PasswordPolicyBreachedParams{Enabled: true, Path: path}, &StandardPasswordPolicyProvider{min: 8})
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, provider.Check("123456"), errPasswordPolicyNoMet)
+	assert.ErrorIs(t, provider.Check("password"), ErrPasswordPolicyBreached)
+	assert.NoError(t, provider.Check("correct horse battery staple"))
+}
+
+func TestBreachedPasswordPolicyProviderShouldReturnErrorForInvalidCorpus(t *testing.T) {
+	dir := t.TempDir()
+
+	testCases := []struct {
+		desc, have, expected string
+	}{
+		{"ShouldErrorEmpty", "", "error occurred loading the breached password corpus '%s': the file is empty"},
+		{"ShouldErrorNotHash", "password:123\n", "error occurred loading the breached password corpus '%s': the line at offset 0 is not a SHA-1 hash"},
+		{"ShouldErrorNotHex", strings.Repeat("Z", 40) + ":1\n", "error occurred loading the breached password corpus '%s': the line at offset 0 is not a SHA-1 hash: encoding/hex: invalid byte: U+005A 'Z'"},
+		{"ShouldErrorBadFilterVersion", breachedPasswordFilterMagic + strings.Repeat("\x02", 20), "error occurred loading the breached password corpus '%s': the bloom filter version 2 is not supported"},
+		{"ShouldErrorTruncatedFilter", breachedPasswordFilterMagic + "\x01", "error occurred loading the breached password corpus '%s': error reading the bloom filter header: unexpected EOF"},
+	}
+
+	for i, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			path := filepath.Join(dir, fmt.Sprintf("corpus-%d", i))
+
+			require.NoError(t, os.WriteFile(path, []byte(tc.have), 0600))
+
+			provider, err := NewBreachedPasswordPolicyProvider(schema.PasswordPolicyBreachedParams{Enabled: true, Path: path}, &StandardPasswordPolicyProvider{})
+			assert.EqualError(t, err, fmt.Sprintf(tc.expected, path))
+			assert.Nil(t, provider)
+		})
+	}
+
+	_, err := NewBreachedPasswordPolicyProvider(schema.PasswordPolicyBreachedParams{Enabled: true, Path: filepath.Join(dir, "missing")}, &StandardPasswordPolicyProvider{})
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestBreachedPasswordFilterShouldHaveLowFalsePositiveRate(t *testing.T) {
+	filter := NewBreachedPasswordFilter(1000, 0.01)
+
+	for i := 0; i < 1000; i++ {
+		filter.Add(sha1.Sum([]byte(fmt.Sprintf("breached-%d", i)))) //nolint:gosec
+	}
+
+	falsePositives := 0
+
+	for i := 0; i < 10000; i++ {
+		if breached, _ := filter.Contains(sha1.Sum([]byte(fmt.Sprintf("other-%d", i)))); breached { //nolint:gosec
+			falsePositives++
+		}
+	}
+
+	assert.Less(t, falsePositives, 300)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/authelia/authelia/v4/internal/authentication"
 	"github.com/authelia/authelia/v4/internal/authorization"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/geoip"
 	"github.com/authelia/authelia/v4/internal/metrics"
 	"github.com/authelia/authelia/v4/internal/notification"
 	"github.com/authelia/authelia/v4/internal/ntp"
@@ -38,12 +39,18 @@ type Providers struct {
 	OpenIDConnect   oidc.OpenIDConnectProvider
 	Metrics         metrics.Provider
 	NTP             *ntp.Provider
+	GeoIP           geoip.Provider
 	UserProvider    authentication.UserProvider
 	StorageProvider storage.Provider
 	Notifier        notification.Notifier
 	Templates       *templates.Provider
 	TOTP            totp.Provider
 	PasswordPolicy  PasswordPolicyProvider
+	PasswordHistory *authentication.PasswordHistory
+
+	// AuthorizerStore when configured is the source of the Authorizer for each request, allowing the Authorizer to be
+	// swapped when the access control rules are reloaded.
+	AuthorizerStore *AuthorizerStore
 }
 
 // RequestHandler represents an Authelia request handler.
@@ -166,6 +166,20 @@ func (mr *MockStorageMockRecorder) DeleteTOTPConfiguration(arg0, arg1 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTOTPConfiguration", reflect.TypeOf((*MockStorage)(nil).DeleteTOTPConfiguration), arg0, arg1)
 }
 
+// DeleteUserAccount mocks base method.
+func (m *MockStorage) DeleteUserAccount(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserAccount", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserAccount indicates an expected call of DeleteUserAccount.
+func (mr *MockStorageMockRecorder) DeleteUserAccount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserAccount", reflect.TypeOf((*MockStorage)(nil).DeleteUserAccount), arg0, arg1)
+}
+
 // FindIdentityVerification mocks base method.
 func (m *MockStorage) FindIdentityVerification(arg0 context.Context, arg1 string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -316,6 +330,21 @@ func (mr *MockStorageMockRecorder) LoadTOTPConfigurations(arg0, arg1, arg2 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadTOTPConfigurations", reflect.TypeOf((*MockStorage)(nil).LoadTOTPConfigurations), arg0, arg1, arg2)
 }
 
+// LoadUserAccount mocks base method.
+func (m *MockStorage) LoadUserAccount(arg0 context.Context, arg1 string) (*model.UserAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadUserAccount", arg0, arg1)
+	ret0, _ := ret[0].(*model.UserAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadUserAccount indicates an expected call of LoadUserAccount.
+func (mr *MockStorageMockRecorder) LoadUserAccount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadUserAccount", reflect.TypeOf((*MockStorage)(nil).LoadUserAccount), arg0, arg1)
+}
+
 // LoadUserInfo mocks base method.
 func (m *MockStorage) LoadUserInfo(arg0 context.Context, arg1 string) (model.UserInfo, error) {
 	m.ctrl.T.Helper()
@@ -376,6 +405,21 @@ func (mr *MockStorageMockRecorder) LoadUserOpaqueIdentifiers(arg0 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadUserOpaqueIdentifiers", reflect.TypeOf((*MockStorage)(nil).LoadUserOpaqueIdentifiers), arg0)
 }
 
+// LoadUserPasswordHistory mocks base method.
+func (m *MockStorage) LoadUserPasswordHistory(arg0 context.Context, arg1 string, arg2 int) ([]model.UserPasswordHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadUserPasswordHistory", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]model.UserPasswordHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadUserPasswordHistory indicates an expected call of LoadUserPasswordHistory.
+func (mr *MockStorageMockRecorder) LoadUserPasswordHistory(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadUserPasswordHistory", reflect.TypeOf((*MockStorage)(nil).LoadUserPasswordHistory), arg0, arg1, arg2)
+}
+
 // LoadWebauthnDevices mocks base method.
 func (m *MockStorage) LoadWebauthnDevices(arg0 context.Context, arg1, arg2 int) ([]model.WebauthnDevice, error) {
 	m.ctrl.T.Helper()
@@ -406,6 +450,21 @@ func (mr *MockStorageMockRecorder) LoadWebauthnDevicesByUsername(arg0, arg1 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadWebauthnDevicesByUsername", reflect.TypeOf((*MockStorage)(nil).LoadWebauthnDevicesByUsername), arg0, arg1)
 }
 
+// ReplaceUserAccountPassword mocks base method.
+func (m *MockStorage) ReplaceUserAccountPassword(arg0 context.Context, arg1, arg2, arg3 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceUserAccountPassword", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplaceUserAccountPassword indicates an expected call of ReplaceUserAccountPassword.
+func (mr *MockStorageMockRecorder) ReplaceUserAccountPassword(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceUserAccountPassword", reflect.TypeOf((*MockStorage)(nil).ReplaceUserAccountPassword), arg0, arg1, arg2, arg3)
+}
+
 // RevokeOAuth2Session mocks base method.
 func (m *MockStorage) RevokeOAuth2Session(arg0 context.Context, arg1 storage.OAuth2SessionType, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -588,6 +647,20 @@ func (mr *MockStorageMockRecorder) SaveTOTPConfiguration(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTOTPConfiguration", reflect.TypeOf((*MockStorage)(nil).SaveTOTPConfiguration), arg0, arg1)
 }
 
+// SaveUserAccount mocks base method.
+func (m *MockStorage) SaveUserAccount(arg0 context.Context, arg1 model.UserAccount) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveUserAccount", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveUserAccount indicates an expected call of SaveUserAccount.
+func (mr *MockStorageMockRecorder) SaveUserAccount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveUserAccount", reflect.TypeOf((*MockStorage)(nil).SaveUserAccount), arg0, arg1)
+}
+
 // SaveUserOpaqueIdentifier mocks base method.
 func (m *MockStorage) SaveUserOpaqueIdentifier(arg0 context.Context, arg1 model.UserOpaqueIdentifier) error {
 	m.ctrl.T.Helper()
@@ -602,6 +675,20 @@ func (mr *MockStorageMockRecorder) SaveUserOpaqueIdentifier(arg0, arg1 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveUserOpaqueIdentifier", reflect.TypeOf((*MockStorage)(nil).SaveUserOpaqueIdentifier), arg0, arg1)
 }
 
+// SaveUserPasswordHistory mocks base method.
+func (m *MockStorage) SaveUserPasswordHistory(arg0 context.Context, arg1 model.UserPasswordHistory, arg2 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveUserPasswordHistory", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveUserPasswordHistory indicates an expected call of SaveUserPasswordHistory.
+func (mr *MockStorageMockRecorder) SaveUserPasswordHistory(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveUserPasswordHistory", reflect.TypeOf((*MockStorage)(nil).SaveUserPasswordHistory), arg0, arg1, arg2)
+}
+
 // SaveWebauthnDevice mocks base method.
 func (m *MockStorage) SaveWebauthnDevice(arg0 context.Context, arg1 model.WebauthnDevice) error {
 	m.ctrl.T.Helper()
@@ -776,6 +863,34 @@ func (mr *MockStorageMockRecorder) UpdateTOTPConfigurationSignIn(arg0, arg1, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTOTPConfigurationSignIn", reflect.TypeOf((*MockStorage)(nil).UpdateTOTPConfigurationSignIn), arg0, arg1, arg2)
 }
 
+// UpdateUserAccountGroups mocks base method.
+func (m *MockStorage) UpdateUserAccountGroups(arg0 context.Context, arg1 string, arg2 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserAccountGroups", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserAccountGroups indicates an expected call of UpdateUserAccountGroups.
+func (mr *MockStorageMockRecorder) UpdateUserAccountGroups(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserAccountGroups", reflect.TypeOf((*MockStorage)(nil).UpdateUserAccountGroups), arg0, arg1, arg2)
+}
+
+// UpdateUserAccountPassword mocks base method.
+func (m *MockStorage) UpdateUserAccountPassword(arg0 context.Context, arg1 string, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserAccountPassword", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserAccountPassword indicates an expected call of UpdateUserAccountPassword.
+func (mr *MockStorageMockRecorder) UpdateUserAccountPassword(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserAccountPassword", reflect.TypeOf((*MockStorage)(nil).UpdateUserAccountPassword), arg0, arg1, arg2)
+}
+
 // UpdateWebauthnDeviceSignIn mocks base method.
 func (m *MockStorage) UpdateWebauthnDeviceSignIn(arg0 context.Context, arg1 int, arg2 string, arg3 *time.Time, arg4 uint32, arg5 bool) error {
 	m.ctrl.T.Helper()
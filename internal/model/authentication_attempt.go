@@ -1,6 +1,7 @@
 package model
 
 import (
+	"database/sql"
 	"time"
 )
 
@@ -15,4 +16,7 @@ type AuthenticationAttempt struct {
 	RemoteIP      NullIP    `db:"remote_ip"`
 	RequestURI    string    `db:"request_uri"`
 	RequestMethod string    `db:"request_method"`
+
+	Country sql.NullString `db:"country"`
+	ASN     sql.NullInt64  `db:"asn"`
 }
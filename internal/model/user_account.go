@@ -0,0 +1,27 @@
+package model
+
+import (
+	"time"
+)
+
+// UserAccount represents a user account stored in the database which is used by the SQL authentication backend.
+type UserAccount struct {
+	ID          int       `db:"id"`
+	CreatedAt   time.Time `db:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+	Username    string    `db:"username"`
+	Password    string    `db:"password"`
+	DisplayName string    `db:"display_name"`
+	Email       string    `db:"email"`
+
+	Groups []string `db:"-"`
+}
+
+// UserPasswordHistory represents a previous password hash of a user stored in the database which is used to enforce the
+// password history of the password policy.
+type UserPasswordHistory struct {
+	ID        int       `db:"id"`
+	CreatedAt time.Time `db:"created_at"`
+	Username  string    `db:"username"`
+	Password  string    `db:"password"`
+}
@@ -17,6 +17,7 @@ const (
 	ClaimEmail             = "email"
 	ClaimEmailVerified     = "email_verified"
 	ClaimEmailAlts         = "alt_emails"
+	ClaimAttributes        = "attributes"
 )
 
 // Endpoints.
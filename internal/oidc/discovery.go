@@ -48,6 +48,7 @@ func NewOpenIDConnectWellKnownConfiguration(enablePKCEPlainChallenge, pairwise b
 				ClaimGroups,
 				ClaimPreferredUsername,
 				ClaimDisplayName,
+				ClaimAttributes,
 			},
 		},
 		OAuth2DiscoveryOptions: OAuth2DiscoveryOptions{
@@ -170,7 +170,7 @@ func TestOpenIDConnectProvider_NewOpenIDConnectProvider_GetOpenIDConnectWellKnow
 	assert.Contains(t, disco.RequestObjectSigningAlgValuesSupported, "RS256")
 	assert.Contains(t, disco.RequestObjectSigningAlgValuesSupported, "none")
 
-	assert.Len(t, disco.ClaimsSupported, 18)
+	assert.Len(t, disco.ClaimsSupported, 19)
 	assert.Contains(t, disco.ClaimsSupported, "amr")
 	assert.Contains(t, disco.ClaimsSupported, "aud")
 	assert.Contains(t, disco.ClaimsSupported, "azp")
@@ -189,6 +189,7 @@ func TestOpenIDConnectProvider_NewOpenIDConnectProvider_GetOpenIDConnectWellKnow
 	assert.Contains(t, disco.ClaimsSupported, ClaimGroups)
 	assert.Contains(t, disco.ClaimsSupported, ClaimPreferredUsername)
 	assert.Contains(t, disco.ClaimsSupported, ClaimDisplayName)
+	assert.Contains(t, disco.ClaimsSupported, ClaimAttributes)
 }
 
 func TestOpenIDConnectProvider_NewOpenIDConnectProvider_GetOAuth2WellKnownConfiguration(t *testing.T) {
@@ -247,7 +248,7 @@ func TestOpenIDConnectProvider_NewOpenIDConnectProvider_GetOAuth2WellKnownConfig
 	assert.Contains(t, disco.ResponseTypesSupported, "code token id_token")
 	assert.Contains(t, disco.ResponseTypesSupported, "none")
 
-	assert.Len(t, disco.ClaimsSupported, 18)
+	assert.Len(t, disco.ClaimsSupported, 19)
 	assert.Contains(t, disco.ClaimsSupported, "amr")
 	assert.Contains(t, disco.ClaimsSupported, "aud")
 	assert.Contains(t, disco.ClaimsSupported, "azp")
@@ -266,6 +267,7 @@ func TestOpenIDConnectProvider_NewOpenIDConnectProvider_GetOAuth2WellKnownConfig
 	assert.Contains(t, disco.ClaimsSupported, ClaimGroups)
 	assert.Contains(t, disco.ClaimsSupported, ClaimPreferredUsername)
 	assert.Contains(t, disco.ClaimsSupported, ClaimDisplayName)
+	assert.Contains(t, disco.ClaimsSupported, ClaimAttributes)
 }
 
 func TestOpenIDConnectProvider_NewOpenIDConnectProvider_GetOpenIDConnectWellKnownConfigurationWithPlainPKCE(t *testing.T) {
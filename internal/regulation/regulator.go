@@ -2,6 +2,7 @@ package regulation
 
 import (
 	"context"
+	"database/sql"
 	"strings"
 	"time"
 
@@ -26,6 +27,8 @@ func NewRegulator(config schema.RegulationConfiguration, provider storage.Regula
 func (r *Regulator) Mark(ctx Context, successful, banned bool, username, requestURI, requestMethod, authType string) error {
 	ctx.RecordAuthentication(successful, banned, strings.ToLower(authType))
 
+	location := ctx.RemoteLocation()
+
 	return r.storageProvider.AppendAuthenticationLog(ctx, model.AuthenticationAttempt{
 		Time:          r.clock.Now(),
 		Successful:    successful,
@@ -35,6 +38,8 @@ func (r *Regulator) Mark(ctx Context, successful, banned bool, username, request
 		RemoteIP:      model.NewNullIP(ctx.RemoteIP()),
 		RequestURI:    requestURI,
 		RequestMethod: requestMethod,
+		Country:       sql.NullString{String: location.Country, Valid: location.Country != ""},
+		ASN:           sql.NullInt64{Int64: int64(location.ASN), Valid: location.ASN != 0},
 	})
 }
 
@@ -5,6 +5,7 @@ import (
 	"net"
 
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/geoip"
 	"github.com/authelia/authelia/v4/internal/storage"
 	"github.com/authelia/authelia/v4/internal/utils"
 )
@@ -27,6 +28,7 @@ type Context interface {
 	MetricsRecorder
 
 	RemoteIP() (ip net.IP)
+	RemoteLocation() (location geoip.Location)
 }
 
 // MetricsRecorder represents the methods used to record regulation.
@@ -97,6 +97,8 @@ func handleRouter(config schema.Configuration, providers middlewares.Providers)
 
 	resetPasswordCustomURL := config.AuthenticationBackend.PasswordReset.CustomURL.String()
 
+	registration := strconv.FormatBool(config.AuthenticationBackend.Registration.Enable)
+
 	duoSelfEnrollment := f
 	if !config.DuoAPI.Disable {
 		duoSelfEnrollment = strconv.FormatBool(config.DuoAPI.EnableSelfEnrollment)
@@ -104,9 +106,9 @@ func handleRouter(config schema.Configuration, providers middlewares.Providers)
 
 	https := config.Server.TLS.Key != "" && config.Server.TLS.Certificate != ""
 
-	serveIndexHandler := ServeTemplatedFile(assetsRoot, fileIndexHTML, config.Server.AssetPath, duoSelfEnrollment, rememberMe, resetPassword, resetPasswordCustomURL, config.Session.Name, config.Theme, https)
-	serveSwaggerHandler := ServeTemplatedFile(assetsSwagger, fileIndexHTML, config.Server.AssetPath, duoSelfEnrollment, rememberMe, resetPassword, resetPasswordCustomURL, config.Session.Name, config.Theme, https)
-	serveSwaggerAPIHandler := ServeTemplatedFile(assetsSwagger, fileOpenAPI, config.Server.AssetPath, duoSelfEnrollment, rememberMe, resetPassword, resetPasswordCustomURL, config.Session.Name, config.Theme, https)
+	serveIndexHandler := ServeTemplatedFile(assetsRoot, fileIndexHTML, config.Server.AssetPath, duoSelfEnrollment, rememberMe, resetPassword, resetPasswordCustomURL, registration, config.Session.Name, config.Theme, https)
+	serveSwaggerHandler := ServeTemplatedFile(assetsSwagger, fileIndexHTML, config.Server.AssetPath, duoSelfEnrollment, rememberMe, resetPassword, resetPasswordCustomURL, registration, config.Session.Name, config.Theme, https)
+	serveSwaggerAPIHandler := ServeTemplatedFile(assetsSwagger, fileOpenAPI, config.Server.AssetPath, duoSelfEnrollment, rememberMe, resetPassword, resetPasswordCustomURL, registration, config.Session.Name, config.Theme, https)
 
 	handlerPublicHTML := newPublicHTMLEmbeddedHandler()
 	handlerLocales := newLocalesEmbeddedHandler()
@@ -183,6 +185,14 @@ func handleRouter(config schema.Configuration, providers middlewares.Providers)
 		r.POST("/api/reset-password", middlewareAPI(handlers.ResetPasswordPOST))
 	}
 
+	// Only register endpoints if self-service registration is enabled.
+	if config.AuthenticationBackend.Registration.Enable {
+		// Registration related endpoints.
+		r.POST("/api/register", middlewareAPI(handlers.RegisterIdentityStart))
+		r.POST("/api/register/identity/finish", middlewareAPI(handlers.RegisterIdentityFinish))
+		r.POST("/api/register/complete", middlewareAPI(handlers.RegisterPOST))
+	}
+
 	// Information about the user.
 	r.GET("/api/user/info", middleware1FA(handlers.UserInfoGET))
 	r.POST("/api/user/info", middleware1FA(handlers.UserInfoPOST))
@@ -27,6 +27,9 @@ type UserSession struct {
 	Groups []string
 	Emails []string
 
+	// Attributes are the configured extra attributes of the user keyed by the lowercase attribute name.
+	Attributes map[string][]string
+
 	KeepMeLoggedIn      bool
 	AuthenticationLevel authentication.Level
 	LastActivity        int64
@@ -43,9 +46,22 @@ type UserSession struct {
 	// while doing the query actually updating the password.
 	PasswordResetUsername *string
 
+	// Registration holds the details of the pending self-service registration for this session.
+	Registration *Registration
+
 	RefreshTTL time.Time
 }
 
+// Registration is the details of a pending self-service registration.
+type Registration struct {
+	Username    string
+	DisplayName string
+	Email       string
+
+	// Verified is set to true after the identity verification of the email address has completed.
+	Verified bool
+}
+
 // Identity identity of the user who is being verified.
 type Identity struct {
 	Username    string
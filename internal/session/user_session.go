@@ -29,6 +29,23 @@ func (s *UserSession) SetOneFactor(now time.Time, details *authentication.UserDe
 	s.DisplayName = details.DisplayName
 	s.Groups = details.Groups
 	s.Emails = details.Emails
+	s.Attributes = details.Attributes
+
+	s.AuthenticationMethodRefs.UsernameAndPassword = true
+}
+
+// SetOneFactorReauthenticated sets the 1FA AMR's and the first factor timestamp when the user of this session completes
+// the first factor again, retaining the authentication level and the second factor of the session.
+func (s *UserSession) SetOneFactorReauthenticated(now time.Time, details *authentication.UserDetails, keepMeLoggedIn bool) {
+	s.FirstFactorAuthnTimestamp = now.Unix()
+	s.LastActivity = now.Unix()
+
+	s.KeepMeLoggedIn = keepMeLoggedIn
+
+	s.DisplayName = details.DisplayName
+	s.Groups = details.Groups
+	s.Emails = details.Emails
+	s.Attributes = details.Attributes
 
 	s.AuthenticationMethodRefs.UsernameAndPassword = true
 }
@@ -60,6 +77,28 @@ func (s *UserSession) SetTwoFactorWebauthn(now time.Time, userPresence, userVeri
 	s.Webauthn = nil
 }
 
+// AuthenticationMethods returns the second factor authentication methods this session has completed using the names
+// used by the access control rules.
+func (s *UserSession) AuthenticationMethods() (methods []string) {
+	if s.AuthenticationMethodRefs.Webauthn {
+		methods = append(methods, authorization.AuthenticationMethodWebauthn)
+
+		if s.AuthenticationMethodRefs.WebauthnUserVerified {
+			methods = append(methods, authorization.AuthenticationMethodWebauthnUserVerified)
+		}
+	}
+
+	if s.AuthenticationMethodRefs.TOTP {
+		methods = append(methods, authorization.AuthenticationMethodTOTP)
+	}
+
+	if s.AuthenticationMethodRefs.Duo {
+		methods = append(methods, authorization.AuthenticationMethodDuo)
+	}
+
+	return methods
+}
+
 // AuthenticatedTime returns the unix timestamp this session authenticated successfully at the given level.
 func (s *UserSession) AuthenticatedTime(level authorization.Level) (authenticatedTime time.Time, err error) {
 	switch level {
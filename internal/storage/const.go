@@ -9,6 +9,9 @@ const (
 	tableDuoDevices           = "duo_devices"
 	tableIdentityVerification = "identity_verification"
 	tableTOTPConfigurations   = "totp_configurations"
+	tableUserAccounts         = "user_accounts"
+	tableUserGroups           = "user_groups"
+	tableUserPasswordHistory  = "user_password_history"
 	tableUserOpaqueIdentifier = "user_opaque_identifier"
 	tableUserPreferences      = "user_preferences"
 	tableWebauthnDevices      = "webauthn_devices"
@@ -78,7 +81,7 @@ const (
 
 const (
 	// This is the latest schema version for the purpose of tests.
-	testLatestVersion = 5
+	testLatestVersion = 8
 )
 
 const (
@@ -0,0 +1,2 @@
+ALTER TABLE authentication_logs DROP COLUMN country;
+ALTER TABLE authentication_logs DROP COLUMN asn;
@@ -0,0 +1,2 @@
+ALTER TABLE authentication_logs ADD COLUMN country CHAR(2) NULL DEFAULT NULL;
+ALTER TABLE authentication_logs ADD COLUMN asn BIGINT NULL DEFAULT NULL;
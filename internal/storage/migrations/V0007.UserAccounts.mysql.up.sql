@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS user_accounts (
+    id INTEGER AUTO_INCREMENT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    username VARCHAR(100) NOT NULL,
+    password VARCHAR(512) NOT NULL,
+    display_name VARCHAR(100) NOT NULL,
+    email VARCHAR(255) NOT NULL DEFAULT '',
+    PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX user_accounts_username_key ON user_accounts (username);
+
+CREATE TABLE IF NOT EXISTS user_groups (
+    id INTEGER AUTO_INCREMENT,
+    username VARCHAR(100) NOT NULL,
+    group_name VARCHAR(100) NOT NULL,
+    PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX user_groups_username_group_name_key ON user_groups (username, group_name);
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS user_accounts (
+    id SERIAL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    username VARCHAR(100) NOT NULL,
+    password VARCHAR(512) NOT NULL,
+    display_name VARCHAR(100) NOT NULL,
+    email VARCHAR(255) NOT NULL DEFAULT '',
+    PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX user_accounts_username_key ON user_accounts (username);
+
+CREATE TABLE IF NOT EXISTS user_groups (
+    id SERIAL,
+    username VARCHAR(100) NOT NULL,
+    group_name VARCHAR(100) NOT NULL,
+    PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX user_groups_username_group_name_key ON user_groups (username, group_name);
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS user_password_history (
+    id INTEGER AUTO_INCREMENT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    username VARCHAR(100) NOT NULL,
+    password VARCHAR(512) NOT NULL,
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX user_password_history_username_idx ON user_password_history (username);
@@ -16,6 +16,9 @@ type Provider interface {
 
 	RegulatorProvider
 
+	UserAccountProvider
+	UserPasswordHistoryProvider
+
 	storage.Transactional
 
 	SavePreferred2FAMethod(ctx context.Context, username string, method string) (err error)
@@ -83,3 +86,21 @@ type RegulatorProvider interface {
 	AppendAuthenticationLog(ctx context.Context, attempt model.AuthenticationAttempt) (err error)
 	LoadAuthenticationLogs(ctx context.Context, username string, fromDate time.Time, limit, page int) (attempts []model.AuthenticationAttempt, err error)
 }
+
+// UserAccountProvider is an interface providing storage capabilities for persisting the user accounts of the SQL
+// authentication backend.
+type UserAccountProvider interface {
+	SaveUserAccount(ctx context.Context, account model.UserAccount) (err error)
+	LoadUserAccount(ctx context.Context, username string) (account *model.UserAccount, err error)
+	UpdateUserAccountPassword(ctx context.Context, username, password string) (err error)
+	ReplaceUserAccountPassword(ctx context.Context, username, oldPassword, newPassword string) (replaced bool, err error)
+	UpdateUserAccountGroups(ctx context.Context, username string, groups []string) (err error)
+	DeleteUserAccount(ctx context.Context, username string) (err error)
+}
+
+// UserPasswordHistoryProvider is an interface providing storage capabilities for persisting the previous password hashes
+// of users which are used to enforce the password history of the password policy.
+type UserPasswordHistoryProvider interface {
+	SaveUserPasswordHistory(ctx context.Context, history model.UserPasswordHistory, keep int) (err error)
+	LoadUserPasswordHistory(ctx context.Context, username string, limit int) (history []model.UserPasswordHistory, err error)
+}
@@ -64,6 +64,22 @@ func NewSQLProvider(config *schema.Configuration, name, driverName, dataSourceNa
 		sqlSelectPreferred2FAMethod: fmt.Sprintf(queryFmtSelectPreferred2FAMethod, tableUserPreferences),
 		sqlSelectUserInfo:           fmt.Sprintf(queryFmtSelectUserInfo, tableTOTPConfigurations, tableWebauthnDevices, tableDuoDevices, tableUserPreferences),
 
+		sqlSelectUserAccount:          fmt.Sprintf(queryFmtSelectUserAccount, tableUserAccounts),
+		sqlInsertUserAccount:          fmt.Sprintf(queryFmtInsertUserAccount, tableUserAccounts),
+		sqlUpdateUserAccountPassword:  fmt.Sprintf(queryFmtUpdateUserAccountPassword, tableUserAccounts),
+		sqlReplaceUserAccountPassword: fmt.Sprintf(queryFmtReplaceUserAccountPassword, tableUserAccounts),
+		sqlDeleteUserAccount:          fmt.Sprintf(queryFmtDeleteUserAccount, tableUserAccounts),
+
+		sqlSelectUserGroups: fmt.Sprintf(queryFmtSelectUserGroups, tableUserGroups),
+		sqlInsertUserGroup:  fmt.Sprintf(queryFmtInsertUserGroup, tableUserGroups),
+		sqlDeleteUserGroups: fmt.Sprintf(queryFmtDeleteUserGroups, tableUserGroups),
+
+		sqlSelectUserPasswordHistory:         fmt.Sprintf(queryFmtSelectUserPasswordHistory, tableUserPasswordHistory),
+		sqlInsertUserPasswordHistory:         fmt.Sprintf(queryFmtInsertUserPasswordHistory, tableUserPasswordHistory),
+		sqlSelectUserPasswordHistoryOldestID: fmt.Sprintf(queryFmtSelectUserPasswordHistoryOldestID, tableUserPasswordHistory),
+		sqlDeleteUserPasswordHistoryBefore:   fmt.Sprintf(queryFmtDeleteUserPasswordHistoryBefore, tableUserPasswordHistory),
+		sqlDeleteUserPasswordHistory:         fmt.Sprintf(queryFmtDeleteUserPasswordHistory, tableUserPasswordHistory),
+
 		sqlInsertUserOpaqueIdentifier:            fmt.Sprintf(queryFmtInsertUserOpaqueIdentifier, tableUserOpaqueIdentifier),
 		sqlSelectUserOpaqueIdentifier:            fmt.Sprintf(queryFmtSelectUserOpaqueIdentifier, tableUserOpaqueIdentifier),
 		sqlSelectUserOpaqueIdentifiers:           fmt.Sprintf(queryFmtSelectUserOpaqueIdentifiers, tableUserOpaqueIdentifier),
@@ -179,6 +195,25 @@ type SQLProvider struct {
 	sqlSelectPreferred2FAMethod string
 	sqlSelectUserInfo           string
 
+	// Table: user_accounts.
+	sqlSelectUserAccount          string
+	sqlInsertUserAccount          string
+	sqlUpdateUserAccountPassword  string
+	sqlReplaceUserAccountPassword string
+	sqlDeleteUserAccount          string
+
+	// Table: user_groups.
+	sqlSelectUserGroups string
+	sqlInsertUserGroup  string
+	sqlDeleteUserGroups string
+
+	// Table: user_password_history.
+	sqlSelectUserPasswordHistory         string
+	sqlInsertUserPasswordHistory         string
+	sqlSelectUserPasswordHistoryOldestID string
+	sqlDeleteUserPasswordHistoryBefore   string
+	sqlDeleteUserPasswordHistory         string
+
 	// Table: user_opaque_identifier.
 	sqlInsertUserOpaqueIdentifier            string
 	sqlSelectUserOpaqueIdentifier            string
@@ -328,6 +363,193 @@ func (p *SQLProvider) Rollback(ctx context.Context) (err error) {
 	return tx.Rollback()
 }
 
+// SaveUserAccount saves a new user account and the groups of the user account to the database.
+func (p *SQLProvider) SaveUserAccount(ctx context.Context, account model.UserAccount) (err error) {
+	tx, err := p.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error beginning transaction to insert user account for user '%s': %w", account.Username, err)
+	}
+
+	if _, err = tx.ExecContext(ctx, p.sqlInsertUserAccount, account.Username, account.Password, account.DisplayName, account.Email); err != nil {
+		return p.rollback(tx, fmt.Errorf("error inserting user account for user '%s': %w", account.Username, err))
+	}
+
+	if err = p.insertUserGroups(ctx, tx, account.Username, account.Groups); err != nil {
+		return p.rollback(tx, err)
+	}
+
+	return tx.Commit()
+}
+
+// LoadUserAccount loads a user account and the groups of the user account from the database given a username.
+func (p *SQLProvider) LoadUserAccount(ctx context.Context, username string) (account *model.UserAccount, err error) {
+	account = &model.UserAccount{}
+
+	if err = p.db.GetContext(ctx, account, p.sqlSelectUserAccount, username); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNoUserAccount
+		}
+
+		return nil, fmt.Errorf("error selecting user account for user '%s': %w", username, err)
+	}
+
+	if err = p.db.SelectContext(ctx, &account.Groups, p.sqlSelectUserGroups, username); err != nil {
+		return nil, fmt.Errorf("error selecting groups for user '%s': %w", username, err)
+	}
+
+	return account, nil
+}
+
+// UpdateUserAccountPassword updates the password hash of a user account in the database.
+func (p *SQLProvider) UpdateUserAccountPassword(ctx context.Context, username, password string) (err error) {
+	var result sql.Result
+
+	if result, err = p.db.ExecContext(ctx, p.sqlUpdateUserAccountPassword, password, username); err != nil {
+		return fmt.Errorf("error updating user account password for user '%s': %w", username, err)
+	}
+
+	return userAccountResultAffected(result, username)
+}
+
+// ReplaceUserAccountPassword updates the password hash of a user account in the database provided the password hash is
+// still the old password hash. It returns false if the user account doesn't exist or the password hash has changed.
+func (p *SQLProvider) ReplaceUserAccountPassword(ctx context.Context, username, oldPassword, newPassword string) (replaced bool, err error) {
+	var (
+		result   sql.Result
+		affected int64
+	)
+
+	if result, err = p.db.ExecContext(ctx, p.sqlReplaceUserAccountPassword, newPassword, username, oldPassword); err != nil {
+		return false, fmt.Errorf("error replacing user account password for user '%s': %w", username, err)
+	}
+
+	if affected, err = result.RowsAffected(); err != nil {
+		return false, fmt.Errorf("error checking the affected rows for user account of user '%s': %w", username, err)
+	}
+
+	return affected != 0, nil
+}
+
+// UpdateUserAccountGroups replaces the groups of a user account in the database.
+func (p *SQLProvider) UpdateUserAccountGroups(ctx context.Context, username string, groups []string) (err error) {
+	if _, err = p.LoadUserAccount(ctx, username); err != nil {
+		return err
+	}
+
+	tx, err := p.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error beginning transaction to update groups for user '%s': %w", username, err)
+	}
+
+	if _, err = tx.ExecContext(ctx, p.sqlDeleteUserGroups, username); err != nil {
+		return p.rollback(tx, fmt.Errorf("error deleting groups for user '%s': %w", username, err))
+	}
+
+	if err = p.insertUserGroups(ctx, tx, username, groups); err != nil {
+		return p.rollback(tx, err)
+	}
+
+	return tx.Commit()
+}
+
+// DeleteUserAccount deletes a user account and the groups of the user account from the database.
+func (p *SQLProvider) DeleteUserAccount(ctx context.Context, username string) (err error) {
+	tx, err := p.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error beginning transaction to delete user account for user '%s': %w", username, err)
+	}
+
+	if _, err = tx.ExecContext(ctx, p.sqlDeleteUserGroups, username); err != nil {
+		return p.rollback(tx, fmt.Errorf("error deleting groups for user '%s': %w", username, err))
+	}
+
+	if _, err = tx.ExecContext(ctx, p.sqlDeleteUserPasswordHistory, username); err != nil {
+		return p.rollback(tx, fmt.Errorf("error deleting password history for user '%s': %w", username, err))
+	}
+
+	var result sql.Result
+
+	if result, err = tx.ExecContext(ctx, p.sqlDeleteUserAccount, username); err != nil {
+		return p.rollback(tx, fmt.Errorf("error deleting user account for user '%s': %w", username, err))
+	}
+
+	if err = userAccountResultAffected(result, username); err != nil {
+		return p.rollback(tx, err)
+	}
+
+	return tx.Commit()
+}
+
+// SaveUserPasswordHistory saves a password hash to the password history of a user in the database and deletes the
+// entries which are older than the most recent entries to keep.
+func (p *SQLProvider) SaveUserPasswordHistory(ctx context.Context, history model.UserPasswordHistory, keep int) (err error) {
+	tx, err := p.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error beginning transaction to insert password history for user '%s': %w", history.Username, err)
+	}
+
+	if _, err = tx.ExecContext(ctx, p.sqlInsertUserPasswordHistory, history.Username, history.Password); err != nil {
+		return p.rollback(tx, fmt.Errorf("error inserting password history for user '%s': %w", history.Username, err))
+	}
+
+	var oldest int
+
+	if err = tx.GetContext(ctx, &oldest, p.sqlSelectUserPasswordHistoryOldestID, history.Username, keep-1); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return tx.Commit()
+		}
+
+		return p.rollback(tx, fmt.Errorf("error selecting password history for user '%s': %w", history.Username, err))
+	}
+
+	if _, err = tx.ExecContext(ctx, p.sqlDeleteUserPasswordHistoryBefore, history.Username, oldest); err != nil {
+		return p.rollback(tx, fmt.Errorf("error deleting password history for user '%s': %w", history.Username, err))
+	}
+
+	return tx.Commit()
+}
+
+// LoadUserPasswordHistory loads the most recent entries of the password history of a user from the database.
+func (p *SQLProvider) LoadUserPasswordHistory(ctx context.Context, username string, limit int) (history []model.UserPasswordHistory, err error) {
+	if err = p.db.SelectContext(ctx, &history, p.sqlSelectUserPasswordHistory, username, limit); err != nil {
+		return nil, fmt.Errorf("error selecting password history for user '%s': %w", username, err)
+	}
+
+	return history, nil
+}
+
+func (p *SQLProvider) insertUserGroups(ctx context.Context, tx *sqlx.Tx, username string, groups []string) (err error) {
+	for _, group := range groups {
+		if _, err = tx.ExecContext(ctx, p.sqlInsertUserGroup, username, group); err != nil {
+			return fmt.Errorf("error inserting group '%s' for user '%s': %w", group, username, err)
+		}
+	}
+
+	return nil
+}
+
+func (p *SQLProvider) rollback(tx *sqlx.Tx, err error) error {
+	if rollbackErr := tx.Rollback(); rollbackErr != nil {
+		return fmt.Errorf("rollback error %v: rollback due to error: %w", rollbackErr, err)
+	}
+
+	return err
+}
+
+func userAccountResultAffected(result sql.Result, username string) (err error) {
+	var affected int64
+
+	if affected, err = result.RowsAffected(); err != nil {
+		return fmt.Errorf("error checking the affected rows for user account of user '%s': %w", username, err)
+	}
+
+	if affected == 0 {
+		return ErrNoUserAccount
+	}
+
+	return nil
+}
+
 // SaveUserOpaqueIdentifier saves a new opaque user identifier to the database.
 func (p *SQLProvider) SaveUserOpaqueIdentifier(ctx context.Context, opaqueID model.UserOpaqueIdentifier) (err error) {
 	if _, err = p.db.ExecContext(ctx, p.sqlInsertUserOpaqueIdentifier, opaqueID.Service, opaqueID.SectorID, opaqueID.Username, opaqueID.Identifier); err != nil {
@@ -933,7 +1155,7 @@ func (p *SQLProvider) LoadPreferredDuoDevice(ctx context.Context, username strin
 func (p *SQLProvider) AppendAuthenticationLog(ctx context.Context, attempt model.AuthenticationAttempt) (err error) {
 	if _, err = p.db.ExecContext(ctx, p.sqlInsertAuthenticationAttempt,
 		attempt.Time, attempt.Successful, attempt.Banned, attempt.Username,
-		attempt.Type, attempt.RemoteIP, attempt.RequestURI, attempt.RequestMethod); err != nil {
+		attempt.Type, attempt.RemoteIP, attempt.RequestURI, attempt.RequestMethod, attempt.Country, attempt.ASN); err != nil {
 		return fmt.Errorf("error inserting authentication attempt for user '%s': %w", attempt.Username, err)
 	}
 
@@ -39,6 +39,21 @@ func NewPostgreSQLProvider(config *schema.Configuration) (provider *PostgreSQLPr
 	provider.sqlSelectPreferred2FAMethod = provider.db.Rebind(provider.sqlSelectPreferred2FAMethod)
 	provider.sqlSelectUserInfo = provider.db.Rebind(provider.sqlSelectUserInfo)
 
+	provider.sqlSelectUserAccount = provider.db.Rebind(provider.sqlSelectUserAccount)
+	provider.sqlInsertUserAccount = provider.db.Rebind(provider.sqlInsertUserAccount)
+	provider.sqlUpdateUserAccountPassword = provider.db.Rebind(provider.sqlUpdateUserAccountPassword)
+	provider.sqlReplaceUserAccountPassword = provider.db.Rebind(provider.sqlReplaceUserAccountPassword)
+	provider.sqlDeleteUserAccount = provider.db.Rebind(provider.sqlDeleteUserAccount)
+	provider.sqlSelectUserGroups = provider.db.Rebind(provider.sqlSelectUserGroups)
+	provider.sqlInsertUserGroup = provider.db.Rebind(provider.sqlInsertUserGroup)
+	provider.sqlDeleteUserGroups = provider.db.Rebind(provider.sqlDeleteUserGroups)
+
+	provider.sqlSelectUserPasswordHistory = provider.db.Rebind(provider.sqlSelectUserPasswordHistory)
+	provider.sqlInsertUserPasswordHistory = provider.db.Rebind(provider.sqlInsertUserPasswordHistory)
+	provider.sqlSelectUserPasswordHistoryOldestID = provider.db.Rebind(provider.sqlSelectUserPasswordHistoryOldestID)
+	provider.sqlDeleteUserPasswordHistoryBefore = provider.db.Rebind(provider.sqlDeleteUserPasswordHistoryBefore)
+	provider.sqlDeleteUserPasswordHistory = provider.db.Rebind(provider.sqlDeleteUserPasswordHistory)
+
 	provider.sqlInsertUserOpaqueIdentifier = provider.db.Rebind(provider.sqlInsertUserOpaqueIdentifier)
 	provider.sqlSelectUserOpaqueIdentifier = provider.db.Rebind(provider.sqlSelectUserOpaqueIdentifier)
 	provider.sqlSelectUserOpaqueIdentifierBySignature = provider.db.Rebind(provider.sqlSelectUserOpaqueIdentifierBySignature)
@@ -192,8 +192,8 @@ const (
 
 const (
 	queryFmtInsertAuthenticationLogEntry = `
-		INSERT INTO %s (time, successful, banned, username, auth_type, remote_ip, request_uri, request_method)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?);`
+		INSERT INTO %s (time, successful, banned, username, auth_type, remote_ip, request_uri, request_method, country, asn)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?);`
 
 	queryFmtSelect1FAAuthenticationLogEntryByUsername = `
 		SELECT time, successful, username
@@ -304,6 +304,77 @@ const (
 			DO UPDATE SET expires_at = $2;`
 )
 
+const (
+	queryFmtSelectUserAccount = `
+		SELECT id, created_at, updated_at, username, password, display_name, email
+		FROM %s
+		WHERE username = ?;`
+
+	queryFmtInsertUserAccount = `
+		INSERT INTO %s (username, password, display_name, email)
+		VALUES (?, ?, ?, ?);`
+
+	//nolint:gosec // These are not hardcoded credentials it's a query to update credentials.
+	queryFmtUpdateUserAccountPassword = `
+		UPDATE %s
+		SET password = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE username = ?;`
+
+	//nolint:gosec // These are not hardcoded credentials it's a query to update credentials.
+	queryFmtReplaceUserAccountPassword = `
+		UPDATE %s
+		SET password = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE username = ? AND password = ?;`
+
+	queryFmtDeleteUserAccount = `
+		DELETE FROM %s
+		WHERE username = ?;`
+
+	queryFmtSelectUserGroups = `
+		SELECT group_name
+		FROM %s
+		WHERE username = ?
+		ORDER BY id;`
+
+	queryFmtInsertUserGroup = `
+		INSERT INTO %s (username, group_name)
+		VALUES (?, ?);`
+
+	queryFmtDeleteUserGroups = `
+		DELETE FROM %s
+		WHERE username = ?;`
+)
+
+const (
+	queryFmtSelectUserPasswordHistory = `
+		SELECT id, created_at, username, password
+		FROM %s
+		WHERE username = ?
+		ORDER BY id DESC
+		LIMIT ?;`
+
+	queryFmtInsertUserPasswordHistory = `
+		INSERT INTO %s (username, password)
+		VALUES (?, ?);`
+
+	// This selects the oldest entry which should be kept so the entries before it can be deleted.
+	queryFmtSelectUserPasswordHistoryOldestID = `
+		SELECT id
+		FROM %s
+		WHERE username = ?
+		ORDER BY id DESC
+		LIMIT 1
+		OFFSET ?;`
+
+	queryFmtDeleteUserPasswordHistoryBefore = `
+		DELETE FROM %s
+		WHERE username = ? AND id < ?;`
+
+	queryFmtDeleteUserPasswordHistory = `
+		DELETE FROM %s
+		WHERE username = ?;`
+)
+
 const (
 	queryFmtInsertUserOpaqueIdentifier = `
 		INSERT INTO %s (service, sector_id, username, identifier)
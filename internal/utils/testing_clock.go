@@ -0,0 +1,26 @@
+package utils
+
+import (
+	"time"
+)
+
+// TestingClock implementation of clock for tests. It lives in this package rather than the mocks package so the tests
+// of the packages the mocks package imports are able to use it.
+type TestingClock struct {
+	now time.Time
+}
+
+// Now return the stored clock.
+func (dc *TestingClock) Now() time.Time {
+	return dc.now
+}
+
+// After return a channel receiving the time after duration has elapsed.
+func (dc *TestingClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// Set set the time of the clock.
+func (dc *TestingClock) Set(now time.Time) {
+	dc.now = now
+}
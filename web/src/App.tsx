@@ -11,8 +11,11 @@ import {
     ConsentRoute,
     IndexRoute,
     LogoutRoute,
+    RegisterFinishRoute,
     RegisterOneTimePasswordRoute,
+    RegisterRoute,
     RegisterWebauthnRoute,
+    ResetPasswordRequiredRoute,
     ResetPasswordStep1Route,
     ResetPasswordStep2Route,
 } from "@constants/Routes";
@@ -22,6 +25,7 @@ import * as themes from "@themes/index";
 import { getBasePath } from "@utils/BasePath";
 import {
     getDuoSelfEnrollment,
+    getRegistration,
     getRememberMe,
     getResetPassword,
     getResetPasswordCustomURL,
@@ -33,6 +37,8 @@ import BaseLoadingPage from "@views/LoadingPage/BaseLoadingPage";
 import ConsentView from "@views/LoginPortal/ConsentView/ConsentView";
 import LoginPortal from "@views/LoginPortal/LoginPortal";
 import SignOut from "@views/LoginPortal/SignOut/SignOut";
+import Register from "@views/Registration/Register";
+import RegisterFinish from "@views/Registration/RegisterFinish";
 import ResetPasswordStep1 from "@views/ResetPassword/ResetPasswordStep1";
 import ResetPasswordStep2 from "@views/ResetPassword/ResetPasswordStep2";
 
@@ -89,6 +95,9 @@ const App: React.FC<Props> = (props: Props) => {
                             <Routes>
                                 <Route path={ResetPasswordStep1Route} element={<ResetPasswordStep1 />} />
                                 <Route path={ResetPasswordStep2Route} element={<ResetPasswordStep2 />} />
+                                <Route path={ResetPasswordRequiredRoute} element={<ResetPasswordStep2 required />} />
+                                <Route path={RegisterRoute} element={<Register />} />
+                                <Route path={RegisterFinishRoute} element={<RegisterFinish />} />
                                 <Route path={RegisterWebauthnRoute} element={<RegisterWebauthn />} />
                                 <Route path={RegisterOneTimePasswordRoute} element={<RegisterOneTimePassword />} />
                                 <Route path={LogoutRoute} element={<SignOut />} />
@@ -101,6 +110,7 @@ const App: React.FC<Props> = (props: Props) => {
                                             rememberMe={getRememberMe()}
                                             resetPassword={getResetPassword()}
                                             resetPasswordCustomURL={getResetPasswordCustomURL()}
+                                            registration={getRegistration()}
                                         />
                                     }
                                 />
@@ -18,6 +18,7 @@ it("renders without crashing", () => {
                 require_number: false,
                 require_special: false,
                 require_uppercase: false,
+                breached: false,
                 mode: PasswordPolicyMode.Standard,
             }}
         />,
@@ -36,6 +37,7 @@ it("renders adjusted height without crashing", () => {
                 require_number: false,
                 require_special: false,
                 require_uppercase: false,
+                breached: false,
                 mode: PasswordPolicyMode.Standard,
             }}
         />,
@@ -9,6 +9,9 @@ export const SecondFactorPushSubRoute: string = "push-notification";
 
 export const ResetPasswordStep1Route: string = "/reset-password/step1";
 export const ResetPasswordStep2Route: string = "/reset-password/step2";
+export const ResetPasswordRequiredRoute: string = "/reset-password/required";
+export const RegisterRoute: string = "/register";
+export const RegisterFinishRoute: string = "/register/finish";
 export const RegisterWebauthnRoute: string = "/webauthn/register";
 export const RegisterOneTimePasswordRoute: string = "/one-time-password/register";
 export const LogoutRoute: string = "/logout";
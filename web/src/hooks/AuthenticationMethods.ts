@@ -0,0 +1,10 @@
+import queryString from "query-string";
+import { useLocation } from "react-router-dom";
+
+export function useAuthenticationMethods() {
+    const location = useLocation();
+
+    const queryParams = queryString.parse(location.search);
+
+    return queryParams && "am" in queryParams ? (queryParams["am"] as string).split(",") : undefined;
+}
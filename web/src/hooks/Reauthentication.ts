@@ -0,0 +1,23 @@
+import queryString from "query-string";
+import { useLocation } from "react-router-dom";
+
+import { AuthenticationLevel } from "@services/State";
+
+export function useReauthentication() {
+    const location = useLocation();
+
+    const queryParams = queryString.parse(location.search);
+
+    if (!queryParams || !("ra" in queryParams)) {
+        return undefined;
+    }
+
+    switch (queryParams["ra"] as string) {
+        case "1":
+            return AuthenticationLevel.OneFactor;
+        case "2":
+            return AuthenticationLevel.TwoFactor;
+        default:
+            return undefined;
+    }
+}
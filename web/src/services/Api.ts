@@ -28,6 +28,12 @@ export const CompleteResetPasswordPath = basePath + "/api/reset-password/identit
 
 // Do the password reset during completion.
 export const ResetPasswordPath = basePath + "/api/reset-password";
+
+export const InitiateRegistrationPath = basePath + "/api/register";
+export const CompleteRegistrationIdentityPath = basePath + "/api/register/identity/finish";
+
+// Create the account during completion.
+export const CompleteRegistrationPath = basePath + "/api/register/complete";
 export const ChecksSafeRedirectionPath = basePath + "/api/checks/safe-redirection";
 
 export const LogoutPath = basePath + "/api/logout";
@@ -0,0 +1,44 @@
+import { getFirstFactorFailure } from "@services/FirstFactor";
+
+function newErrorResponse(message: string) {
+    return {
+        isAxiosError: true,
+        response: { status: 401, data: { status: "KO", message } },
+    };
+}
+
+it("returns the generic failure message for unknown errors", () => {
+    expect(getFirstFactorFailure(new Error("network error"))).toEqual({
+        message: "Incorrect username or password",
+        passwordChangeRequired: false,
+    });
+
+    expect(getFirstFactorFailure(newErrorResponse("Authentication failed. Check your credentials."))).toEqual({
+        message: "Incorrect username or password",
+        passwordChangeRequired: false,
+    });
+});
+
+it("returns the message of the password policy states", () => {
+    expect(getFirstFactorFailure(newErrorResponse("Your account is locked. Contact your administrator."))).toEqual({
+        message: "Your account is locked",
+        passwordChangeRequired: false,
+    });
+
+    expect(getFirstFactorFailure(newErrorResponse("Your account is disabled. Contact your administrator."))).toEqual({
+        message: "Your account is disabled",
+        passwordChangeRequired: false,
+    });
+
+    expect(getFirstFactorFailure(newErrorResponse("Your password has expired. Contact your administrator."))).toEqual({
+        message: "Your password has expired",
+        passwordChangeRequired: false,
+    });
+});
+
+it("requires a password change when the password must be changed", () => {
+    expect(getFirstFactorFailure(newErrorResponse("Your password must be changed before you can sign in."))).toEqual({
+        message: "Your password must be changed before you can sign in",
+        passwordChangeRequired: true,
+    });
+});
@@ -1,4 +1,6 @@
-import { FirstFactorPath } from "@services/Api";
+import axios from "axios";
+
+import { ErrorResponse, FirstFactorPath } from "@services/Api";
 import { PostWithOptionalResponse } from "@services/Client";
 import { SignInResponse } from "@services/SignIn";
 
@@ -40,3 +42,36 @@ export async function postFirstFactor(
     const res = await PostWithOptionalResponse<SignInResponse>(FirstFactorPath, data);
     return res ? res : ({} as SignInResponse);
 }
+
+// The messages of the first factor failures reported by the server which are shown to the user instead of the generic
+// failure message, mapped to the translation keys of the messages.
+export const FirstFactorPasswordChangeRequiredMessage = "Your password must be changed before you can sign in.";
+
+const firstFactorFailureMessages: Record<string, string> = {
+    "Your password has expired. Contact your administrator.": "Your password has expired",
+    [FirstFactorPasswordChangeRequiredMessage]: "Your password must be changed before you can sign in",
+    "Your account is locked. Contact your administrator.": "Your account is locked",
+    "Your account is disabled. Contact your administrator.": "Your account is disabled",
+};
+
+export interface FirstFactorFailure {
+    message: string;
+    passwordChangeRequired: boolean;
+}
+
+// getFirstFactorFailure returns the translation key of the message to show to the user for an error returned by
+// postFirstFactor and whether the user must change their password before they can sign in.
+export function getFirstFactorFailure(err: unknown): FirstFactorFailure {
+    if (axios.isAxiosError(err)) {
+        const data = err.response?.data as ErrorResponse | undefined;
+
+        if (data && data.status === "KO" && data.message in firstFactorFailureMessages) {
+            return {
+                message: firstFactorFailureMessages[data.message],
+                passwordChangeRequired: data.message === FirstFactorPasswordChangeRequiredMessage,
+            };
+        }
+    }
+
+    return { message: "Incorrect username or password", passwordChangeRequired: false };
+}
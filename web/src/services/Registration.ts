@@ -0,0 +1,14 @@
+import { CompleteRegistrationIdentityPath, CompleteRegistrationPath, InitiateRegistrationPath } from "@services/Api";
+import { PostWithOptionalResponse } from "@services/Client";
+
+export async function initiateRegistrationProcess(username: string, displayname: string, email: string) {
+    return PostWithOptionalResponse(InitiateRegistrationPath, { username, displayname, email });
+}
+
+export async function completeRegistrationProcess(token: string) {
+    return PostWithOptionalResponse(CompleteRegistrationIdentityPath, { token });
+}
+
+export async function completeRegistration(password: string) {
+    return PostWithOptionalResponse(CompleteRegistrationPath, { password });
+}
@@ -10,6 +10,7 @@ export enum AuthenticationLevel {
 export interface AutheliaState {
     username: string;
     authentication_level: AuthenticationLevel;
+    authentication_methods: string[] | null;
 }
 
 export async function getState(): Promise<AutheliaState> {
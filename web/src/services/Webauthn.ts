@@ -227,10 +227,16 @@ async function getAttestationCreationOptions(token: string): Promise<PublicKeyCr
     };
 }
 
-export async function getAssertionRequestOptions(): Promise<PublicKeyCredentialRequestOptionsStatus> {
+export async function getAssertionRequestOptions(
+    authenticationMethods?: string[],
+): Promise<PublicKeyCredentialRequestOptionsStatus> {
     let response: AxiosResponse<ServiceResponse<CredentialRequest>>;
 
-    response = await axios.get<ServiceResponse<CredentialRequest>>(WebauthnAssertionPath);
+    // The authentication methods the user is stepping up to are sent so the authenticator is asked to verify the user
+    // when it's required.
+    response = await axios.get<ServiceResponse<CredentialRequest>>(WebauthnAssertionPath, {
+        params: authenticationMethods ? { am: authenticationMethods.join(",") } : undefined,
+    });
 
     if (response.data.status !== "OK" || response.data.data == null) {
         return {
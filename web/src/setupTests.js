@@ -5,4 +5,5 @@ document.body.setAttribute("data-duoselfenrollment", "true");
 document.body.setAttribute("data-rememberme", "true");
 document.body.setAttribute("data-resetpassword", "true");
 document.body.setAttribute("data-resetpasswordcustomurl", "");
+document.body.setAttribute("data-registration", "false");
 document.body.setAttribute("data-theme", "light");
@@ -27,6 +27,10 @@ export function getResetPasswordCustomURL() {
     return getEmbeddedVariable("resetpasswordcustomurl");
 }
 
+export function getRegistration() {
+    return getEmbeddedVariable("registration") === "true";
+}
+
 export function getTheme() {
     return getEmbeddedVariable("theme");
 }
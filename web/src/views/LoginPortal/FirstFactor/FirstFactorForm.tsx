@@ -8,13 +8,13 @@ import { useTranslation } from "react-i18next";
 import { useNavigate } from "react-router-dom";
 
 import FixedTextField from "@components/FixedTextField";
-import { ResetPasswordStep1Route } from "@constants/Routes";
+import { RegisterRoute, ResetPasswordRequiredRoute, ResetPasswordStep1Route } from "@constants/Routes";
 import { useNotifications } from "@hooks/NotificationsContext";
 import { useRedirectionURL } from "@hooks/RedirectionURL";
 import { useRequestMethod } from "@hooks/RequestMethod";
 import { useWorkflow } from "@hooks/Workflow";
 import LoginLayout from "@layouts/LoginLayout";
-import { postFirstFactor } from "@services/FirstFactor";
+import { getFirstFactorFailure, postFirstFactor } from "@services/FirstFactor";
 
 export interface Props {
     disabled: boolean;
@@ -23,6 +23,8 @@ export interface Props {
     resetPassword: boolean;
     resetPasswordCustomURL: string;
 
+    registration: boolean;
+
     onAuthenticationStart: () => void;
     onAuthenticationFailure: () => void;
     onAuthenticationSuccess: (redirectURL: string | undefined) => void;
@@ -86,8 +88,13 @@ const FirstFactorForm = function (props: Props) {
             props.onAuthenticationSuccess(res ? res.redirect : undefined);
         } catch (err) {
             console.error(err);
-            createErrorNotification(translate("Incorrect username or password"));
+            const failure = getFirstFactorFailure(err);
+            createErrorNotification(translate(failure.message));
             props.onAuthenticationFailure();
+            if (failure.passwordChangeRequired) {
+                navigate(ResetPasswordRequiredRoute);
+                return;
+            }
             setPassword("");
             passwordRef.current.focus();
         }
@@ -103,6 +110,10 @@ const FirstFactorForm = function (props: Props) {
         }
     };
 
+    const handleRegisterClick = () => {
+        navigate(RegisterRoute);
+    };
+
     return (
         <LoginLayout id="first-factor-stage" title={translate("Sign in")} showBrand>
             <Grid container spacing={2}>
@@ -205,17 +216,32 @@ const FirstFactorForm = function (props: Props) {
                         {translate("Sign in")}
                     </Button>
                 </Grid>
-                {props.resetPassword ? (
-                    <Grid item xs={12} className={classnames(styles.actionRow, styles.flexEnd)}>
-                        <Link
-                            id="reset-password-button"
-                            component="button"
-                            onClick={handleResetPasswordClick}
-                            className={styles.resetLink}
-                            underline="hover"
-                        >
-                            {translate("Reset password?")}
-                        </Link>
+                {props.resetPassword || props.registration ? (
+                    <Grid item xs={12} className={classnames(styles.actionRow, styles.spaceBetween)}>
+                        {props.registration ? (
+                            <Link
+                                id="register-button"
+                                component="button"
+                                onClick={handleRegisterClick}
+                                className={styles.resetLink}
+                                underline="hover"
+                            >
+                                {translate("Register")}
+                            </Link>
+                        ) : (
+                            <span />
+                        )}
+                        {props.resetPassword ? (
+                            <Link
+                                id="reset-password-button"
+                                component="button"
+                                onClick={handleResetPasswordClick}
+                                className={styles.resetLink}
+                                underline="hover"
+                            >
+                                {translate("Reset password?")}
+                            </Link>
+                        ) : null}
                     </Grid>
                 ) : null}
             </Grid>
@@ -240,7 +266,7 @@ const useStyles = makeStyles((theme: Theme) => ({
     rememberMe: {
         flexGrow: 1,
     },
-    flexEnd: {
-        justifyContent: "flex-end",
+    spaceBetween: {
+        justifyContent: "space-between",
     },
 }));
@@ -10,9 +10,11 @@ import {
     SecondFactorTOTPSubRoute,
     SecondFactorWebauthnSubRoute,
 } from "@constants/Routes";
+import { useAuthenticationMethods } from "@hooks/AuthenticationMethods";
 import { useConfiguration } from "@hooks/Configuration";
 import { useNotifications } from "@hooks/NotificationsContext";
 import { useRedirectionURL } from "@hooks/RedirectionURL";
+import { useReauthentication } from "@hooks/Reauthentication";
 import { useRedirector } from "@hooks/Redirector";
 import { useRequestMethod } from "@hooks/RequestMethod";
 import { useAutheliaState } from "@hooks/State";
@@ -32,19 +34,27 @@ export interface Props {
 
     resetPassword: boolean;
     resetPasswordCustomURL: string;
+
+    registration: boolean;
 }
 
 const RedirectionErrorMessage =
     "Redirection was determined to be unsafe and aborted. Ensure the redirection URL is correct.";
 
+const ReauthenticationMessage =
+    "The resource you are accessing requires a more recent authentication. Please authenticate again to continue.";
+
 const LoginPortal = function (props: Props) {
     const navigate = useNavigate();
     const location = useLocation();
     const redirectionURL = useRedirectionURL();
     const requestMethod = useRequestMethod();
     const workflow = useWorkflow();
-    const { createErrorNotification } = useNotifications();
+    const authenticationMethods = useAuthenticationMethods();
+    const reauthentication = useReauthentication();
+    const { createInfoNotification, createErrorNotification } = useNotifications();
     const [firstFactorDisabled, setFirstFactorDisabled] = useState(true);
+    const [reauthenticated, setReauthenticated] = useState(false);
     const [broadcastRedirect, setBroadcastRedirect] = useState(false);
     const redirector = useRedirector();
 
@@ -52,6 +62,33 @@ const LoginPortal = function (props: Props) {
     const [userInfo, fetchUserInfo, , fetchUserInfoError] = useUserInfoPOST();
     const [configuration, fetchConfiguration, , fetchConfigurationError] = useConfiguration();
 
+    // The target requires the user to step up by completing one of the listed authentication methods which they haven't
+    // completed yet in this session.
+    const stepUpRequired =
+        state !== undefined &&
+        authenticationMethods !== undefined &&
+        state.authentication_level === AuthenticationLevel.TwoFactor &&
+        !authenticationMethods.some((method) => (state.authentication_methods ?? []).includes(method));
+
+    // The target requires the user to complete a factor again as it was completed too long ago.
+    const reauthenticationRequired =
+        state !== undefined &&
+        reauthentication !== undefined &&
+        !reauthenticated &&
+        state.authentication_level >= reauthentication;
+
+    // The authentication level the portal treats the user as having when deciding which stage to display.
+    let authenticationLevel = state ? state.authentication_level : AuthenticationLevel.Unauthenticated;
+
+    if (reauthenticationRequired) {
+        authenticationLevel =
+            reauthentication === AuthenticationLevel.OneFactor
+                ? AuthenticationLevel.Unauthenticated
+                : AuthenticationLevel.OneFactor;
+    } else if (stepUpRequired) {
+        authenticationLevel = AuthenticationLevel.OneFactor;
+    }
+
     const redirect = useCallback(
         (pathname: string, search?: string) => {
             if (search) {
@@ -78,10 +115,17 @@ const LoginPortal = function (props: Props) {
 
     // Enable first factor when user is unauthenticated.
     useEffect(() => {
-        if (state && state.authentication_level > AuthenticationLevel.Unauthenticated) {
+        if (state && authenticationLevel > AuthenticationLevel.Unauthenticated) {
             setFirstFactorDisabled(true);
         }
-    }, [state, setFirstFactorDisabled]);
+    }, [state, authenticationLevel, setFirstFactorDisabled]);
+
+    // Display the reason when the user has to authenticate again.
+    useEffect(() => {
+        if (reauthenticationRequired) {
+            createInfoNotification(ReauthenticationMessage);
+        }
+    }, [reauthenticationRequired, createInfoNotification]);
 
     // Display an error when state fetching fails
     useEffect(() => {
@@ -115,8 +159,8 @@ const LoginPortal = function (props: Props) {
                 redirectionURL &&
                 ((configuration &&
                     configuration.available_methods.size === 0 &&
-                    state.authentication_level >= AuthenticationLevel.OneFactor) ||
-                    state.authentication_level === AuthenticationLevel.TwoFactor ||
+                    authenticationLevel >= AuthenticationLevel.OneFactor) ||
+                    authenticationLevel === AuthenticationLevel.TwoFactor ||
                     broadcastRedirect)
             ) {
                 try {
@@ -135,19 +179,23 @@ const LoginPortal = function (props: Props) {
             const search = redirectionURL
                 ? `?rd=${encodeURIComponent(redirectionURL)}${requestMethod ? `&rm=${requestMethod}` : ""}${
                       workflow ? `&workflow=${workflow}` : ""
+                  }${authenticationMethods ? `&am=${encodeURIComponent(authenticationMethods.join(","))}` : ""}${
+                      reauthentication ? `&ra=${reauthentication}` : ""
                   }`
                 : undefined;
 
-            if (state.authentication_level === AuthenticationLevel.Unauthenticated) {
+            if (authenticationLevel === AuthenticationLevel.Unauthenticated) {
                 setFirstFactorDisabled(false);
                 redirect(IndexRoute, search);
-            } else if (state.authentication_level >= AuthenticationLevel.OneFactor && userInfo && configuration) {
+            } else if (authenticationLevel >= AuthenticationLevel.OneFactor && userInfo && configuration) {
                 if (configuration.available_methods.size === 0) {
                     redirect(AuthenticatedRoute);
                 } else {
-                    if (userInfo.method === SecondFactorMethod.Webauthn) {
+                    const method = stepUpMethod(authenticationMethods, userInfo.method);
+
+                    if (method === SecondFactorMethod.Webauthn) {
                         redirect(`${SecondFactorRoute}${SecondFactorWebauthnSubRoute}`, search);
-                    } else if (userInfo.method === SecondFactorMethod.MobilePush) {
+                    } else if (method === SecondFactorMethod.MobilePush) {
                         redirect(`${SecondFactorRoute}${SecondFactorPushSubRoute}`, search);
                     } else {
                         redirect(`${SecondFactorRoute}${SecondFactorTOTPSubRoute}`, search);
@@ -167,6 +215,9 @@ const LoginPortal = function (props: Props) {
         createErrorNotification,
         redirector,
         broadcastRedirect,
+        authenticationMethods,
+        reauthentication,
+        authenticationLevel,
     ]);
 
     const handleChannelStateChange = async () => {
@@ -175,6 +226,8 @@ const LoginPortal = function (props: Props) {
     };
 
     const handleAuthSuccess = async (redirectionURL: string | undefined) => {
+        setReauthenticated(true);
+
         if (redirectionURL) {
             // Do an external redirection pushed by the server.
             redirector(redirectionURL);
@@ -186,7 +239,7 @@ const LoginPortal = function (props: Props) {
 
     const firstFactorReady =
         state !== undefined &&
-        state.authentication_level === AuthenticationLevel.Unauthenticated &&
+        authenticationLevel === AuthenticationLevel.Unauthenticated &&
         location.pathname === IndexRoute;
 
     return (
@@ -200,6 +253,7 @@ const LoginPortal = function (props: Props) {
                             rememberMe={props.rememberMe}
                             resetPassword={props.resetPassword}
                             resetPasswordCustomURL={props.resetPasswordCustomURL}
+                            registration={props.registration}
                             onAuthenticationStart={() => setFirstFactorDisabled(true)}
                             onAuthenticationFailure={() => setFirstFactorDisabled(false)}
                             onAuthenticationSuccess={handleAuthSuccess}
@@ -213,7 +267,7 @@ const LoginPortal = function (props: Props) {
                 element={
                     state && userInfo && configuration ? (
                         <SecondFactorForm
-                            authenticationLevel={state.authentication_level}
+                            authenticationLevel={authenticationLevel}
                             userInfo={userInfo}
                             configuration={configuration}
                             duoSelfEnrollment={props.duoSelfEnrollment}
@@ -233,6 +287,27 @@ const LoginPortal = function (props: Props) {
 
 export default LoginPortal;
 
+// stepUpMethod returns the second factor method the user should complete to satisfy the required authentication
+// methods, preferring the method the user has selected when it's acceptable.
+function stepUpMethod(authenticationMethods: string[] | undefined, preferred: SecondFactorMethod) {
+    const methods = (authenticationMethods ?? []).map((method) => {
+        switch (method) {
+            case "duo":
+                return SecondFactorMethod.MobilePush;
+            case "totp":
+                return SecondFactorMethod.TOTP;
+            default:
+                return SecondFactorMethod.Webauthn;
+        }
+    });
+
+    if (methods.length === 0 || methods.includes(preferred)) {
+        return preferred;
+    }
+
+    return methods[0];
+}
+
 interface ComponentOrLoadingProps {
     ready: boolean;
 
@@ -6,6 +6,7 @@ import makeStyles from "@mui/styles/makeStyles";
 import FailureIcon from "@components/FailureIcon";
 import FingerTouchIcon from "@components/FingerTouchIcon";
 import LinearProgressBar from "@components/LinearProgressBar";
+import { useAuthenticationMethods } from "@hooks/AuthenticationMethods";
 import { useIsMountedRef } from "@hooks/Mounted";
 import { useRedirectionURL } from "@hooks/RedirectionURL";
 import { useTimer } from "@hooks/Timer";
@@ -40,6 +41,7 @@ const WebauthnMethod = function (props: Props) {
     const [state, setState] = useState(State.WaitTouch);
     const styles = useStyles();
     const redirectionURL = useRedirectionURL();
+    const authenticationMethods = useAuthenticationMethods();
     const mounted = useIsMountedRef();
     const [timerPercent, triggerTimer] = useTimer(signInTimeout * 1000 - 500);
 
@@ -56,7 +58,7 @@ const WebauthnMethod = function (props: Props) {
         try {
             triggerTimer();
             setState(State.WaitTouch);
-            const assertionRequestResponse = await getAssertionRequestOptions();
+            const assertionRequestResponse = await getAssertionRequestOptions(authenticationMethods);
 
             if (assertionRequestResponse.status !== 200 || assertionRequestResponse.options == null) {
                 setState(State.Failure);
@@ -135,6 +137,7 @@ const WebauthnMethod = function (props: Props) {
         onSignInErrorCallback,
         onSignInSuccessCallback,
         redirectionURL,
+        authenticationMethods,
         mounted,
         triggerTimer,
         props.authenticationLevel,
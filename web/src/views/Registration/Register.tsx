@@ -0,0 +1,142 @@
+import React, { useState } from "react";
+
+import { Button, Grid, Theme } from "@mui/material";
+import makeStyles from "@mui/styles/makeStyles";
+import { useTranslation } from "react-i18next";
+import { useNavigate } from "react-router-dom";
+
+import FixedTextField from "@components/FixedTextField";
+import { IndexRoute } from "@constants/Routes";
+import { useNotifications } from "@hooks/NotificationsContext";
+import LoginLayout from "@layouts/LoginLayout";
+import { initiateRegistrationProcess } from "@services/Registration";
+
+const Register = function () {
+    const styles = useStyles();
+    const [username, setUsername] = useState("");
+    const [displayName, setDisplayName] = useState("");
+    const [email, setEmail] = useState("");
+    const [errorUsername, setErrorUsername] = useState(false);
+    const [errorEmail, setErrorEmail] = useState(false);
+    const { createInfoNotification, createErrorNotification } = useNotifications();
+    const navigate = useNavigate();
+    const { t: translate } = useTranslation();
+
+    const doInitiateRegistrationProcess = async () => {
+        if (username === "" || email === "") {
+            setErrorUsername(username === "");
+            setErrorEmail(email === "");
+            return;
+        }
+
+        try {
+            await initiateRegistrationProcess(username, displayName, email);
+            createInfoNotification(translate("An email has been sent to your address to complete the process"));
+        } catch (err) {
+            console.error(err);
+            if ((err as Error).message.includes("username")) {
+                setErrorUsername(true);
+                createErrorNotification(translate("The username is not available"));
+            } else if ((err as Error).message.includes("email address")) {
+                setErrorEmail(true);
+                createErrorNotification(translate("Registration is not allowed with this email address"));
+            } else {
+                createErrorNotification(translate("There was a problem initiating the registration process"));
+            }
+        }
+    };
+
+    const handleRegisterClick = () => {
+        doInitiateRegistrationProcess();
+    };
+
+    const handleCancelClick = () => {
+        navigate(IndexRoute);
+    };
+
+    const handleKeyPress = (ev: React.KeyboardEvent) => {
+        if (ev.key === "Enter") {
+            doInitiateRegistrationProcess();
+            ev.preventDefault();
+        }
+    };
+
+    return (
+        <LoginLayout title={translate("Register")} id="register-stage">
+            <Grid container className={styles.root} spacing={2}>
+                <Grid item xs={12}>
+                    <FixedTextField
+                        id="username-textfield"
+                        label={translate("Username")}
+                        variant="outlined"
+                        fullWidth
+                        required
+                        error={errorUsername}
+                        value={username}
+                        autoComplete="username"
+                        onChange={(e) => setUsername(e.target.value)}
+                        onKeyPress={handleKeyPress}
+                    />
+                </Grid>
+                <Grid item xs={12}>
+                    <FixedTextField
+                        id="displayname-textfield"
+                        label={translate("Display name")}
+                        variant="outlined"
+                        fullWidth
+                        value={displayName}
+                        autoComplete="name"
+                        onChange={(e) => setDisplayName(e.target.value)}
+                        onKeyPress={handleKeyPress}
+                    />
+                </Grid>
+                <Grid item xs={12}>
+                    <FixedTextField
+                        id="email-textfield"
+                        label={translate("Email")}
+                        variant="outlined"
+                        type="email"
+                        fullWidth
+                        required
+                        error={errorEmail}
+                        value={email}
+                        autoComplete="email"
+                        onChange={(e) => setEmail(e.target.value)}
+                        onKeyPress={handleKeyPress}
+                    />
+                </Grid>
+                <Grid item xs={6}>
+                    <Button
+                        id="register-button"
+                        variant="contained"
+                        color="primary"
+                        fullWidth
+                        onClick={handleRegisterClick}
+                    >
+                        {translate("Register")}
+                    </Button>
+                </Grid>
+                <Grid item xs={6}>
+                    <Button
+                        id="cancel-button"
+                        variant="contained"
+                        color="primary"
+                        fullWidth
+                        onClick={handleCancelClick}
+                    >
+                        {translate("Cancel")}
+                    </Button>
+                </Grid>
+            </Grid>
+        </LoginLayout>
+    );
+};
+
+export default Register;
+
+const useStyles = makeStyles((theme: Theme) => ({
+    root: {
+        marginTop: theme.spacing(2),
+        marginBottom: theme.spacing(2),
+    },
+}));
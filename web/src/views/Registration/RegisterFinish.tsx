@@ -0,0 +1,208 @@
+import React, { useCallback, useEffect, useState } from "react";
+
+import { Visibility, VisibilityOff } from "@mui/icons-material";
+import { Button, Grid, IconButton, InputAdornment, Theme } from "@mui/material";
+import makeStyles from "@mui/styles/makeStyles";
+import classnames from "classnames";
+import { useTranslation } from "react-i18next";
+import { useLocation, useNavigate } from "react-router-dom";
+
+import FixedTextField from "@components/FixedTextField";
+import PasswordMeter from "@components/PasswordMeter";
+import { IndexRoute } from "@constants/Routes";
+import { useNotifications } from "@hooks/NotificationsContext";
+import LoginLayout from "@layouts/LoginLayout";
+import { PasswordPolicyConfiguration, PasswordPolicyMode } from "@models/PasswordPolicy";
+import { getPasswordPolicyConfiguration } from "@services/PasswordPolicyConfiguration";
+import { completeRegistration, completeRegistrationProcess } from "@services/Registration";
+import { extractIdentityToken } from "@utils/IdentityToken";
+
+const RegisterFinish = function () {
+    const styles = useStyles();
+    const location = useLocation();
+    const [formDisabled, setFormDisabled] = useState(true);
+    const [password1, setPassword1] = useState("");
+    const [password2, setPassword2] = useState("");
+    const [errorPassword1, setErrorPassword1] = useState(false);
+    const [errorPassword2, setErrorPassword2] = useState(false);
+    const { createSuccessNotification, createErrorNotification } = useNotifications();
+    const { t: translate } = useTranslation();
+    const navigate = useNavigate();
+    const [showPassword, setShowPassword] = useState(false);
+
+    const [pPolicy, setPPolicy] = useState<PasswordPolicyConfiguration>({
+        max_length: 0,
+        min_length: 8,
+        min_score: 0,
+        require_lowercase: false,
+        require_number: false,
+        require_special: false,
+        require_uppercase: false,
+        breached: false,
+        mode: PasswordPolicyMode.Disabled,
+    });
+
+    // Get the token from the query param to give it back to the API when completing the identity verification.
+    const processToken = extractIdentityToken(location.search);
+
+    const completeProcess = useCallback(async () => {
+        if (!processToken) {
+            setFormDisabled(true);
+            createErrorNotification(translate("No verification token provided"));
+            return;
+        }
+
+        try {
+            setFormDisabled(true);
+            await completeRegistrationProcess(processToken);
+            const policy = await getPasswordPolicyConfiguration();
+            setPPolicy(policy);
+            setFormDisabled(false);
+        } catch (err) {
+            console.error(err);
+            createErrorNotification(
+                translate("There was an issue completing the process. The verification token might have expired"),
+            );
+            setFormDisabled(true);
+        }
+    }, [processToken, createErrorNotification, translate]);
+
+    useEffect(() => {
+        completeProcess();
+    }, [completeProcess]);
+
+    const doRegister = async () => {
+        if (password1 === "" || password2 === "") {
+            if (password1 === "") {
+                setErrorPassword1(true);
+            }
+            if (password2 === "") {
+                setErrorPassword2(true);
+            }
+            return;
+        }
+        if (password1 !== password2) {
+            setErrorPassword1(true);
+            setErrorPassword2(true);
+            createErrorNotification(translate("Passwords do not match"));
+            return;
+        }
+
+        try {
+            await completeRegistration(password1);
+            createSuccessNotification(translate("Your account has been registered"));
+            setTimeout(() => navigate(IndexRoute), 1500);
+            setFormDisabled(true);
+        } catch (err) {
+            console.error(err);
+            if ((err as Error).message.includes("breach")) {
+                createErrorNotification(
+                    "Your supplied password has appeared in a data breach and can't be used, choose a different password.",
+                );
+            } else if ((err as Error).message.includes("policy")) {
+                createErrorNotification("Your supplied password does not meet the password policy requirements.");
+            } else if ((err as Error).message.includes("username")) {
+                createErrorNotification(translate("The username is not available"));
+            } else {
+                createErrorNotification(translate("There was an issue registering the account"));
+            }
+        }
+    };
+
+    const handleRegisterClick = () => doRegister();
+
+    const handleCancelClick = () => navigate(IndexRoute);
+
+    return (
+        <LoginLayout title={translate("Choose a password")} id="register-finish-stage">
+            <Grid container className={styles.root} spacing={2}>
+                <Grid item xs={12}>
+                    <FixedTextField
+                        id="password1-textfield"
+                        label={translate("New password")}
+                        variant="outlined"
+                        type={showPassword ? "text" : "password"}
+                        value={password1}
+                        disabled={formDisabled}
+                        onChange={(e) => setPassword1(e.target.value)}
+                        error={errorPassword1}
+                        className={classnames(styles.fullWidth)}
+                        autoComplete="new-password"
+                        InputProps={{
+                            endAdornment: (
+                                <InputAdornment position="end">
+                                    <IconButton
+                                        aria-label="toggle password visibility"
+                                        onClick={(e) => setShowPassword(!showPassword)}
+                                        edge="end"
+                                        size="large"
+                                    >
+                                        {showPassword ? <VisibilityOff></VisibilityOff> : <Visibility></Visibility>}
+                                    </IconButton>
+                                </InputAdornment>
+                            ),
+                        }}
+                    />
+                    {pPolicy.mode === PasswordPolicyMode.Disabled ? null : (
+                        <PasswordMeter value={password1} policy={pPolicy} />
+                    )}
+                </Grid>
+                <Grid item xs={12}>
+                    <FixedTextField
+                        id="password2-textfield"
+                        label={translate("Repeat new password")}
+                        variant="outlined"
+                        type={showPassword ? "text" : "password"}
+                        disabled={formDisabled}
+                        value={password2}
+                        onChange={(e) => setPassword2(e.target.value)}
+                        error={errorPassword2}
+                        onKeyPress={(ev) => {
+                            if (ev.key === "Enter") {
+                                doRegister();
+                                ev.preventDefault();
+                            }
+                        }}
+                        className={classnames(styles.fullWidth)}
+                        autoComplete="new-password"
+                    />
+                </Grid>
+                <Grid item xs={6}>
+                    <Button
+                        id="register-button"
+                        variant="contained"
+                        color="primary"
+                        disabled={formDisabled}
+                        onClick={handleRegisterClick}
+                        className={styles.fullWidth}
+                    >
+                        {translate("Register")}
+                    </Button>
+                </Grid>
+                <Grid item xs={6}>
+                    <Button
+                        id="cancel-button"
+                        variant="contained"
+                        color="primary"
+                        onClick={handleCancelClick}
+                        className={styles.fullWidth}
+                    >
+                        {translate("Cancel")}
+                    </Button>
+                </Grid>
+            </Grid>
+        </LoginLayout>
+    );
+};
+
+export default RegisterFinish;
+
+const useStyles = makeStyles((theme: Theme) => ({
+    root: {
+        marginTop: theme.spacing(2),
+        marginBottom: theme.spacing(2),
+    },
+    fullWidth: {
+        width: "100%",
+    },
+}));
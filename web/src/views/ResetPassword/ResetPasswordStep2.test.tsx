@@ -0,0 +1,43 @@
+import React from "react";
+
+import { ThemeProvider, createTheme } from "@mui/material";
+import { render, screen, waitFor } from "@testing-library/react";
+import { MemoryRouter } from "react-router-dom";
+
+import { PasswordPolicyMode } from "@models/PasswordPolicy";
+import { getPasswordPolicyConfiguration } from "@services/PasswordPolicyConfiguration";
+import { completeResetPasswordProcess } from "@services/ResetPassword";
+import ResetPasswordStep2 from "@views/ResetPassword/ResetPasswordStep2";
+
+jest.mock("@services/PasswordPolicyConfiguration");
+jest.mock("@services/ResetPassword");
+jest.mock("react-i18next", () => ({
+    useTranslation: () => ({ t: (key: string) => key }),
+}));
+
+it("allows changing a required password without a verification token", async () => {
+    (getPasswordPolicyConfiguration as jest.Mock).mockResolvedValue({
+        max_length: 0,
+        min_length: 8,
+        min_score: 0,
+        require_lowercase: false,
+        require_number: false,
+        require_special: false,
+        require_uppercase: false,
+        breached: false,
+        mode: PasswordPolicyMode.Disabled,
+    });
+
+    render(
+        <ThemeProvider theme={createTheme()}>
+            <MemoryRouter>
+                <ResetPasswordStep2 required />
+            </MemoryRouter>
+        </ThemeProvider>,
+    );
+
+    await waitFor(() => expect(screen.getByRole("button", { name: "Reset" })).toBeEnabled());
+
+    expect(screen.getByText("Change your password")).toBeInTheDocument();
+    expect(completeResetPasswordProcess).not.toHaveBeenCalled();
+});
@@ -17,7 +17,13 @@ import { getPasswordPolicyConfiguration } from "@services/PasswordPolicyConfigur
 import { completeResetPasswordProcess, resetPassword } from "@services/ResetPassword";
 import { extractIdentityToken } from "@utils/IdentityToken";
 
-const ResetPasswordStep2 = function () {
+export interface Props {
+    // required is set when the user must change their password before they can sign in, in which case the server has
+    // already permitted the password change when the user signed in and there's no verification token.
+    required?: boolean;
+}
+
+const ResetPasswordStep2 = function (props: Props) {
     const styles = useStyles();
     const location = useLocation();
     const [formDisabled, setFormDisabled] = useState(true);
@@ -38,6 +44,7 @@ const ResetPasswordStep2 = function () {
         require_number: false,
         require_special: false,
         require_uppercase: false,
+        breached: false,
         mode: PasswordPolicyMode.Disabled,
     });
 
@@ -46,6 +53,19 @@ const ResetPasswordStep2 = function () {
     const processToken = extractIdentityToken(location.search);
 
     const completeProcess = useCallback(async () => {
+        if (props.required) {
+            try {
+                const policy = await getPasswordPolicyConfiguration();
+                setPPolicy(policy);
+                setFormDisabled(false);
+            } catch (err) {
+                console.error(err);
+                createErrorNotification(translate("There was an issue retrieving the password policy"));
+                setFormDisabled(true);
+            }
+            return;
+        }
+
         if (!processToken) {
             setFormDisabled(true);
             createErrorNotification(translate("No verification token provided"));
@@ -65,7 +85,7 @@ const ResetPasswordStep2 = function () {
             );
             setFormDisabled(true);
         }
-    }, [processToken, createErrorNotification, translate]);
+    }, [props.required, processToken, createErrorNotification, translate]);
 
     useEffect(() => {
         completeProcess();
@@ -97,6 +117,10 @@ const ResetPasswordStep2 = function () {
             console.error(err);
             if ((err as Error).message.includes("0000052D.")) {
                 createErrorNotification("Your supplied password does not meet the password policy requirements.");
+            } else if ((err as Error).message.includes("breach")) {
+                createErrorNotification(
+                    "Your supplied password has appeared in a data breach and can't be used, choose a different password.",
+                );
             } else if ((err as Error).message.includes("policy")) {
                 createErrorNotification("Your supplied password does not meet the password policy requirements.");
             } else {
@@ -110,7 +134,10 @@ const ResetPasswordStep2 = function () {
     const handleCancelClick = () => navigate(IndexRoute);
 
     return (
-        <LoginLayout title={translate("Enter new password")} id="reset-password-step2-stage">
+        <LoginLayout
+            title={translate(props.required ? "Change your password" : "Enter new password")}
+            id="reset-password-step2-stage"
+        >
             <Grid container className={styles.root} spacing={2}>
                 <Grid item xs={12}>
                     <FixedTextField